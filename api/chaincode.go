@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"time"
+
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/msp"
@@ -21,6 +23,27 @@ type Chaincode interface {
 	Install(version string)
 	// Subscribe returns subscription on chaincode events
 	Subscribe(ctx context.Context) (EventCCSubscription, error)
+	// InvokeWithPolicy invokes chaincode function endorsed by peers of minimal set of organizations
+	// which have ready peers and satisfy chaincode endorsement policy
+	InvokeWithPolicy(ctx context.Context, fn string, args [][]byte, options ...DoOption) (*Result, error)
+	// SubscribeEvents subscribes on events of chaincode with presented name, all events are matched if name is empty.
+	// Delivery starts from fromBlock, or from newest block if it is nil, and resumes after last delivered block on reconnect
+	SubscribeEvents(ctx context.Context, eventName string, fromBlock *uint64) (ChaincodeEventSubscription, error)
+	// QueryBatch makes queries concurrently by up to workers goroutines, spreading them across ready peers
+	// of identity organization, and returns responses in order of requests
+	QueryBatch(ctx context.Context, requests []QueryRequest, workers int) []QueryResponse
+}
+
+// QueryRequest is chaincode query of batch
+type QueryRequest struct {
+	Fn   string
+	Args []string
+}
+
+// QueryResponse is result of batch query, Err is set if query failed
+type QueryResponse struct {
+	Payload []byte
+	Err     error
 }
 
 type ChaincodePackage interface {
@@ -38,17 +61,81 @@ type ChaincodeInvokeResponse struct {
 	Err     error
 }
 
+// TxIDGenerator generates transaction id from nonce and serialized creator identity.
+// Standard Fabric peers accept only SHA-256 hash of nonce and creator concatenation,
+// so any other scheme breaks interaction with standard networks
+type TxIDGenerator interface {
+	TxID(nonce, creator []byte) (string, error)
+}
+
 // TxWaiter is interface for build your custom function for wait of result of tx after endorsement
 type TxWaiter interface {
 	Wait(ctx context.Context, channel string, txid ChaincodeTx) error
 }
 
+// TxBlockWaiter is tx waiter which reports number of block with committed transaction
+type TxBlockWaiter interface {
+	TxWaiter
+	// WaitBlock waits for transaction like Wait and returns number of its block, false if block is unknown
+	WaitBlock(ctx context.Context, channel string, txid ChaincodeTx) (uint64, bool, error)
+}
+
 type DoOptions struct {
 	DiscoveryChaincode *DiscoveryChaincode
 	Identity           msp.SigningIdentity
 	Pool               PeerPool
 
 	TxWaiter TxWaiter
+	// Sizes is filled with sizes of transaction parts if presented
+	Sizes *TxSizes
+	// PostCommitVerifyKeys are keys which values read from ledger after commit are compared with endorsed ones
+	PostCommitVerifyKeys []string
+	// PostCommitReadFn is chaincode query function which takes key and returns its current value
+	PostCommitReadFn string
+	// Endorsements is filled with endorsement result of each organization if presented
+	Endorsements *[]EndorsementInfo
+	// Collections are private data collections which are written by invoke,
+	// only organizations which are members of them are used for endorsement
+	Collections []string
+	// SkipPolicyCheck disables check of endorsement policy satisfaction before broadcast
+	SkipPolicyCheck bool
+	// ReturnWriteSet requests committed write set of transaction in invoke result
+	ReturnWriteSet bool
+	// RequiredEndorsers are organizations which peers endorse invoke instead of ones derived from policy
+	RequiredEndorsers []string
+	// PolicyEndorsers requests endorsement by minimal set of organizations with ready peers satisfying policy,
+	// all organizations with ready peers endorse invoke if chaincode has no policy
+	PolicyEndorsers bool
+	// Timeouts limit endorsement and commit stages of invoke if deadlines are not set in context
+	Timeouts InvokeTimeouts
+}
+
+// InvokeTimeouts limit stages of invoke, zero value means stage is limited only by invoke context.
+// Commit stage includes broadcast to orderer and waiting for commit
+type InvokeTimeouts struct {
+	Endorse time.Duration
+	Commit  time.Duration
+}
+
+// EndorsementInfo describes endorsement of proposal by organization peer
+type EndorsementInfo struct {
+	MspID string
+	// Endorser is serialized identity of endorsing peer, empty if endorsement failed
+	Endorser []byte
+	// Status is status of chaincode response
+	Status  int32
+	Latency time.Duration
+	Err     error
+}
+
+// TxSizes contains sizes in bytes of transaction parts
+type TxSizes struct {
+	// Proposal is size of signed proposal
+	Proposal int
+	// LargestResponse is size of largest proposal response from endorsing peers
+	LargestResponse int
+	// Envelope is size of signed envelope sent to orderer, zero for queries
+	Envelope int
 }
 
 type DoOption func(opt *DoOptions) error
@@ -57,6 +144,8 @@ type DoOption func(opt *DoOptions) error
 type ChaincodeInvokeBuilder interface {
 	// WithIdentity allows to invoke chaincode from custom identity
 	WithIdentity(identity msp.SigningIdentity) ChaincodeInvokeBuilder
+	// As allows to invoke chaincode from custom identity, which signs with crypto suite of core
+	As(identity Identity) ChaincodeInvokeBuilder
 	// Transient allows to pass arguments to transient map
 	Transient(args TransArgs) ChaincodeInvokeBuilder
 	// ArgBytes set slice of bytes as argument
@@ -65,14 +154,25 @@ type ChaincodeInvokeBuilder interface {
 	ArgJSON(in ...interface{}) ChaincodeInvokeBuilder
 	// ArgString set slice of strings as arguments
 	ArgString(args ...string) ChaincodeInvokeBuilder
+	// RetryOnConflict endorses and submits transaction again, up to maxAttempts in total, if it's invalidated
+	// by MVCC or phantom read conflict. Delay before retry starts from backoff and is doubled after each attempt.
+	// Conflicts are detected only if commit is awaited by tx waiter
+	RetryOnConflict(maxAttempts int, backoff time.Duration) ChaincodeInvokeBuilder
+	// Unsigned returns marshaled proposal with built arguments for serialized creator identity,
+	// proposal is signed offline and endorsed by Core.SubmitSigned
+	Unsigned(creator []byte) ([]byte, ChaincodeTx, error)
 	// Do makes invoke with built arguments
 	Do(ctx context.Context, opts ...DoOption) (*peer.Response, ChaincodeTx, error)
+	// DoResult makes invoke with built arguments and returns result with commit block, code and chaincode event
+	DoResult(ctx context.Context, opts ...DoOption) (*Result, error)
 }
 
 // ChaincodeQueryBuilder describe possibilities how to get query results
 type ChaincodeQueryBuilder interface {
 	// WithIdentity allows to invoke chaincode from custom identity
 	WithIdentity(identity msp.SigningIdentity) ChaincodeQueryBuilder
+	// As allows to query chaincode from custom identity, which signs with crypto suite of core
+	As(identity Identity) ChaincodeQueryBuilder
 	// Transient allows to pass arguments to transient map
 	Transient(args TransArgs) ChaincodeQueryBuilder
 	// AsBytes allows to get result of querying chaincode as byte slice
@@ -81,6 +181,16 @@ type ChaincodeQueryBuilder interface {
 	AsJSON(ctx context.Context, out interface{}) error
 	// AsProposalResponse allows to get raw peer response
 	AsProposalResponse(ctx context.Context) (*peer.ProposalResponse, error)
+	// AsResult allows to get result of querying chaincode in same form as invoke result
+	AsResult(ctx context.Context) (*Result, error)
+	// WithSizes allows to get sizes of query proposal and response
+	WithSizes(sizes *TxSizes) ChaincodeQueryBuilder
+	// AsQuorum queries all ready pool peers concurrently and returns payload as soon as quorum peers
+	// return identical payloads, so failed or lagging peers are tolerated. QuorumError is returned if quorum isn't reached
+	AsQuorum(ctx context.Context, quorum int) ([]byte, error)
+	// WithFreshestRead sends query to peer with highest channel height among pool peers,
+	// so query doesn't read state of lagging peers
+	WithFreshestRead() ChaincodeQueryBuilder
 }
 
 // QSCC describes Query System Chaincode (QSCC)
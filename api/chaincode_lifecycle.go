@@ -6,6 +6,141 @@ import (
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 )
 
+// Lifecycle describes Fabric V2 chaincode lifecycle system chaincode (_lifecycle)
 type Lifecycle interface {
+	// QueryInstalledChaincodes returns chaincodes installed on peer of current identity organization
 	QueryInstalledChaincodes(ctx context.Context) (*lb.QueryInstalledChaincodesResult, error)
+	// QueryInstalledChaincodesOn returns chaincodes installed on presented peer with channel chaincodes referencing them
+	QueryInstalledChaincodesOn(ctx context.Context, peer Peer) (*lb.QueryInstalledChaincodesResult, error)
+	// GetInstalledChaincodePackage returns package installed on presented peer
+	GetInstalledChaincodePackage(ctx context.Context, peer Peer, packageID string) ([]byte, error)
+	// InstallChaincode installs chaincode package on peer of current identity organization
+	InstallChaincode(ctx context.Context, pkg []byte) (*lb.InstallChaincodeResult, error)
+	// ApproveForMyOrg approves chaincode definition for current identity organization and waits for commit of approval
+	ApproveForMyOrg(ctx context.Context, channelName string, args *lb.ApproveChaincodeDefinitionForMyOrgArgs) error
+	// CheckCommitReadiness returns approvals of chaincode definition by channel organizations
+	CheckCommitReadiness(ctx context.Context, channelName string, args *lb.CheckCommitReadinessArgs) (*lb.CheckCommitReadinessResult, error)
+	// CommitReadiness checks approvals of chaincode definition by channel organizations, definition is the same
+	// as approved by ApproveForMyOrg, result contains approval status by MSP id
+	CommitReadiness(ctx context.Context, channelName string, def *lb.ApproveChaincodeDefinitionForMyOrgArgs) (map[string]bool, error)
+	// QueryChaincodeDefinition returns chaincode definition committed on channel
+	QueryChaincodeDefinition(ctx context.Context, channelName, ccName string) (*lb.QueryChaincodeDefinitionResult, error)
+	// QueryChaincodeDefinitions returns definitions of all chaincodes committed on channel
+	QueryChaincodeDefinitions(ctx context.Context, channelName string) (*lb.QueryChaincodeDefinitionsResult, error)
+	// Commit commits chaincode definition, proposal is endorsed by peers of presented organizations
+	// (current identity organization if not presented)
+	Commit(ctx context.Context, channelName string, args *lb.CommitChaincodeDefinitionArgs, endorserMSPs ...string) error
+	// Upgrade installs package, approves and commits chaincode definition as far as current identity can do it
+	Upgrade(ctx context.Context, req *LifecycleUpgradeRequest) (*LifecycleUpgradeResult, error)
+	// ApproveForMyOrgOnChannels approves chaincode definition for current identity organization on each of channels
+	// concurrently, failure on one channel doesn't stop approvals on others
+	ApproveForMyOrgOnChannels(ctx context.Context, req *LifecycleBatchApproveRequest) (*LifecycleBatchApproveResult, error)
+	// DefinitionDrift queries chaincode definition approved by each pool organization
+	// and reports fields which values differ between organizations
+	DefinitionDrift(ctx context.Context, channelName, ccName string, sequence int64) (*LifecycleDefinitionDrift, error)
+	// RolloutStatus queries each pool peer for installed packages and chaincode definition approved by peer organization
+	RolloutStatus(ctx context.Context, channelName, ccName string) []LifecyclePeerStatus
+}
+
+// LifecyclePeerStatus describes chaincode rollout state on peer, query errors are reported per peer
+type LifecyclePeerStatus struct {
+	MspID   string
+	Address string
+	// InstalledPackageIDs contains ids of all packages installed on peer
+	InstalledPackageIDs []string
+	InstalledErr        error
+	// ApprovedSequence and ApprovedPackageID describe latest chaincode definition approved by peer organization
+	ApprovedSequence  int64
+	ApprovedPackageID string
+	ApprovedErr       error
+}
+
+// LifecycleStep is a step of chaincode upgrade sequence
+type LifecycleStep string
+
+const (
+	LifecycleStepInstall              LifecycleStep = `install`
+	LifecycleStepApprove              LifecycleStep = `approve`
+	LifecycleStepCheckCommitReadiness LifecycleStep = `check_commit_readiness`
+	LifecycleStepCommit               LifecycleStep = `commit`
+)
+
+// LifecycleUpgradeRequest describes chaincode upgrade
+type LifecycleUpgradeRequest struct {
+	ChannelName string
+	// Package is chaincode package, installation is skipped if package is already installed
+	Package []byte
+	// Definition of chaincode, Source is filled with installed package id
+	Definition *lb.ApproveChaincodeDefinitionForMyOrgArgs
+	// Orgs are MSP ids of organizations which peers endorse commit,
+	// current identity organization is used if empty
+	Orgs []string
+	// OnStep is called after each step if presented
+	OnStep func(step LifecycleStep, err error)
+}
+
+// LifecycleUpgradeResult describes progress of chaincode upgrade
+type LifecycleUpgradeResult struct {
+	PackageID string
+	// Completed steps in order of execution
+	Completed []LifecycleStep
+	// PendingApprovals are organizations which still have to approve definition before commit
+	PendingApprovals []string
+	Committed        bool
+}
+
+// LifecycleBatchApproveRequest describes approval of same chaincode definition on several channels
+type LifecycleBatchApproveRequest struct {
+	Channels []string
+	// Package is chaincode package which id is set as definition Source,
+	// Source of definition is used as is if package is not presented
+	Package    []byte
+	Definition *lb.ApproveChaincodeDefinitionForMyOrgArgs
+	// Concurrency limits number of channels approved at once, 4 is used if not presented
+	Concurrency int
+}
+
+// LifecycleBatchApproveResult describes approvals of chaincode definition on channels in order of request channels
+type LifecycleBatchApproveResult struct {
+	PackageID string
+	Channels  []LifecycleChannelApproval
+}
+
+// LifecycleChannelApproval is result of approval on channel, Err is nil if approval is committed
+type LifecycleChannelApproval struct {
+	ChannelName string
+	Err         error
+}
+
+// Failed returns channels on which approval failed
+func (r *LifecycleBatchApproveResult) Failed() []LifecycleChannelApproval {
+	var failed []LifecycleChannelApproval
+	for _, approval := range r.Channels {
+		if approval.Err != nil {
+			failed = append(failed, approval)
+		}
+	}
+	return failed
+}
+
+// LifecycleDefinitionDrift describes differences between chaincode definitions approved by organizations
+type LifecycleDefinitionDrift struct {
+	// Approved are definitions approved by organizations, by MSP id
+	Approved map[string]*lb.QueryApprovedChaincodeDefinitionResult
+	// Errors are query errors of organizations which approved definition is unknown, e.g. not approved yet
+	Errors map[string]error
+	// Fields are definition fields which values differ, sorted by field name
+	Fields []LifecycleFieldDrift
+}
+
+// LifecycleFieldDrift describes definition field which value differs between organizations
+type LifecycleFieldDrift struct {
+	Field string
+	// Values are field values by MSP id
+	Values map[string]string
+}
+
+// HasDrift returns true if approved definitions differ
+func (d *LifecycleDefinitionDrift) HasDrift() bool {
+	return len(d.Fields) > 0
 }
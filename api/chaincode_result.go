@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// Result is result of chaincode invoke or query, commit fields are nil for query
+// and for invoke which doesn't wait for commit
+type Result struct {
+	TxID ChaincodeTx
+	// Payload is payload of chaincode response
+	Payload []byte
+	// ProposalResponses are responses of endorsing peers
+	ProposalResponses []*peer.ProposalResponse
+	// CommitBlock is number of block with committed transaction, nil if block is unknown
+	CommitBlock *uint64
+	// CommitCode is validation code of committed transaction
+	CommitCode *peer.TxValidationCode
+	// Event is chaincode event set by chaincode in endorsed proposal response, nil if no event was emitted.
+	// Event is available before commit, so it is not committed yet if CommitCode is nil
+	Event *peer.ChaincodeEvent
+	// Timing is elapsed time breakdown of invoke, empty for query
+	Timing InvokeTiming
+	// BroadcastInfo is info returned by orderer on transaction broadcast, empty for query
+	BroadcastInfo string
+	// WriteSet is public state writes of committed transaction taken from commit block,
+	// it is set only if it is requested by invoke option
+	WriteSet []NamespaceWrites
+}
+
+// NamespaceWrites are state writes of transaction to chaincode namespace
+type NamespaceWrites struct {
+	Namespace string
+	Writes    []KeyWrite
+}
+
+// KeyWrite is state write of key, Value is empty if key is deleted
+type KeyWrite struct {
+	Key      string
+	Value    []byte
+	IsDelete bool
+}
+
+// InvokeTiming is elapsed time breakdown of invoke stages
+type InvokeTiming struct {
+	// Proposal is time to build and sign proposal
+	Proposal time.Duration
+	// Endorsement is time to gather endorsements of all peers
+	Endorsement time.Duration
+	// SlowestEndorsement is endorsement time of slowest peer organization
+	SlowestEndorsement time.Duration
+	// SlowestEndorserMspID is MSP id of organization which peer endorsed slowest
+	SlowestEndorserMspID string
+	// Broadcast is time to broadcast transaction to orderer
+	Broadcast time.Duration
+	// Commit is time to wait transaction commit, zero if commit is not waited
+	Commit time.Duration
+	// Total is elapsed time of whole invoke
+	Total time.Duration
+}
+
+// Response returns chaincode response of first endorsing peer
+func (r *Result) Response() *peer.Response {
+	if len(r.ProposalResponses) == 0 {
+		return nil
+	}
+	return r.ProposalResponses[0].Response
+}
+
+// JSON unmarshals payload to out
+func (r *Result) JSON(out interface{}) error {
+	if err := json.Unmarshal(r.Payload, out); err != nil {
+		return errors.Wrap(err, `failed to unmarshal JSON`)
+	}
+	return nil
+}
+
+// Committed returns true if transaction is committed
+func (r *Result) Committed() bool {
+	return r.CommitCode != nil
+}
+
+// BlockNumber returns number of block with committed transaction if it is known
+func (r *Result) BlockNumber() (uint64, bool) {
+	if r.CommitBlock == nil {
+		return 0, false
+	}
+	return *r.CommitBlock, true
+}
+
+// ValidationCode returns validation code of committed transaction if transaction is committed
+func (r *Result) ValidationCode() (peer.TxValidationCode, bool) {
+	if r.CommitCode == nil {
+		return 0, false
+	}
+	return *r.CommitCode, true
+}
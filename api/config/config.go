@@ -8,6 +8,8 @@ import (
 
 type Config struct {
 	Crypto CryptoConfig `yaml:"crypto"`
+	// EnvelopeCrypto is used for signing envelopes sent to orderer, Crypto is used if not presented
+	EnvelopeCrypto *CryptoConfig `yaml:"envelope_crypto"`
 	// Deprecated: use Orderers.
 	Orderer   *ConnectionConfig  `yaml:"orderer"`
 	Orderers  []ConnectionConfig `yaml:"orderers"`
@@ -21,6 +23,22 @@ type ConnectionConfig struct {
 	Tls     TlsConfig  `yaml:"tls"`
 	GRPC    GRPCConfig `yaml:"grpc"`
 	Timeout Duration   `yaml:"timeout"`
+	// DialTimeout limits establishing of connection, Timeout is used if it's not set
+	DialTimeout Duration `yaml:"dial_timeout"`
+	// CallTimeout limits each call of peer endorsement or orderer broadcast and deliver if context has no deadline.
+	// Peer calls are limited by Timeout if it's not set
+	CallTimeout Duration `yaml:"call_timeout"`
+	// BlockBuffer is used by block subscriptions of peer
+	BlockBuffer BlockBufferConfig `yaml:"block_buffer"`
+	// SharedDeliver makes block subscriptions of peer from newest block share one deliver stream per channel
+	SharedDeliver bool `yaml:"shared_deliver"`
+}
+
+// BlockBufferConfig describes buffering of blocks in subscriptions.
+// Policy is `block` (default) or `drop_oldest`, peer with other policy isn't created
+type BlockBufferConfig struct {
+	Size   uint   `yaml:"size"`
+	Policy string `yaml:"policy"`
 }
 
 type OrdererConfig struct {
@@ -48,6 +66,14 @@ type MSPConfig struct {
 type GRPCConfig struct {
 	KeepAlive *GRPCKeepAliveConfig `yaml:"keep_alive"`
 	Retry     *GRPCRetryConfig     `yaml:"retry"`
+	// RetryPolicy is retry policy of GRPC service config applied by GRPC transport in addition to Retry.
+	// GRPC transport retries are enabled only if GRPC_GO_RETRY=on environment variable is set
+	RetryPolicy *GRPCRetryPolicyConfig `yaml:"retry_policy"`
+	// MaxRecvMsgSize and MaxSendMsgSize are limits of message size in bytes, 100MB by default
+	MaxRecvMsgSize int `yaml:"max_recv_msg_size"`
+	MaxSendMsgSize int `yaml:"max_send_msg_size"`
+	// ClientID identifies client application in user agent and x-client-id header of calls, e.g. `app/1.0.0`
+	ClientID string `yaml:"client_id"`
 }
 
 type GRPCRetryConfig struct {
@@ -57,6 +83,17 @@ type GRPCRetryConfig struct {
 	Timeout Duration `yaml:"timeout"`
 }
 
+// GRPCRetryPolicyConfig is retry policy of GRPC service config, zero fields are set to defaults:
+// 3 attempts, backoff from 100ms to 1s with multiplier 2, UNAVAILABLE status is retried
+type GRPCRetryPolicyConfig struct {
+	MaxAttempts       int      `yaml:"max_attempts"`
+	InitialBackoff    Duration `yaml:"initial_backoff"`
+	MaxBackoff        Duration `yaml:"max_backoff"`
+	BackoffMultiplier float64  `yaml:"backoff_multiplier"`
+	// RetryableStatusCodes are names of GRPC status codes, e.g. UNAVAILABLE
+	RetryableStatusCodes []string `yaml:"retryable_status_codes"`
+}
+
 type GRPCKeepAliveConfig struct {
 	// See keepalive.ClientParameters.Time, current value in seconds, default: 1 min.
 	Time int `yaml:"time" default:"60"`
@@ -65,12 +102,19 @@ type GRPCKeepAliveConfig struct {
 }
 
 type TlsConfig struct {
-	Enabled      bool   `yaml:"enabled"`
-	SkipVerify   bool   `yaml:"skip_verify"`
+	Enabled    bool `yaml:"enabled"`
+	SkipVerify bool `yaml:"skip_verify"`
+	// HostOverride is server name used to verify server certificate instead of host of connection,
+	// e.g. if peer is dialed by IP address or behind load balancer
 	HostOverride string `yaml:"host_override"`
 	CertPath     string `yaml:"cert_path"`
 	KeyPath      string `yaml:"key_path"`
 	CACertPath   string `yaml:"ca_cert_path"`
+	// CertPEM and KeyPEM are client certificate and key for mutual TLS, used instead of CertPath and KeyPath if set
+	CertPEM string `yaml:"cert_pem"`
+	KeyPEM  string `yaml:"key_pem"`
+	// CACertPEM is CA certificate used instead of CACertPath if set
+	CACertPEM string `yaml:"ca_cert_pem"`
 }
 
 type DiscoveryConfig struct {
@@ -2,7 +2,11 @@ package api
 
 import (
 	"context"
+	"time"
 
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/msp"
 )
 
@@ -11,6 +15,38 @@ type Channel interface {
 	Chaincode(name string) Chaincode
 	// Joins channel
 	Join(ctx context.Context) error
+	// JoinWithBlock joins peer of current identity organization to channel with presented genesis block,
+	// ErrChannelAlreadyJoined is returned if peer has already joined channel
+	JoinWithBlock(ctx context.Context, genesisBlock *common.Block) error
+	// Create broadcasts channel creation transaction to orderer,
+	// ErrChannelAlreadyExists is returned if channel exists
+	Create(ctx context.Context, envelope *common.Envelope) error
+	// Config returns channel config from latest config block fetched from orderer,
+	// util.ConfigToJSON and util.Get*FromChannelConfig helpers decode its parts
+	Config(ctx context.Context) (*common.Config, error)
+	// LoadMSPs loads MSPs of channel organizations from actual channel config and caches them on channel
+	LoadMSPs(ctx context.Context) (map[string]msp.MSP, error)
+	// ValidateIdentity checks serialized identity against MSP of its channel organization
+	ValidateIdentity(ctx context.Context, serializedIdentity []byte) error
+	// Blocks subscribes on channel blocks delivered by ready peer of current identity organization,
+	// newest block is used as start position if seek option is not presented. Subscription is stopped with context
+	Blocks(ctx context.Context, seekOpt ...EventCCSeekOption) (RawBlockSubscription, error)
+	// FilteredBlocks subscribes on filtered channel blocks, which contain only transaction ids and validation codes.
+	// Subscription reconnects if stream drops, onReconnect is called on reconnection if presented
+	FilteredBlocks(ctx context.Context, onReconnect ReconnectHandler, seekOpt ...EventCCSeekOption) (FilteredBlockSubscription, error)
+	// Info returns channel height and hashes of current and previous blocks from ready peer of current identity
+	// organization which joined channel. ErrChannelNotJoined is returned if there is no such peer
+	Info(ctx context.Context) (*common.BlockchainInfo, error)
+	// GetBlock returns decoded block by number and its raw bytes as stored by peer,
+	// ErrBlockOutOfRange is returned if number isn't less than channel height
+	GetBlock(ctx context.Context, number uint64) (*common.Block, []byte, error)
+	// GetBlockByTxID returns decoded block containing transaction and its raw bytes
+	GetBlockByTxID(ctx context.Context, txID ChaincodeTx) (*common.Block, []byte, error)
+	// TxValidationCode returns validation code of committed transaction from its block
+	TxValidationCode(ctx context.Context, txID ChaincodeTx) (peer.TxValidationCode, error)
+	// WaitTx waits until transaction is committed and returns its validation code,
+	// error is returned only if waiting fails, e.g. context is done
+	WaitTx(ctx context.Context, txID ChaincodeTx) (peer.TxValidationCode, error)
 	// CSCC implements Configuration System Chaincode (CSCC)
 }
 
@@ -19,6 +55,12 @@ type Core interface {
 	Channel(name string) Channel
 	// CurrentIdentity identity returns current signing identity used by core
 	CurrentIdentity() msp.SigningIdentity
+	// SetIdentity replaces signing identity used by core, already started operations complete with previous identity.
+	// Nil identity clears default identity, so operations which need it fail with ErrNoIdentity
+	SetIdentity(identity Identity)
+	// SetDiscoveryProvider replaces discovery provider used by core,
+	// already started operations complete with previous provider
+	SetDiscoveryProvider(provider DiscoveryProvider)
 	// CryptoSuite returns current crypto suite implementation
 	CryptoSuite() CryptoSuite
 	// System allows access to system chaincodes
@@ -29,6 +71,40 @@ type Core interface {
 	Chaincode(name string) ChaincodePackage
 	// FabricV2 returns if core works in fabric v2 mode
 	FabricV2() bool
+	// SubmitEnvelope broadcasts externally built endorser transaction or config update envelope to channel orderer.
+	// Envelope without signature is signed by current identity, if it is envelope creator, otherwise it is refused. Commit of transaction is awaited if TxWaiter option is presented
+	SubmitEnvelope(ctx context.Context, channelName string, envelope *common.Envelope, opts ...DoOption) (*orderer.BroadcastResponse, error)
+	// SubmitSigned endorses proposal signed offline, e.g. built by ChaincodeInvokeBuilder.Unsigned, and returns
+	// transaction envelope without signature. Envelope payload must be signed by the same identity
+	// and envelope with signature is broadcasted by SubmitEnvelope
+	SubmitSigned(ctx context.Context, proposalBytes, signature []byte, opts ...DoOption) (*common.Envelope, error)
+	// ResolvedConfig returns effective configuration of core with sensitive settings redacted
+	ResolvedConfig() ResolvedConfig
+	// WarmUp waits for peer and orderer connections, including orderers of warm up channels, to become ready
+	// and fetches discovery of warm up channels, returns connections which failed to warm up
+	WarmUp(ctx context.Context) []WarmUpFailure
+	// PeerChannels returns ids of channels joined by presented peer, e.g. one of PeerPool peers
+	PeerChannels(ctx context.Context, peer Peer) ([]string, error)
+	// CheckCertificateExpiry inspects TLS server certificates of configured peers and orderers
+	// and returns endpoints which certificates expire within presented duration or which are unreachable
+	CheckCertificateExpiry(ctx context.Context, within time.Duration) []CertificateExpiry
+	// Close stops background refreshes and closes connections of peer pool, orderers and gateway,
+	// subsequent calls return ErrClientClosed. Repeated Close is no-op
+	Close() error
+}
+
+// CertificateExpiry describes TLS server certificate of endpoint, Err is set if certificate can't be fetched
+type CertificateExpiry struct {
+	Host     string
+	NotAfter time.Time
+	Err      error
+}
+
+// WarmUpFailure describes connection which failed to warm up
+type WarmUpFailure struct {
+	// Target is peer or orderer address or `discovery:<channel>`
+	Target string
+	Err    error
 }
 
 // SystemCC describes interface to access Fabric System Chaincodes
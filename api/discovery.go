@@ -20,6 +20,12 @@ type DiscoveryProvider interface {
 	Chaincodes(channelName string) ([]DiscoveryChaincode, error)
 }
 
+// EndorsersPlanner is discovery provider which keeps endorsement plan of chaincodes
+type EndorsersPlanner interface {
+	// Endorsers returns organizations which endorsements satisfy chaincode policy, false if chaincode isn't planned
+	Endorsers(channelName, ccName string) ([]string, bool)
+}
+
 type DiscoveryChannel struct {
 	Name        string                    `json:"channel_name" yaml:"name"`
 	Description string                    `json:"channel_description" yaml:"description"`
@@ -33,6 +39,14 @@ type DiscoveryChaincode struct {
 	Version     string `json:"version"`
 	Description string `json:"description"`
 	Policy      string `json:"policy"`
+	// Collections are private data collections of chaincode
+	Collections []DiscoveryCollection `json:"collections" yaml:"collections"`
+}
+
+// DiscoveryCollection describes private data collection, Policy defines collection member organizations
+type DiscoveryCollection struct {
+	Name   string `json:"name" yaml:"name"`
+	Policy string `json:"policy" yaml:"policy"`
 }
 
 func (c DiscoveryChaincode) GetFabricType() peer.ChaincodeSpec_Type {
@@ -1,12 +1,23 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	ErrEmptyConfig         = Error(`empty core configuration`)
 	ErrInvalidPEMStructure = Error(`invalid PEM structure`)
+	ErrCircuitOpen         = Error(`circuit breaker is open`)
+	ErrClientClosed        = Error(`client closed`)
+	ErrNoIdentity          = Error(`identity is not set`)
+	ErrNoCryptoSuite       = Error(`crypto suite is not set`)
+	// ErrCommitNotAwaited is returned by operations which need committed transaction if tx waiter doesn't wait for commit
+	ErrCommitNotAwaited = Error(`transaction commit is not awaited`)
 )
 
 type MultiError struct {
@@ -39,3 +50,125 @@ type Error string
 func (e Error) Error() string {
 	return string(e)
 }
+
+// PostCommitMismatchError describes keys which values read from ledger after commit differ from endorsed ones
+type PostCommitMismatchError struct {
+	TxId ChaincodeTx
+	Keys []string
+}
+
+func (e PostCommitMismatchError) Error() string {
+	return fmt.Sprintf("committed values of tx %s differ from endorsed for keys: %v", e.TxId, e.Keys)
+}
+
+// InvalidBlockError describes delivered block which failed verification of data hash or orderer signatures
+type InvalidBlockError struct {
+	BlockNumber uint64
+	Reason      string
+}
+
+func (e InvalidBlockError) Error() string {
+	return fmt.Sprintf("block %d is invalid: %s", e.BlockNumber, e.Reason)
+}
+
+const (
+	ErrGossipMembershipNotSupported = Error(`peer doesn't expose gossip membership via discovery service`)
+	ErrChaincodeLogsNotExposed      = Error(`peer operations endpoint doesn't expose chaincode logs`)
+)
+
+// QuorumError describes responses of quorum query which didn't reach quorum of identical payloads
+type QuorumError struct {
+	Quorum    int
+	Responses []QuorumResponse
+}
+
+// QuorumResponse is response of peer to quorum query, Err is set if peer failed to respond
+type QuorumResponse struct {
+	MspID   string
+	Address string
+	Payload []byte
+	Err     error
+}
+
+func (e QuorumError) Error() string {
+	return fmt.Sprintf("quorum %d of identical responses is not reached, got %d responses", e.Quorum, len(e.Responses))
+}
+
+// PolicyUnsatisfiableError describes endorsement policy which can't be satisfied by organizations with ready peers
+type PolicyUnsatisfiableError struct {
+	Policy string
+	// Ready are organizations which have ready peers in pool
+	Ready []string
+}
+
+func (e PolicyUnsatisfiableError) Error() string {
+	return fmt.Sprintf("endorsement policy %s can't be satisfied by organizations with ready peers %v", e.Policy, e.Ready)
+}
+
+// EndorsementPolicyError describes endorsement policy which isn't satisfied by collected endorsements
+type EndorsementPolicyError struct {
+	Policy string
+	// Need is number of endorsements which are missing
+	Need int
+	// From are organizations which endorsements can satisfy policy
+	From []string
+}
+
+func (e EndorsementPolicyError) Error() string {
+	return fmt.Sprintf("endorsement policy %s is not satisfied: need %d more from %v", e.Policy, e.Need, e.From)
+}
+
+// PeerUnavailableError describes peer which can't be reached by endorsement call,
+// GRPC status of underlying error is available with status.FromError
+type PeerUnavailableError struct {
+	Peer string
+	Err  error
+}
+
+func (e PeerUnavailableError) Error() string {
+	return fmt.Sprintf("peer %s is unavailable: %s", e.Peer, e.Err)
+}
+
+func (e PeerUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func (e PeerUnavailableError) GRPCStatus() *status.Status {
+	return status.Convert(e.Err)
+}
+
+// OrdererError describes broadcast or deliver rejected by orderer with status
+type OrdererError struct {
+	Status common.Status
+	// Info is details of rejection, e.g. failed envelope validation
+	Info string
+}
+
+func (e OrdererError) Error() string {
+	if e.Info != `` {
+		return fmt.Sprintf("orderer status: %s: %s", e.Status, e.Info)
+	}
+	return fmt.Sprintf("orderer status: %s", e.Status)
+}
+
+// IsMVCCConflict returns true if error is caused by transaction invalidated due to read conflict
+// with concurrently committed transaction, such transaction can be retried with new endorsement.
+// MultiError is conflict if any of its errors is
+func IsMVCCConflict(err error) bool {
+	var multiErr *MultiError
+	if errors.As(err, &multiErr) {
+		for _, e := range multiErr.Errors {
+			if IsMVCCConflict(e) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var txErr InvalidTxError
+	if !errors.As(err, &txErr) {
+		return false
+	}
+	return txErr.Code == peer.TxValidationCode_MVCC_READ_CONFLICT ||
+		txErr.Code == peer.TxValidationCode_PHANTOM_READ_CONFLICT
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+)
+
+// Gateway is client of peer Gateway service (Fabric 2.4+), which collects endorsements
+// and submits transactions on server side
+type Gateway interface {
+	// Evaluate sends query proposal to peer of one of target organizations, any organization is used if targets are empty
+	Evaluate(ctx context.Context, channelName string, tx ChaincodeTx, proposal *peer.SignedProposal, targetOrgs ...string) (*peer.Response, error)
+	// Endorse collects endorsements satisfying chaincode policy or of presented organizations
+	// and returns prepared transaction envelope, which must be signed before submit
+	Endorse(ctx context.Context, channelName string, tx ChaincodeTx, proposal *peer.SignedProposal, endorsingOrgs ...string) (*common.Envelope, error)
+	// Submit sends signed transaction envelope to orderer
+	Submit(ctx context.Context, channelName string, tx ChaincodeTx, envelope *common.Envelope) error
+	// CommitStatus waits for transaction commit and returns its validation code and block number
+	CommitStatus(ctx context.Context, channelName string, tx ChaincodeTx, identity msp.SigningIdentity) (peer.TxValidationCode, uint64, error)
+	Close() error
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+
 	"github.com/hyperledger/fabric/msp"
 )
 
@@ -8,3 +10,21 @@ type Identity interface {
 	// GetSigningIdentity returns signing identity which will use presented crypto suite
 	GetSigningIdentity(cs CryptoSuite) msp.SigningIdentity
 }
+
+// IdentityProvider obtains identity from external source, e.g. enrolls short-lived certificate in CA
+type IdentityProvider func(ctx context.Context) (Identity, error)
+
+// IdentityResolver is signing identity which is resolved when operation starts, e.g. identity refreshed
+// by IdentityProvider. Operation is signed only by resolved identity, so all its signatures match single creator
+type IdentityResolver interface {
+	msp.SigningIdentity
+	ResolveIdentity() msp.SigningIdentity
+}
+
+// ResolveIdentity returns identity resolved by IdentityResolver or presented identity as is
+func ResolveIdentity(identity msp.SigningIdentity) msp.SigningIdentity {
+	if resolver, ok := identity.(IdentityResolver); ok {
+		return resolver.ResolveIdentity()
+	}
+	return identity
+}
@@ -10,6 +10,9 @@ import (
 type Invoker interface {
 	// Invoke method allows to invoke chaincode
 	Invoke(ctx context.Context, from msp.SigningIdentity, channel string, chaincode string, fn string, args [][]byte, transArgs TransArgs, doOpts ...DoOption) (*peer.Response, ChaincodeTx, error)
+	// InvokeWithEvent invokes chaincode and after commit returns chaincode event emitted by transaction, nil if no event was emitted.
+	// ErrCommitNotAwaited is returned if tx waiter set by options doesn't wait for commit
+	InvokeWithEvent(ctx context.Context, from msp.SigningIdentity, channel string, chaincode string, fn string, args [][]byte, transArgs TransArgs, doOpts ...DoOption) (*peer.ChaincodeEvent, ChaincodeTx, error)
 	// Query method allows to query chaincode without sending response to orderer
 	Query(ctx context.Context, from msp.SigningIdentity, channel string, chaincode string, fn string, args [][]byte, transArgs TransArgs) (*peer.Response, error)
 	// Subscribe allows to subscribe on chaincode events
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/orderer"
@@ -12,4 +13,34 @@ type Orderer interface {
 	Broadcast(ctx context.Context, envelope *common.Envelope) (*orderer.BroadcastResponse, error)
 	// Deliver fetches block from orderer by envelope
 	Deliver(ctx context.Context, envelope *common.Envelope) (*common.Block, error)
+	// Close closes connections of orderer, subsequent calls return ErrClientClosed. Repeated Close is no-op
+	Close() error
+}
+
+// PreBroadcastHook receives assembled envelope before it is sent to orderer and returns envelope for broadcasting.
+// Returned error aborts submission
+type PreBroadcastHook func(envelope *common.Envelope) (*common.Envelope, error)
+
+// OrdererSource is source of orderer endpoints for channel
+type OrdererSource string
+
+const (
+	// OrdererSourceDiscovery are orderers returned by discovery provider for channel
+	OrdererSourceDiscovery OrdererSource = `discovery`
+	// OrdererSourceConfig are orderers from SDK config or set by option
+	OrdererSourceConfig OrdererSource = `config`
+	// OrdererSourceChannelConfig are orderers from channel config block fetched from peer
+	OrdererSourceChannelConfig OrdererSource = `channel_config`
+)
+
+// AuditSink receives transaction envelopes submitted to orderer with results of their broadcast
+type AuditSink interface {
+	Record(txID string, envelope []byte, submittedAt time.Time, result AuditResult) error
+}
+
+// AuditResult is result of envelope broadcast, Status is not set if broadcast failed with error
+type AuditResult struct {
+	Status common.Status
+	Info   string
+	Err    error
 }
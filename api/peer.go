@@ -26,6 +26,8 @@ type Peer interface {
 type PeerProcessor interface {
 	// CreateProposal creates signed proposal for presented cc, function and args using signing identity
 	CreateProposal(cc *DiscoveryChaincode, identity msp.SigningIdentity, fn string, args [][]byte, transArgs TransArgs) (*peer.SignedProposal, ChaincodeTx, error)
+	// CreateUnsignedProposal creates marshaled proposal for serialized creator identity, which is signed outside of sdk
+	CreateUnsignedProposal(cc *DiscoveryChaincode, creator []byte, fn string, args [][]byte, transArgs TransArgs) ([]byte, ChaincodeTx, error)
 	// Send sends signed proposal to endorsing peers and collects their responses
 	Send(ctx context.Context, proposal *peer.SignedProposal, cc *DiscoveryChaincode, pool PeerPool) ([]*peer.ProposalResponse, error)
 }
@@ -35,8 +37,18 @@ type PeerProcessor interface {
 type PeerEndorseError struct {
 	Status  int32
 	Message string
+	// Payload is payload of chaincode response, it can contain structured error
+	Payload []byte
+	// Peer is address of endorsing peer, empty if error is not returned by peer
+	Peer string
+	// Response is proposal response of peer, nil if error is not returned by peer
+	Response *peer.ProposalResponse
 }
 
+// ResponseErrorDecoder turns chaincode response with error status into application error,
+// nil is returned if response can't be decoded, then PeerEndorseError is used
+type ResponseErrorDecoder func(response *peer.Response) error
+
 func (e PeerEndorseError) Error() string {
 	return fmt.Sprintf("failed to endorse: %s (code: %d)", e.Message, e.Status)
 }
@@ -53,3 +65,11 @@ func WithContext(ctx context.Context) PeerEndorseOpt {
 		return nil
 	}
 }
+
+// GossipMember describes channel member known by peer gossip
+type GossipMember struct {
+	MspID    string
+	Endpoint string
+	// LedgerHeight is zero if peer hasn't received state of member yet
+	LedgerHeight uint64
+}
@@ -51,6 +51,13 @@ func SeekSingle(num uint64) EventCCSeekOption {
 	}
 }
 
+// SeekFrom sets offset from block number to new channel blocks
+func SeekFrom(num uint64) EventCCSeekOption {
+	return func() (*orderer.SeekPosition, *orderer.SeekPosition) {
+		return &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: num}}}, maxStop
+	}
+}
+
 // SeekRange sets offset from one block to another by their numbers
 func SeekRange(start, end uint64) EventCCSeekOption {
 	return func() (*orderer.SeekPosition, *orderer.SeekPosition) {
@@ -68,6 +75,22 @@ type EventCCSubscription interface {
 	Close() error
 }
 
+// ChaincodeEvent is chaincode event with number of block and id of transaction which emitted it.
+// BlockNumber can be saved as checkpoint to resume subscription from it, events of checkpoint block are delivered again
+type ChaincodeEvent struct {
+	Event       *peer.ChaincodeEvent
+	BlockNumber uint64
+	TxID        string
+}
+
+// ChaincodeEventSubscription is subscription on chaincode events which resumes after stream errors
+type ChaincodeEventSubscription interface {
+	Events() <-chan *ChaincodeEvent
+	// Errors returns error which stopped subscription, channel is closed after subscription is stopped
+	Errors() <-chan error
+	Close() error
+}
+
 // EventCCSubscription describes tx subscription
 type TxSubscription interface {
 	// returns result of current tx: success flag, original peer validation code and error if occurred
@@ -75,6 +98,13 @@ type TxSubscription interface {
 	Close() error
 }
 
+// TxBlockSubscription is tx subscription which reports number of block with transaction
+type TxBlockSubscription interface {
+	TxSubscription
+	// BlockNumber returns number of block with transaction after Result is returned, false if block is unknown
+	BlockNumber() (uint64, bool)
+}
+
 type BlockSubscription interface {
 	Blocks() <-chan *common.Block
 	// DEPRECATED: will migrate to just once Err() <- chan error
@@ -82,6 +112,63 @@ type BlockSubscription interface {
 	Close() error
 }
 
+// DeliveredBlock is block delivered by peer, Raw contains block bytes as received from deliver stream
+type DeliveredBlock struct {
+	Block *common.Block
+	Raw   []byte
+}
+
+// RawBlockSubscription is subscription on channel blocks which keeps raw bytes of delivered blocks
+type RawBlockSubscription interface {
+	Blocks() <-chan *DeliveredBlock
+	// Errors returns error which stopped subscription, channel is closed after subscription is stopped
+	Errors() <-chan error
+	Close() error
+}
+
+// FilteredBlockSubscription is subscription on filtered channel blocks
+type FilteredBlockSubscription interface {
+	Blocks() <-chan *peer.FilteredBlock
+	// Errors returns error which stopped subscription, channel is closed after subscription is stopped
+	Errors() <-chan error
+	Close() error
+}
+
+// ReconnectHandler is called before subscription reconnects after stream error,
+// fromBlock is number of block which delivery is resumed from, nil if no blocks were delivered yet
+type ReconnectHandler func(fromBlock *uint64, err error)
+
+// BlockOverflowPolicy defines behaviour of block subscription when consumer is slower than delivery stream
+type BlockOverflowPolicy string
+
+const (
+	// OverflowBlock blocks delivery stream until consumer reads block from full buffer
+	OverflowBlock BlockOverflowPolicy = `block`
+	// OverflowDropOldest drops oldest buffered block to free space for received one
+	OverflowDropOldest BlockOverflowPolicy = `drop_oldest`
+)
+
+// ParseBlockOverflowPolicy returns overflow policy by name, empty name means OverflowBlock
+func ParseBlockOverflowPolicy(name string) (BlockOverflowPolicy, error) {
+	switch policy := BlockOverflowPolicy(name); policy {
+	case ``:
+		return OverflowBlock, nil
+	case OverflowBlock, OverflowDropOldest:
+		return policy, nil
+	default:
+		return ``, fmt.Errorf(`unknown block overflow policy: %s`, name)
+	}
+}
+
+// BufferedBlockSubscription is block subscription with configurable buffering
+type BufferedBlockSubscription interface {
+	BlockSubscription
+	// OverflowPolicy returns active overflow policy
+	OverflowPolicy() BlockOverflowPolicy
+	// Dropped returns count of blocks dropped due to buffer overflow
+	Dropped() uint64
+}
+
 type TxEvent struct {
 	TxId    ChaincodeTx
 	Success bool
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/msp"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 )
 
@@ -16,6 +17,7 @@ const (
 	//ErrNoReadyPeersForMSP = Error(`no ready peers for presented MSP`)
 	ErrMSPNotFound  = Error(`MSP not found`)
 	ErrPeerNotReady = Error(`peer not ready`)
+	ErrPeerNotFound = Error(`peer not found`)
 )
 
 type ErrNoReadyPeers struct {
@@ -26,13 +28,105 @@ func (e ErrNoReadyPeers) Error() string {
 	return fmt.Sprintf("no ready peers for MspId: %s", e.MspId)
 }
 
+// ErrChannelNotJoined is returned if channel isn't served by any ready peer of organization,
+// Err is error returned by last tried peer
+type ErrChannelNotJoined struct {
+	Channel string
+	MspId   string
+	Err     error
+}
+
+func (e ErrChannelNotJoined) Error() string {
+	return fmt.Sprintf("channel %s is not joined on ready peers of MspId: %s: %s", e.Channel, e.MspId, e.Err)
+}
+
+// ErrChannelAlreadyJoined is returned if peer of organization has already joined channel
+type ErrChannelAlreadyJoined struct {
+	Channel string
+	MspId   string
+	Err     error
+}
+
+func (e ErrChannelAlreadyJoined) Error() string {
+	return fmt.Sprintf("channel %s is already joined by peer of MspId: %s: %s", e.Channel, e.MspId, e.Err)
+}
+
+// ErrChannelAlreadyExists is returned if orderer rejects channel creation because channel exists
+type ErrChannelAlreadyExists struct {
+	Channel string
+	Err     error
+}
+
+func (e ErrChannelAlreadyExists) Error() string {
+	return fmt.Sprintf("channel %s already exists: %s", e.Channel, e.Err)
+}
+
+// ErrBlockOutOfRange is returned if requested block number isn't less than channel height
+type ErrBlockOutOfRange struct {
+	Number uint64
+	Height uint64
+}
+
+func (e ErrBlockOutOfRange) Error() string {
+	return fmt.Sprintf("block %d not found: channel height is %d", e.Number, e.Height)
+}
+
+// QueryAffinity describes which MSP peers are used for chaincode queries.
+// In strict mode queries are sent only to peers of MspID, otherwise peers of MspID are preferred
+// and query falls back to peers of querying identity MSP if MspID peers are not available
+type QueryAffinity struct {
+	MspID  string
+	Strict bool
+}
+
 type PeerPool interface {
 	Add(mspId string, peer Peer, strategy PeerPoolCheckStrategy) error
 	Process(ctx context.Context, mspId string, proposal *peer.SignedProposal) (*peer.ProposalResponse, error)
 	DeliverClient(mspId string, identity msp.SigningIdentity) (DeliverClient, error)
+	// Conn returns GRPC connection of peer with presented MSP and address.
+	// Connection is managed by pool, so caller must not close it
+	Conn(mspId string, address string) (*grpc.ClientConn, error)
+	// Peers returns snapshot of pool peers grouped by MSP
+	Peers() map[string][]Peer
+	// Status returns states of pool peers grouped by MSP, peers which are not ready are skipped by Process
+	Status() map[string][]PeerStatus
+	// Ping makes round trip to peer with presented MSP and address and returns its latency,
+	// ping is limited by default timeout if context has no deadline
+	Ping(ctx context.Context, mspId string, address string) (time.Duration, error)
+	// Remove removes peer with presented MSP and address from pool and closes it after endorsements in progress complete
+	Remove(mspId string, address string) error
+	// Close stops peer checks and closes pool peers, subsequent calls return ErrClientClosed. Repeated Close is no-op
 	Close() error
 }
 
+type PoolMembershipEventType string
+
+const (
+	PoolMembershipPeerAdded   PoolMembershipEventType = `added`
+	PoolMembershipPeerRemoved PoolMembershipEventType = `removed`
+)
+
+// PoolMembershipEvent describes peer added to or removed from pool
+type PoolMembershipEvent struct {
+	Type    PoolMembershipEventType
+	MspID   string
+	Address string
+	At      time.Time
+}
+
+// PoolMembershipHandler is called for each pool membership change in order of changes
+type PoolMembershipHandler func(event PoolMembershipEvent)
+
+// PeerStatus describes state of pool peer reported by check strategy
+type PeerStatus struct {
+	Address string
+	Ready   bool
+	// Since is time when peer state was changed last time, zero if state wasn't changed since peer was added
+	Since time.Time
+	// Circuit is state of peer circuit breaker, e.g. `open`, empty if peer has no circuit breaker
+	Circuit string
+}
+
 type PeerPoolCheckStrategy func(ctx context.Context, peer Peer, alive chan bool)
 
 func StrategyGRPC(d time.Duration) PeerPoolCheckStrategy {
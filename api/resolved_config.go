@@ -0,0 +1,36 @@
+package api
+
+// ResolvedConfig describes effective core configuration after applying options and defaults.
+// Private keys and crypto or discovery options are never included
+type ResolvedConfig struct {
+	// Peers are connections of pool peers by MSP id
+	Peers map[string][]ResolvedConnection
+	// Orderers are connections of default orderer, orderer set by option is reported without host
+	// as its endpoints are unknown
+	Orderers []ResolvedConnection
+	// ChannelOrderers are connections of orderers from discovery or channel config by channel name,
+	// channel orderers are reported after they're dialed
+	ChannelOrderers map[string][]ResolvedConnection
+	// DiscoveryType is configured type of discovery provider, empty if provider is set by option
+	DiscoveryType string
+	// CryptoType is type of crypto suite implementation
+	CryptoType string
+	FabricV2   bool
+}
+
+// ResolvedConnection describes connection endpoint, TLS settings are empty if connection is not set from config
+type ResolvedConnection struct {
+	Host string
+	TLS  ResolvedTLS
+}
+
+// ResolvedTLS describes TLS settings of connection with client key path redacted
+type ResolvedTLS struct {
+	Enabled      bool
+	SkipVerify   bool
+	HostOverride string
+	CertPath     string
+	CACertPath   string
+	// ClientKey reports whether client private key is configured
+	ClientKey bool
+}
@@ -0,0 +1,164 @@
+// Package audit allows to keep record of transaction envelopes submitted to orderer
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// ErrBufferFull is passed to error handler of auditor when record is dropped because buffer is full
+var ErrBufferFull = errors.New(`audit buffer is full, record is dropped`)
+
+// Entry is audit record written by FileSink
+type Entry struct {
+	TxID        string    `json:"tx_id"`
+	Envelope    []byte    `json:"envelope"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	Status      string    `json:"status,omitempty"`
+	Info        string    `json:"info,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// FileSink appends audit records to file as JSON lines
+type FileSink struct {
+	file *os.File
+	enc  *json.Encoder
+	mx   sync.Mutex
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+func (s *FileSink) Record(txID string, envelope []byte, submittedAt time.Time, result api.AuditResult) error {
+	entry := Entry{TxID: txID, Envelope: envelope, SubmittedAt: submittedAt, Info: result.Info}
+	if result.Err != nil {
+		entry.Error = result.Err.Error()
+	} else {
+		entry.Status = result.Status.String()
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.enc.Encode(entry)
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+type record struct {
+	envelope    *common.Envelope
+	submittedAt time.Time
+	result      api.AuditResult
+}
+
+// Auditor passes broadcast envelopes to sink asynchronously, so sink doesn't add latency to broadcast.
+// Records are buffered, if buffer is full record is dropped
+type Auditor struct {
+	sink      api.AuditSink
+	onError   func(err error)
+	records   chan record
+	dropped   uint64
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewAuditor starts passing records to sink, onError is called with sink errors and ErrBufferFull if presented
+func NewAuditor(sink api.AuditSink, bufferSize int, onError func(err error)) *Auditor {
+	a := &Auditor{
+		sink:    sink,
+		onError: onError,
+		records: make(chan record, bufferSize),
+		done:    make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *Auditor) run() {
+	defer close(a.done)
+	for r := range a.records {
+		if err := a.write(r); err != nil && a.onError != nil {
+			a.onError(err)
+		}
+	}
+}
+
+func (a *Auditor) write(r record) error {
+	envelope, err := proto.Marshal(r.envelope)
+	if err != nil {
+		return err
+	}
+
+	var txID string
+	if chHeader, err := protoutil.ChannelHeader(r.envelope); err == nil {
+		txID = chHeader.TxId
+	}
+
+	return a.sink.Record(txID, envelope, r.submittedAt, r.result)
+}
+
+// Orderer wraps orderer, so each broadcast envelope and its result are recorded
+func (a *Auditor) Orderer(orderer api.Orderer) api.Orderer {
+	return &auditOrderer{Orderer: orderer, auditor: a}
+}
+
+// Dropped returns number of records dropped because buffer was full
+func (a *Auditor) Dropped() uint64 {
+	return atomic.LoadUint64(&a.dropped)
+}
+
+// Close waits until buffered records are passed to sink, broadcasts mustn't be sent after close
+func (a *Auditor) Close() error {
+	a.closeOnce.Do(func() {
+		close(a.records)
+	})
+	<-a.done
+	return nil
+}
+
+func (a *Auditor) add(r record) {
+	select {
+	case a.records <- r:
+	default:
+		atomic.AddUint64(&a.dropped, 1)
+		if a.onError != nil {
+			a.onError(ErrBufferFull)
+		}
+	}
+}
+
+type auditOrderer struct {
+	api.Orderer
+	auditor *Auditor
+}
+
+func (o *auditOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	submittedAt := time.Now()
+	resp, err := o.Orderer.Broadcast(ctx, envelope)
+
+	result := api.AuditResult{Err: err}
+	if resp != nil {
+		result.Status, result.Info = resp.Status, resp.Info
+	}
+	o.auditor.add(record{envelope: envelope, submittedAt: submittedAt, result: result})
+
+	return resp, err
+}
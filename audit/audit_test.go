@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+type testOrderer struct {
+	api.Orderer
+}
+
+func (testOrderer) Broadcast(context.Context, *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	return &fabricOrderer.BroadcastResponse{Status: common.Status_SUCCESS}, nil
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `audit.log`)
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+
+	chHeader, err := proto.Marshal(&common.ChannelHeader{TxId: `tx1`})
+	require.NoError(t, err)
+	payload, err := proto.Marshal(&common.Payload{Header: &common.Header{ChannelHeader: chHeader}})
+	require.NoError(t, err)
+	envelope := &common.Envelope{Payload: payload, Signature: []byte(`sig`)}
+
+	auditor := NewAuditor(sink, 10, func(err error) { t.Error(err) })
+	_, err = auditor.Orderer(testOrderer{}).Broadcast(context.Background(), envelope)
+	require.NoError(t, err)
+	require.NoError(t, auditor.Close())
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	require.True(t, scanner.Scan())
+
+	var entry Entry
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+	require.Equal(t, `tx1`, entry.TxID)
+	require.Equal(t, common.Status_SUCCESS.String(), entry.Status)
+	require.WithinDuration(t, time.Now(), entry.SubmittedAt, time.Minute)
+
+	recorded := new(common.Envelope)
+	require.NoError(t, proto.Unmarshal(entry.Envelope, recorded))
+	require.True(t, proto.Equal(envelope, recorded))
+	require.False(t, scanner.Scan())
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+func (c *core) CheckCertificateExpiry(ctx context.Context, within time.Duration) []api.CertificateExpiry {
+	var connections []api.ResolvedConnection
+
+	resolved := c.ResolvedConfig()
+	for _, peers := range resolved.Peers {
+		connections = append(connections, peers...)
+	}
+	connections = append(connections, resolved.Orderers...)
+
+	var (
+		expiring []api.CertificateExpiry
+		mx       sync.Mutex
+		wg       sync.WaitGroup
+	)
+
+	deadline := time.Now().Add(within)
+	for _, conn := range connections {
+		// TLS settings of connections added by option are unknown, so only connections with enabled TLS are checked
+		if !conn.TLS.Enabled {
+			continue
+		}
+
+		wg.Add(1)
+		go func(conn api.ResolvedConnection) {
+			defer wg.Done()
+
+			expiry := api.CertificateExpiry{Host: conn.Host}
+			cert, err := util.ServerCertificate(ctx, conn.Host, conn.TLS.HostOverride)
+			if err != nil {
+				expiry.Err = err
+			} else {
+				if cert.NotAfter.After(deadline) {
+					return
+				}
+				expiry.NotAfter = cert.NotAfter
+			}
+
+			mx.Lock()
+			expiring = append(expiring, expiry)
+			mx.Unlock()
+		}(conn)
+	}
+
+	wg.Wait()
+	return expiring
+}
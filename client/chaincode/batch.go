@@ -0,0 +1,63 @@
+package chaincode
+
+import (
+	"context"
+	"sync"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// DefaultQueryBatchWorkers is number of concurrent queries of batch if it's not presented
+const DefaultQueryBatchWorkers = 10
+
+func (c *Core) QueryBatch(ctx context.Context, requests []api.QueryRequest, workers int) []api.QueryResponse {
+	if workers <= 0 {
+		workers = DefaultQueryBatchWorkers
+	}
+	if workers > len(requests) {
+		workers = len(requests)
+	}
+
+	peers := readyPeers(c.peerPool, c.identity.GetMSPIdentifier())
+	responses := make([]api.QueryResponse, len(requests))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				q := NewQueryBuilder(c, api.ResolveIdentity(c.identity), requests[i].Fn, requests[i].Args...).(*QueryBuilder)
+				if len(peers) > 0 {
+					q.target = peers[i%len(peers)]
+				}
+				responses[i].Payload, responses[i].Err = q.AsBytes(ctx)
+			}
+		}()
+	}
+
+	for i := range requests {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return responses
+}
+
+// readyPeers returns ready pool peers of organization
+func readyPeers(pool api.PeerPool, mspID string) []api.Peer {
+	ready := make(map[string]bool)
+	for _, status := range pool.Status()[mspID] {
+		ready[status.Address] = status.Ready
+	}
+
+	var peers []api.Peer
+	for _, p := range pool.Peers()[mspID] {
+		if ready[p.Uri()] {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,87 @@
+package chaincode_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/client"
+	sdkinvoker "github.com/s7techlab/hlf-sdk-go/client/invoker"
+	"github.com/s7techlab/hlf-sdk-go/crypto"
+	"github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+	"github.com/s7techlab/hlf-sdk-go/identity"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+	sdkpeer "github.com/s7techlab/hlf-sdk-go/peer"
+	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+)
+
+// hangingEndorser never responds and reports when call is torn down by client
+type hangingEndorser struct {
+	aborted chan struct{}
+}
+
+func (e *hangingEndorser) ProcessProposal(ctx context.Context, _ *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	<-ctx.Done()
+	e.aborted <- struct{}{}
+	return nil, ctx.Err()
+}
+
+func TestInvokeCancelledDuringEndorsement(t *testing.T) {
+	lis, err := net.Listen(`tcp`, `127.0.0.1:0`)
+	require.NoError(t, err)
+
+	endorser := &hangingEndorser{aborted: make(chan struct{}, 3)}
+	srv := grpc.NewServer()
+	peer.RegisterEndorserServer(srv, endorser)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// peer timeout is much longer than test, so only cancellation can abort endorsement
+	p, err := sdkpeer.NewFromGRPC(conn, logger.DefaultLogger, time.Hour)
+	require.NoError(t, err)
+
+	peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{})
+	for _, mspID := range []string{`org1msp`, `org2msp`, `org3msp`} {
+		require.NoError(t, peerPool.Add(mspID, p, defaultAlivePeer))
+	}
+
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `./testdata/msp`)
+	require.NoError(t, err)
+
+	cryptoSuite, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	core, err := client.NewCore(`org1msp`, id,
+		client.WithOrderer(&mockOrderer{}),
+		client.WithPeerPool(peerPool),
+		client.WithConfigYaml(`./testdata/config.yaml`),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	started := time.Now()
+	_, _, err = sdkinvoker.New(core).Invoke(ctx, id.GetSigningIdentity(cryptoSuite),
+		`success-network`, `my-chaincode`, `call`, nil, nil)
+	require.Equal(t, context.Canceled, errors.Cause(err))
+	require.Less(t, int64(time.Since(started)), int64(5*time.Second))
+
+	// in-flight GRPC call is cancelled on server side too
+	select {
+	case <-endorser.aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal(`endorsement call was not aborted on peer`)
+	}
+}
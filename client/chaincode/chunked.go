@@ -0,0 +1,70 @@
+package chaincode
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// ChunkHandler receives payload of each chunk, returned error stops query
+type ChunkHandler func(payload []byte) error
+
+// QueryChunked queries chaincode which returns large result in chunks with continuation token.
+// Token from previous response is passed as last argument, empty token is passed on first query.
+// Response payload must be JSON object with token in tokenField, nested fields are separated by dot,
+// e.g. `metadata.bookmark`. Query is repeated until token is empty, each chunk is passed to handler as is
+func QueryChunked(ctx context.Context, cc api.Chaincode, tokenField string, fn string, args []string, handler ChunkHandler) error {
+	var token string
+	for {
+		chunkArgs := make([]string, 0, len(args)+1)
+		chunkArgs = append(append(chunkArgs, args...), token)
+
+		payload, err := cc.Query(fn, chunkArgs...).AsBytes(ctx)
+		if err != nil {
+			return errors.Wrap(err, `failed to query chunk`)
+		}
+
+		if err = handler(payload); err != nil {
+			return err
+		}
+
+		next, err := continuationToken(payload, tokenField)
+		if err != nil {
+			return err
+		}
+		if next == `` {
+			return nil
+		}
+		if next == token {
+			return errors.Errorf(`continuation token %s is not changed by chaincode`, token)
+		}
+		token = next
+	}
+}
+
+func continuationToken(payload []byte, tokenField string) (string, error) {
+	path := strings.Split(tokenField, `.`)
+
+	value := json.RawMessage(payload)
+	for _, field := range path {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err != nil {
+			return ``, errors.Wrapf(err, `failed to get %s from chunk`, tokenField)
+		}
+		var ok bool
+		if value, ok = fields[field]; !ok {
+			// no token means last chunk
+			return ``, nil
+		}
+	}
+
+	var token string
+	if err := json.Unmarshal(value, &token); err != nil {
+		return ``, errors.Wrapf(err, `failed to unmarshal %s`, tokenField)
+	}
+	return token, nil
+}
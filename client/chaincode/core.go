@@ -5,6 +5,8 @@ import (
 
 	"github.com/hyperledger/fabric/msp"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/s7techlab/hlf-sdk-go/api"
 )
 
@@ -16,6 +18,14 @@ type Core struct {
 	orderer     api.Orderer
 	dp          api.DiscoveryProvider
 	identity    msp.SigningIdentity
+	cs          api.CryptoSuite
+	affinity    *api.QueryAffinity
+	txID        api.TxIDGenerator
+	errDecoder  api.ResponseErrorDecoder
+	timeouts    api.InvokeTimeouts
+	gateway     api.Gateway
+	heights     heightCache
+	tracer      trace.Tracer
 }
 
 func (c *Core) Invoke(fn string) api.ChaincodeInvokeBuilder {
@@ -23,7 +33,11 @@ func (c *Core) Invoke(fn string) api.ChaincodeInvokeBuilder {
 }
 
 func (c *Core) Query(fn string, args ...string) api.ChaincodeQueryBuilder {
-	return NewQueryBuilder(c, c.identity, fn, args...)
+	return NewQueryBuilder(c, api.ResolveIdentity(c.identity), fn, args...)
+}
+
+func (c *Core) InvokeWithPolicy(ctx context.Context, fn string, args [][]byte, options ...api.DoOption) (*api.Result, error) {
+	return c.Invoke(fn).ArgBytes(args).DoResult(ctx, append([]api.DoOption{WithPolicyEndorsers()}, options...)...)
 }
 
 func (c *Core) Install(version string) {
@@ -31,15 +45,67 @@ func (c *Core) Install(version string) {
 }
 
 func (c *Core) Subscribe(ctx context.Context) (api.EventCCSubscription, error) {
-	peerDeliver, err := c.peerPool.DeliverClient(c.mspId, c.identity)
+	peerDeliver, err := c.peerPool.DeliverClient(c.mspId, api.ResolveIdentity(c.identity))
 	if err != nil {
 		return nil, errors.Wrap(err, `failed to initiate DeliverClient`)
 	}
 	return peerDeliver.SubscribeCC(ctx, c.channelName, c.name)
 }
 
-func NewCore(mspId, ccName, channelName string, peerPool api.PeerPool, orderer api.Orderer, dp api.DiscoveryProvider, identity msp.SigningIdentity) *Core {
-	return &Core{
+// Opt sets optional dependency of chaincode core
+type Opt func(c *Core)
+
+// WithCryptoSuite sets crypto suite used to get signing identity of per-call identities
+func WithCryptoSuite(cs api.CryptoSuite) Opt {
+	return func(c *Core) {
+		c.cs = cs
+	}
+}
+
+// WithQueryAffinity routes chaincode queries to peers of affinity MSP
+func WithQueryAffinity(affinity *api.QueryAffinity) Opt {
+	return func(c *Core) {
+		c.affinity = affinity
+	}
+}
+
+// WithTxIDGenerator sets generator of transaction ids
+func WithTxIDGenerator(txID api.TxIDGenerator) Opt {
+	return func(c *Core) {
+		c.txID = txID
+	}
+}
+
+// WithErrorDecoder sets decoder of chaincode error responses
+func WithErrorDecoder(errDecoder api.ResponseErrorDecoder) Opt {
+	return func(c *Core) {
+		c.errDecoder = errDecoder
+	}
+}
+
+// WithDefaultInvokeTimeouts sets timeouts of invoke stages used unless they are set per invoke
+func WithDefaultInvokeTimeouts(timeouts api.InvokeTimeouts) Opt {
+	return func(c *Core) {
+		c.timeouts = timeouts
+	}
+}
+
+// WithGateway makes invokes and queries to be sent by Gateway service
+func WithGateway(gateway api.Gateway) Opt {
+	return func(c *Core) {
+		c.gateway = gateway
+	}
+}
+
+// WithTracer sets tracer of invokes and queries, noop tracer is used by default
+func WithTracer(tracer trace.Tracer) Opt {
+	return func(c *Core) {
+		c.tracer = tracer
+	}
+}
+
+func NewCore(mspId, ccName, channelName string, peerPool api.PeerPool, orderer api.Orderer, dp api.DiscoveryProvider, identity msp.SigningIdentity, opts ...Opt) *Core {
+	c := &Core{
 		mspId:       mspId,
 		name:        ccName,
 		channelName: channelName,
@@ -48,4 +114,11 @@ func NewCore(mspId, ccName, channelName string, peerPool api.PeerPool, orderer a
 		dp:          dp,
 		identity:    identity,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.tracer == nil {
+		c.tracer = trace.NewNoopTracerProvider().Tracer(TracerName)
+	}
+	return c
 }
@@ -74,7 +74,7 @@ func (c *corePackage) Instantiate(ctx context.Context, channelName, path, versio
 		return errors.Wrap(err, `failed to pnmarshal proposal for make peer.Proposal`)
 	}
 
-	env, err := protoutil.CreateSignedTx(peerProp, c.identity, resp)
+	env, err := protoutil.CreateSignedTx(peerProp, api.ResolveIdentity(c.identity), resp)
 	if err != nil {
 		return errors.Wrap(err, "could not assemble transaction")
 	}
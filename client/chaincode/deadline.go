@@ -0,0 +1,50 @@
+package chaincode
+
+import (
+	"context"
+	"time"
+)
+
+type endorseDeadlineKey struct{}
+
+type commitDeadlineKey struct{}
+
+// ContextWithEndorseDeadline returns context which limits endorsement stage of invokes with presented deadline
+func ContextWithEndorseDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, endorseDeadlineKey{}, deadline)
+}
+
+// EndorseDeadlineFromContext returns deadline set by ContextWithEndorseDeadline
+func EndorseDeadlineFromContext(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Value(endorseDeadlineKey{}).(time.Time)
+	return deadline, ok
+}
+
+// ContextWithCommitDeadline returns context which limits broadcast and commit waiting of invokes with presented deadline
+func ContextWithCommitDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, commitDeadlineKey{}, deadline)
+}
+
+// CommitDeadlineFromContext returns deadline set by ContextWithCommitDeadline
+func CommitDeadlineFromContext(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Value(commitDeadlineKey{}).(time.Time)
+	return deadline, ok
+}
+
+// stageContext returns context of invoke stage. Stage is limited by first presented of:
+// deadline from context value, timeout from invoke options, default timeout of core.
+// Deadline of parent context is always honored
+func stageContext(ctx context.Context, fromContext func(context.Context) (time.Time, bool),
+	timeout, defaultTimeout time.Duration) (context.Context, context.CancelFunc) {
+
+	if deadline, ok := fromContext(ctx); ok {
+		return context.WithDeadline(ctx, deadline)
+	}
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	if defaultTimeout > 0 {
+		return context.WithTimeout(ctx, defaultTimeout)
+	}
+	return context.WithCancel(ctx)
+}
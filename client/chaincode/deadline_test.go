@@ -0,0 +1,45 @@
+package chaincode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStageContext(t *testing.T) {
+	now := time.Now()
+	ctxDeadline := now.Add(time.Minute)
+
+	deadlineOf := func(ctx context.Context, timeout, defaultTimeout time.Duration) (time.Time, bool) {
+		stageCtx, cancel := stageContext(ctx, EndorseDeadlineFromContext, timeout, defaultTimeout)
+		defer cancel()
+		return stageCtx.Deadline()
+	}
+
+	// context value takes precedence over options and defaults
+	deadline, ok := deadlineOf(ContextWithEndorseDeadline(context.Background(), ctxDeadline), time.Second, time.Hour)
+	require.True(t, ok)
+	require.Equal(t, ctxDeadline, deadline)
+
+	// commit deadline doesn't limit endorsement
+	deadline, ok = deadlineOf(ContextWithCommitDeadline(context.Background(), ctxDeadline), time.Second, time.Hour)
+	require.True(t, ok)
+	require.WithinDuration(t, now.Add(time.Second), deadline, time.Second)
+
+	// default timeout is used without context value and options
+	deadline, ok = deadlineOf(context.Background(), 0, time.Hour)
+	require.True(t, ok)
+	require.WithinDuration(t, now.Add(time.Hour), deadline, time.Second)
+
+	_, ok = deadlineOf(context.Background(), 0, 0)
+	require.False(t, ok)
+
+	// parent context deadline is always honored
+	parent, cancel := context.WithDeadline(context.Background(), now.Add(time.Millisecond))
+	defer cancel()
+	deadline, ok = deadlineOf(ContextWithEndorseDeadline(parent, ctxDeadline), 0, 0)
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Millisecond), deadline)
+}
@@ -0,0 +1,112 @@
+package chaincode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+// instrumentedPool collects status and latency of each endorsement processed by pool.
+// Endorsements abandoned by cancelled Send can complete later, so infos are read by snapshot
+type instrumentedPool struct {
+	api.PeerPool
+	infos []api.EndorsementInfo
+	mx    sync.Mutex
+}
+
+func (p *instrumentedPool) Process(ctx context.Context, mspId string, proposal *fabricPeer.SignedProposal) (*fabricPeer.ProposalResponse, error) {
+	started := time.Now()
+	resp, err := p.PeerPool.Process(ctx, mspId, proposal)
+
+	info := api.EndorsementInfo{
+		MspID:    mspId,
+		Endorser: resp.GetEndorsement().GetEndorser(),
+		Status:   resp.GetResponse().GetStatus(),
+		Latency:  time.Since(started),
+		Err:      err,
+	}
+
+	p.mx.Lock()
+	p.infos = append(p.infos, info)
+	p.mx.Unlock()
+
+	return resp, err
+}
+
+// snapshot returns copy of endorsements collected so far
+func (p *instrumentedPool) snapshot() []api.EndorsementInfo {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	return append([]api.EndorsementInfo(nil), p.infos...)
+}
+
+// policyEndorsers returns minimal set of organizations with ready peers which endorsements satisfy policy,
+// all organizations with ready peers are returned if policy is empty
+func policyEndorsers(pool api.PeerPool, policy string) ([]string, error) {
+	var ready []string
+	for mspID, peers := range pool.Status() {
+		for _, p := range peers {
+			if p.Ready {
+				ready = append(ready, mspID)
+				break
+			}
+		}
+	}
+	sort.Strings(ready)
+
+	if policy == `` {
+		if len(ready) == 0 {
+			return nil, errors.New(`no organizations with ready peers`)
+		}
+		return ready, nil
+	}
+
+	endorsers, err := util.SelectEndorsers(policy, ready)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to select endorsers`)
+	}
+	if endorsers == nil {
+		return nil, api.PolicyUnsatisfiableError{Policy: policy, Ready: ready}
+	}
+	return endorsers, nil
+}
+
+// plannedEndorsers returns endorsers from endorsement plan of discovery provider if planned organizations have
+// ready peers, otherwise endorsers are selected by chaincode policy. Plan isn't used for endorsers scoped by collections
+func (b *invokeBuilder) plannedEndorsers(doOpts *api.DoOptions, policy string) ([]string, error) {
+	if planner, ok := b.ccCore.dp.(api.EndorsersPlanner); ok && len(doOpts.Collections) == 0 {
+		planned, ok := planner.Endorsers(b.ccCore.channelName, b.ccCore.name)
+		if ok && checkEndorsersReady(doOpts.Pool, planned) == nil {
+			return planned, nil
+		}
+	}
+	return policyEndorsers(doOpts.Pool, policy)
+}
+
+// checkEndorsersReady checks that each of organizations has ready peer in pool
+func checkEndorsersReady(pool api.PeerPool, mspIDs []string) error {
+	status := pool.Status()
+	for _, mspID := range mspIDs {
+		peers, ok := status[mspID]
+		if !ok {
+			return fmt.Errorf(`required endorser %s: %w`, mspID, api.ErrMSPNotFound)
+		}
+
+		ready := false
+		for _, p := range peers {
+			ready = ready || p.Ready
+		}
+		if !ready {
+			return api.ErrNoReadyPeers{MspId: mspID}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package chaincode
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+	"github.com/s7techlab/hlf-sdk-go/util/txflags"
+)
+
+// EventReconnectDelay is delay before chaincode event subscription resubscribes on blocks after stream error
+var EventReconnectDelay = time.Second
+
+func (c *Core) SubscribeEvents(ctx context.Context, eventName string, fromBlock *uint64) (api.ChaincodeEventSubscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &eventSubscription{
+		core:      c,
+		eventName: eventName,
+		next:      fromBlock,
+		cancel:    cancel,
+		events:    make(chan *api.ChaincodeEvent),
+		errors:    make(chan error, 1),
+	}
+
+	blocks, err := sub.subscribe(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go sub.handle(ctx, blocks)
+	return sub, nil
+}
+
+type eventSubscription struct {
+	core      *Core
+	eventName string
+	// next is number of block which delivery is started from on (re)subscription, newest block if nil
+	next   *uint64
+	cancel context.CancelFunc
+	events chan *api.ChaincodeEvent
+	errors chan error
+}
+
+func (s *eventSubscription) subscribe(ctx context.Context) (api.BlockSubscription, error) {
+	deliver, err := s.core.peerPool.DeliverClient(s.core.mspId, api.ResolveIdentity(s.core.identity))
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to initiate DeliverClient`)
+	}
+
+	seek := api.SeekNewest()
+	if s.next != nil {
+		seek = api.SeekFrom(*s.next)
+	}
+
+	return deliver.SubscribeBlock(ctx, s.core.channelName, seek)
+}
+
+func (s *eventSubscription) handle(ctx context.Context, blocks api.BlockSubscription) {
+	defer close(s.errors)
+	defer close(s.events)
+	defer s.cancel()
+
+	for {
+		var streamErr error
+		select {
+		case <-ctx.Done():
+			_ = blocks.Close()
+			return
+
+		case block, ok := <-blocks.Blocks():
+			if ok {
+				if err := s.handleBlock(ctx, block); err != nil {
+					_ = blocks.Close()
+					if ctx.Err() == nil {
+						s.errors <- err
+					}
+					return
+				}
+				// block is delivered completely, so subscription is resumed from next one
+				next := block.GetHeader().GetNumber() + 1
+				s.next = &next
+				continue
+			}
+			streamErr = errors.New(`block stream closed`)
+
+		case streamErr = <-blocks.Errors():
+			if streamErr == nil {
+				streamErr = errors.New(`block stream closed`)
+			}
+		}
+
+		_ = blocks.Close()
+		if blocks = s.resubscribe(ctx); blocks == nil {
+			return
+		}
+	}
+}
+
+// resubscribe subscribes on blocks starting from next one until success or context is done
+func (s *eventSubscription) resubscribe(ctx context.Context) api.BlockSubscription {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(EventReconnectDelay):
+		}
+
+		if blocks, err := s.subscribe(ctx); err == nil {
+			return blocks
+		}
+	}
+}
+
+// handleBlock sends events of chaincode from valid transactions of block
+func (s *eventSubscription) handleBlock(ctx context.Context, block *common.Block) error {
+	var flags txflags.ValidationFlags
+	if len(block.GetMetadata().GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+
+	for i, envBytes := range block.GetData().GetData() {
+		if i < len(flags) && !flags.IsValid(i) {
+			continue
+		}
+
+		event, err := util.GetEventFromEnvelope(envBytes)
+		if err != nil {
+			if util.IsErrUnsupportedTxType(err) {
+				continue
+			}
+			return errors.Wrapf(err, `failed to get event from block %d`, block.GetHeader().GetNumber())
+		}
+
+		if event.GetChaincodeId() != s.core.name || event.GetEventName() == `` {
+			continue
+		}
+		if s.eventName != `` && event.EventName != s.eventName {
+			continue
+		}
+
+		select {
+		case s.events <- &api.ChaincodeEvent{Event: event, BlockNumber: block.GetHeader().GetNumber(), TxID: event.TxId}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (s *eventSubscription) Events() <-chan *api.ChaincodeEvent {
+	return s.events
+}
+
+func (s *eventSubscription) Errors() <-chan error {
+	return s.errors
+}
+
+func (s *eventSubscription) Close() error {
+	s.cancel()
+	return nil
+}
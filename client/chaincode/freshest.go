@@ -0,0 +1,192 @@
+package chaincode
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/util"
+	qsccPkg "github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/peer"
+)
+
+var (
+	// HeightCacheTTL is period during which channel heights of peers probed for freshest read are reused
+	HeightCacheTTL = time.Second
+	// HeightProbeTimeout limits probe of channel heights of peers for freshest read
+	HeightProbeTimeout = 3 * time.Second
+)
+
+type peerHeight struct {
+	quorumPeer
+	height uint64
+}
+
+// heightCache keeps channel heights of pool peers probed last time
+type heightCache struct {
+	heights  []peerHeight
+	probedAt time.Time
+	// probe is probe in progress, nil if heights aren't probed now
+	probe *heightProbe
+	mx    sync.Mutex
+}
+
+// heightProbe is probe of channel heights shared by concurrent callers, heights are set before done is closed
+type heightProbe struct {
+	done    chan struct{}
+	heights []peerHeight
+}
+
+// WithFreshestRead makes query to be sent to peer which has highest channel height among pool peers.
+// Query reflects state committed at least at highest height observed by probe of pool peers,
+// which is made not earlier than HeightCacheTTL before query. Read isn't linearizable: blocks committed after probe
+// may be not visible. If all probed peers have same height or probe fails, query is processed as usual
+func (q *QueryBuilder) WithFreshestRead() api.ChaincodeQueryBuilder {
+	q.freshest = true
+	return q
+}
+
+// processFreshest sends proposal to peer with highest channel height, peers of query affinity organization
+// and then of identity organization are preferred. With strict affinity only peers of affinity organization are candidates.
+// Returns false if there is no single freshest group of peers or freshest peer is unreachable
+func (q *QueryBuilder) processFreshest(ctx context.Context, proposal *fabricPeer.SignedProposal) (*fabricPeer.ProposalResponse, bool, error) {
+	heights := q.ccCore.heights.get(ctx, q.peerPool, q.identity, q.ccCore.channelName)
+
+	preferredMSPs := []string{q.identity.GetMSPIdentifier()}
+	if affinity := q.ccCore.affinity; affinity != nil {
+		preferredMSPs = append([]string{affinity.MspID}, preferredMSPs...)
+		if affinity.Strict {
+			var affine []peerHeight
+			for _, h := range heights {
+				if h.mspID == affinity.MspID {
+					affine = append(affine, h)
+				}
+			}
+			heights = affine
+		}
+	}
+
+	var maxHeight uint64
+	for _, h := range heights {
+		if h.height > maxHeight {
+			maxHeight = h.height
+		}
+	}
+
+	var freshest []peerHeight
+	for _, h := range heights {
+		if h.height == maxHeight {
+			freshest = append(freshest, h)
+		}
+	}
+	if maxHeight == 0 || len(freshest) == len(heights) {
+		return nil, false, nil
+	}
+
+	selected := freshest[0]
+selection:
+	for _, mspID := range preferredMSPs {
+		for _, h := range freshest {
+			if h.mspID == mspID {
+				selected = h
+				break selection
+			}
+		}
+	}
+
+	resp, err := selected.peer.Endorse(ctx, proposal)
+	if err != nil {
+		// chaincode errors are returned, unavailable peer leads to usual processing
+		if _, ok := errors.Cause(err).(api.PeerEndorseError); !ok {
+			return nil, false, nil
+		}
+	}
+	return resp, true, err
+}
+
+// get returns cached heights or waits for probe of channel height of each pool peer, unreachable peers are skipped.
+// Single probe is made for concurrent callers, it is limited by HeightProbeTimeout instead of caller context,
+// so cancelled caller doesn't fail probe for others. Failed or empty probes aren't cached
+func (c *heightCache) get(ctx context.Context, pool api.PeerPool, identity msp.SigningIdentity, channelName string) []peerHeight {
+	c.mx.Lock()
+	if len(c.heights) > 0 && time.Since(c.probedAt) < HeightCacheTTL {
+		heights := c.heights
+		c.mx.Unlock()
+		return heights
+	}
+	if c.probe == nil {
+		c.probe = &heightProbe{done: make(chan struct{})}
+		go c.run(c.probe, pool, identity, channelName)
+	}
+	probe := c.probe
+	c.mx.Unlock()
+
+	select {
+	case <-probe.done:
+		return probe.heights
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// run probes channel height of each pool peer concurrently and caches heights if any peer responded
+func (c *heightCache) run(probe *heightProbe, pool api.PeerPool, identity msp.SigningIdentity, channelName string) {
+	defer close(probe.done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), HeightProbeTimeout)
+	defer cancel()
+	probe.heights = probeHeights(ctx, pool, identity, channelName)
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if len(probe.heights) > 0 {
+		c.heights, c.probedAt = probe.heights, time.Now()
+	}
+	c.probe = nil
+}
+
+func probeHeights(ctx context.Context, pool api.PeerPool, identity msp.SigningIdentity, channelName string) []peerHeight {
+	proposal, _, err := peer.NewProcessor(``).CreateProposal(
+		&api.DiscoveryChaincode{Name: `qscc`, Type: api.CCTypeGoLang},
+		identity, qsccPkg.GetChainInfo, util.ToChaincodeArgs(channelName), nil)
+	if err != nil {
+		return nil
+	}
+
+	var (
+		heights []peerHeight
+		mx      sync.Mutex
+		wg      sync.WaitGroup
+	)
+	for mspID, peers := range pool.Peers() {
+		for _, p := range peers {
+			wg.Add(1)
+			go func(mspID string, p api.Peer) {
+				defer wg.Done()
+
+				resp, err := p.Endorse(ctx, proposal)
+				if err != nil {
+					return
+				}
+				info := new(common.BlockchainInfo)
+				if err = proto.Unmarshal(resp.GetResponse().GetPayload(), info); err != nil {
+					return
+				}
+
+				mx.Lock()
+				heights = append(heights, peerHeight{quorumPeer: quorumPeer{mspID: mspID, peer: p}, height: info.Height})
+				mx.Unlock()
+			}(mspID, p)
+		}
+	}
+	wg.Wait()
+
+	return heights
+}
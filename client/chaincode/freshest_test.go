@@ -0,0 +1,106 @@
+package chaincode_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/client"
+	"github.com/s7techlab/hlf-sdk-go/identity"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+)
+
+// heightPeer reports presented channel height to QSCC probes and responds to queries with its address
+type heightPeer struct {
+	api.Peer
+	uri     string
+	height  uint64
+	queries int32
+}
+
+func (p *heightPeer) Endorse(_ context.Context, proposal *peer.SignedProposal, _ ...api.PeerEndorseOpt) (*peer.ProposalResponse, error) {
+	prop, err := protoutil.UnmarshalProposal(proposal.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := protoutil.UnmarshalChaincodeProposalPayload(prop.Payload)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := protoutil.UnmarshalChaincodeInvocationSpec(payload.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.ChaincodeSpec.ChaincodeId.Name == `qscc` {
+		info, err := proto.Marshal(&common.BlockchainInfo{Height: p.height})
+		if err != nil {
+			return nil, err
+		}
+		return &peer.ProposalResponse{Response: &peer.Response{Status: 200, Payload: info}}, nil
+	}
+
+	atomic.AddInt32(&p.queries, 1)
+	return &peer.ProposalResponse{Response: &peer.Response{Status: 200, Payload: []byte(p.uri)}}, nil
+}
+
+func (p *heightPeer) Uri() string { return p.uri }
+
+func (p *heightPeer) Close() error { return nil }
+
+func TestFreshestReadWithAffinity(t *testing.T) {
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `./testdata/msp`)
+	require.NoError(t, err)
+
+	newCore := func(t *testing.T, strict bool) (api.Core, map[string]*heightPeer) {
+		peers := map[string]*heightPeer{
+			`org1msp`: {uri: `peer0.org1:7051`, height: 10},
+			`org2msp`: {uri: `peer0.org2:7051`, height: 10},
+			`org3msp`: {uri: `peer0.org3:7051`, height: 5},
+		}
+		peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{})
+		for mspID, p := range peers {
+			require.NoError(t, peerPool.Add(mspID, p, defaultAlivePeer))
+		}
+
+		core, err := client.NewCore(`org1msp`, id,
+			client.WithOrderer(&mockOrderer{}),
+			client.WithPeerPool(peerPool),
+			client.WithConfigYaml(`./testdata/config.yaml`),
+			client.WithQueryAffinity(`org3msp`, strict),
+		)
+		require.NoError(t, err)
+		return core, peers
+	}
+
+	t.Run(`strict affinity`, func(t *testing.T) {
+		core, peers := newCore(t, true)
+
+		// fresher peers of other organizations aren't queried
+		resp, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).
+			Query(`get`).WithFreshestRead().AsBytes(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, `peer0.org3:7051`, string(resp))
+		require.Equal(t, int32(0), atomic.LoadInt32(&peers[`org1msp`].queries))
+		require.Equal(t, int32(0), atomic.LoadInt32(&peers[`org2msp`].queries))
+	})
+
+	t.Run(`not strict affinity`, func(t *testing.T) {
+		core, _ := newCore(t, false)
+
+		// affinity organization isn't among freshest ones, so identity organization is preferred
+		resp, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).
+			Query(`get`).WithFreshestRead().AsBytes(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, `peer0.org1:7051`, string(resp))
+	})
+}
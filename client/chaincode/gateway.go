@@ -0,0 +1,144 @@
+package chaincode
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode/txwaiter"
+)
+
+// invokeGateway endorses and submits transaction by Gateway service, commit is awaited by gateway commit status
+// unless tx waiter doesn't wait. Endorsing organizations are chosen by gateway if required endorsers aren't set
+func (b *invokeBuilder) invokeGateway(ctx context.Context, gw api.Gateway, tx api.ChaincodeTx,
+	proposal *fabricPeer.SignedProposal, doOpts *api.DoOptions, timing *api.InvokeTiming) (api.ChaincodeTx, []*fabricPeer.ProposalResponse, error) {
+	endorseCtx, cancelEndorse := stageContext(ctx, EndorseDeadlineFromContext, doOpts.Timeouts.Endorse, b.ccCore.timeouts.Endorse)
+	defer cancelEndorse()
+
+	stageStarted := time.Now()
+	envelope, err := gw.Endorse(endorseCtx, b.ccCore.channelName, tx, proposal, doOpts.RequiredEndorsers...)
+	timing.Endorsement = time.Since(stageStarted)
+	if err != nil {
+		if ctxErr := endorseCtx.Err(); ctxErr != nil {
+			return tx, nil, ctxErr
+		}
+		return tx, nil, errors.Wrap(err, `failed to endorse by gateway`)
+	}
+
+	peerResponses, err := envelopeResponses(envelope)
+	if err != nil {
+		return tx, nil, errors.Wrap(err, `failed to get endorsements from prepared transaction`)
+	}
+
+	if b.event, err = endorsedEvent(peerResponses[0], tx, b.ccCore.name); err != nil {
+		return tx, nil, err
+	}
+
+	if envelope.Signature, err = b.identity.Sign(envelope.Payload); err != nil {
+		return tx, nil, errors.Wrap(err, `failed to sign prepared transaction`)
+	}
+
+	if doOpts.Sizes != nil {
+		doOpts.Sizes.Proposal = proto.Size(proposal)
+		doOpts.Sizes.LargestResponse = largestResponseSize(peerResponses)
+		doOpts.Sizes.Envelope = proto.Size(envelope)
+	}
+
+	commitCtx, cancelCommit := stageContext(ctx, CommitDeadlineFromContext, doOpts.Timeouts.Commit, b.ccCore.timeouts.Commit)
+	defer cancelCommit()
+
+	stageStarted = time.Now()
+	err = gw.Submit(commitCtx, b.ccCore.channelName, tx, envelope)
+	timing.Broadcast = time.Since(stageStarted)
+	if err != nil {
+		return tx, nil, errors.Wrap(err, `failed to submit by gateway`)
+	}
+
+	if txwaiter.Waits(b.txWaiter) {
+		stageStarted = time.Now()
+		code, blockNumber, err := gw.CommitStatus(commitCtx, b.ccCore.channelName, tx, b.identity)
+		timing.Commit = time.Since(stageStarted)
+		if err != nil {
+			return tx, nil, errors.Wrap(err, `failed to get commit status`)
+		}
+		if code != fabricPeer.TxValidationCode_VALID {
+			return tx, nil, api.InvalidTxError{TxId: tx, Code: code}
+		}
+		b.commitBlock = &blockNumber
+	}
+
+	if len(doOpts.PostCommitVerifyKeys) > 0 {
+		if err = b.verifyCommitted(ctx, tx, peerResponses[0], doOpts.PostCommitReadFn, doOpts.PostCommitVerifyKeys); err != nil {
+			return tx, nil, err
+		}
+	}
+
+	return tx, peerResponses, nil
+}
+
+// envelopeResponses restores proposal responses from endorsed transaction, one response per endorsement
+func envelopeResponses(envelope *common.Envelope) ([]*fabricPeer.ProposalResponse, error) {
+	payload, err := protoutil.UnmarshalPayload(envelope.GetPayload())
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal payload`)
+	}
+
+	transaction, err := protoutil.UnmarshalTransaction(payload.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal transaction`)
+	}
+	if len(transaction.Actions) == 0 {
+		return nil, errors.New(`transaction has no actions`)
+	}
+
+	actionPayload, err := protoutil.UnmarshalChaincodeActionPayload(transaction.Actions[0].Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal chaincode action payload`)
+	}
+
+	responsePayload, err := protoutil.UnmarshalProposalResponsePayload(actionPayload.GetAction().GetProposalResponsePayload())
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal proposal response payload`)
+	}
+
+	action, err := protoutil.UnmarshalChaincodeAction(responsePayload.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal chaincode action`)
+	}
+
+	endorsements := actionPayload.GetAction().GetEndorsements()
+	if len(endorsements) == 0 {
+		endorsements = []*fabricPeer.Endorsement{nil}
+	}
+
+	responses := make([]*fabricPeer.ProposalResponse, len(endorsements))
+	for i, endorsement := range endorsements {
+		responses[i] = &fabricPeer.ProposalResponse{
+			Version:     1,
+			Response:    action.Response,
+			Payload:     actionPayload.Action.ProposalResponsePayload,
+			Endorsement: endorsement,
+		}
+	}
+	return responses, nil
+}
+
+// evaluate sends query by Gateway service, query is evaluated by affinity MSP peers in strict affinity mode
+func (q *QueryBuilder) evaluate(ctx context.Context, gw api.Gateway, tx api.ChaincodeTx, proposal *fabricPeer.SignedProposal) (*fabricPeer.ProposalResponse, error) {
+	var targets []string
+	if affinity := q.ccCore.affinity; affinity != nil && affinity.Strict {
+		targets = append(targets, affinity.MspID)
+	}
+
+	resp, err := gw.Evaluate(ctx, q.ccCore.channelName, tx, proposal, targets...)
+	if err != nil {
+		return nil, err
+	}
+	return &fabricPeer.ProposalResponse{Version: 1, Response: resp}, nil
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
@@ -13,19 +15,30 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode/system"
 	"github.com/s7techlab/hlf-sdk-go/client/chaincode/txwaiter"
 	"github.com/s7techlab/hlf-sdk-go/peer"
+	"github.com/s7techlab/hlf-sdk-go/util"
 )
 
 type invokeBuilder struct {
-	ccCore        *Core
-	fn            string
-	processor     api.PeerProcessor
-	peerPool      api.PeerPool
-	identity      msp.SigningIdentity
-	txWaiter      api.TxWaiter
+	ccCore         *Core
+	fn             string
+	processor      api.PeerProcessor
+	peerPool       api.PeerPool
+	identity       msp.SigningIdentity
+	txWaiter       api.TxWaiter
+	returnWriteSet bool
+	broadcastInfo  string
+	// commitBlock is number of block with committed transaction if it is reported by tx waiter
+	commitBlock *uint64
+	// event is chaincode event of endorsed transaction, it is validated before broadcast
+	event         *fabricPeer.ChaincodeEvent
 	args          [][]byte
 	transientArgs api.TransArgs
+	// retryAttempts and retryBackoff are set by RetryOnConflict
+	retryAttempts int
+	retryBackoff  time.Duration
 	err           *errArgMap
 }
 
@@ -98,6 +111,15 @@ func (b *invokeBuilder) WithIdentity(identity msp.SigningIdentity) api.Chaincode
 	return b
 }
 
+func (b *invokeBuilder) As(identity api.Identity) api.ChaincodeInvokeBuilder {
+	signing, err := signingIdentity(identity, b.ccCore.cs)
+	if err != nil {
+		b.err.Add(`identity`, err)
+		return b
+	}
+	return b.WithIdentity(signing)
+}
+
 func (b *invokeBuilder) ArgBytes(args [][]byte) api.ChaincodeInvokeBuilder {
 	b.args = args
 	return b
@@ -108,6 +130,29 @@ func (b *invokeBuilder) Transient(args api.TransArgs) api.ChaincodeInvokeBuilder
 	return b
 }
 
+func (b *invokeBuilder) RetryOnConflict(maxAttempts int, backoff time.Duration) api.ChaincodeInvokeBuilder {
+	b.retryAttempts = maxAttempts
+	b.retryBackoff = backoff
+	return b
+}
+
+func (b *invokeBuilder) Unsigned(creator []byte) ([]byte, api.ChaincodeTx, error) {
+	if err := b.err.Err(); err != nil {
+		return nil, ``, err
+	}
+
+	cc, err := b.ccCore.dp.Chaincode(b.ccCore.channelName, b.ccCore.name)
+	if err != nil {
+		return nil, ``, errors.Wrap(err, `failed to get chaincode definition`)
+	}
+
+	proposal, tx, err := b.processor.CreateUnsignedProposal(cc, creator, b.fn, b.args, b.transientArgs)
+	if err != nil {
+		return nil, ``, errors.Wrap(err, `failed to get unsigned proposal`)
+	}
+	return proposal, tx, nil
+}
+
 func (b *invokeBuilder) getTransaction(proposal *fabricPeer.SignedProposal, peerResponses []*fabricPeer.ProposalResponse) (*common.Envelope, error) {
 
 	prop := new(fabricPeer.Proposal)
@@ -136,14 +181,125 @@ func (b *invokeBuilder) ArgString(args ...string) api.ChaincodeInvokeBuilder {
 }
 
 func (b *invokeBuilder) Do(ctx context.Context, options ...api.DoOption) (*fabricPeer.Response, api.ChaincodeTx, error) {
+	tx, peerResponses, err := b.invoke(ctx, new(api.InvokeTiming), options...)
+	if err != nil {
+		return nil, tx, err
+	}
+	return peerResponses[0].Response, tx, nil
+}
+
+func (b *invokeBuilder) DoResult(ctx context.Context, options ...api.DoOption) (*api.Result, error) {
+	var timing api.InvokeTiming
+	tx, peerResponses, err := b.invoke(ctx, &timing, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.Result{
+		TxID:              tx,
+		Payload:           peerResponses[0].Response.Payload,
+		ProposalResponses: peerResponses,
+		Event:             b.event,
+		Timing:            timing,
+		BroadcastInfo:     b.broadcastInfo,
+	}
+
+	if !txwaiter.Waits(b.txWaiter) {
+		return result, nil
+	}
+
+	// tx waiter returns error if transaction is not valid
+	code := fabricPeer.TxValidationCode_VALID
+	result.CommitCode = &code
+	// commit block number is taken from tx event, block itself is fetched only for write set
+	result.CommitBlock = b.commitBlock
+
+	if !b.returnWriteSet {
+		return result, nil
+	}
+
+	block, err := system.NewQSCC(b.peerPool, b.identity).GetBlockByTxID(ctx, b.ccCore.channelName, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get commit block`)
+	}
+
+	number := block.Header.Number
+	result.CommitBlock = &number
+
+	if result.WriteSet, err = committedWriteSet(block, tx); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// committedWriteSet returns public state writes of transaction from commit block grouped by namespace
+func committedWriteSet(block *common.Block, tx api.ChaincodeTx) ([]api.NamespaceWrites, error) {
+	env, err := util.GetTxEnvelopeFromBlock(block, string(tx))
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get committed transaction`)
+	}
+
+	writes, err := util.GetWritesFromEnvelope(env)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get committed writes`)
+	}
+
+	var writeSet []api.NamespaceWrites
+	for _, w := range writes {
+		if len(writeSet) == 0 || writeSet[len(writeSet)-1].Namespace != w.Namespace {
+			writeSet = append(writeSet, api.NamespaceWrites{Namespace: w.Namespace})
+		}
+		ns := &writeSet[len(writeSet)-1]
+		ns.Writes = append(ns.Writes, api.KeyWrite{Key: w.Key, Value: w.Value, IsDelete: w.IsDelete})
+	}
+	return writeSet, nil
+}
+
+// invoke traces invocation of chaincode, stages of invocation are traced as child spans
+func (b *invokeBuilder) invoke(ctx context.Context, timing *api.InvokeTiming, options ...api.DoOption) (api.ChaincodeTx, []*fabricPeer.ProposalResponse, error) {
+	ctx, span := b.ccCore.startSpan(ctx, `invoke`, b.fn)
+	b.commitBlock = nil
+	tx, peerResponses, err := b.retryInvoke(ctx, timing, options...)
+	if tx != `` {
+		span.SetAttributes(AttrTxID.String(string(tx)))
+	}
+	endSpan(span, err)
+	return tx, peerResponses, err
+}
+
+// retryInvoke makes invoke again with new transaction while it's invalidated by read conflict
+// and attempts set by RetryOnConflict are not exhausted
+func (b *invokeBuilder) retryInvoke(ctx context.Context, timing *api.InvokeTiming, options ...api.DoOption) (api.ChaincodeTx, []*fabricPeer.ProposalResponse, error) {
+	backoff := b.retryBackoff
+	for attempt := 1; ; attempt++ {
+		tx, peerResponses, err := b.doInvoke(ctx, timing, options...)
+		if err == nil || attempt >= b.retryAttempts || !api.IsMVCCConflict(err) {
+			return tx, peerResponses, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return tx, nil, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// doInvoke endorses, broadcasts and waits for commit of transaction, elapsed time of stages is written to timing
+func (b *invokeBuilder) doInvoke(ctx context.Context, timing *api.InvokeTiming, options ...api.DoOption) (api.ChaincodeTx, []*fabricPeer.ProposalResponse, error) {
+	started := time.Now()
+	defer func() { timing.Total = time.Since(started) }()
+
 	err := b.err.Err()
 	if err != nil {
-		return nil, ``, err
+		return ``, nil, err
 	}
 
 	cc, err := b.ccCore.dp.Chaincode(b.ccCore.channelName, b.ccCore.name)
 	if err != nil {
-		return nil, ``, errors.Wrap(err, `failed to get chaincode definition`)
+		return ``, nil, errors.Wrap(err, `failed to get chaincode definition`)
 	}
 
 	doOpts := &api.DoOptions{
@@ -151,53 +307,218 @@ func (b *invokeBuilder) Do(ctx context.Context, options ...api.DoOption) (*fabri
 		Identity:           b.identity,
 		Pool:               b.peerPool,
 	}
-	// set default options
-	if len(options) == 0 {
-		options = append(options, WithTxWaiter(txwaiter.Self))
-	}
-
 	for _, applyOpt := range options {
 		if err := applyOpt(doOpts); err != nil {
-			return nil, ``, err
+			return ``, nil, err
+		}
+	}
+
+	// set default tx waiter
+	if doOpts.TxWaiter == nil {
+		if err = WithTxWaiter(txwaiter.Self)(doOpts); err != nil {
+			return ``, nil, err
 		}
 	}
 	b.txWaiter = doOpts.TxWaiter
+	b.returnWriteSet = doOpts.ReturnWriteSet
 
+	// endorsements are checked against chaincode policy, as committing peers do. If endorsers are scoped
+	// by collections or required organizations, endorsements are checked against policy of scope
+	policy := cc.Policy
+
+	if len(doOpts.Collections) > 0 {
+		endorsers, err := util.GetCollectionsEndorsers(cc, doOpts.Collections)
+		if err != nil {
+			return ``, nil, errors.Wrap(err, `failed to get collections endorsers`)
+		}
+		scoped := *cc
+		scoped.Policy = util.NewMembersPolicy(endorsers)
+		cc = &scoped
+		policy = scoped.Policy
+	}
+
+	stageStarted := time.Now()
+	if len(doOpts.RequiredEndorsers) > 0 {
+		if err = checkEndorsersReady(doOpts.Pool, doOpts.RequiredEndorsers); err != nil {
+			return ``, nil, err
+		}
+		scoped := *cc
+		scoped.Policy = util.NewMembersPolicy(doOpts.RequiredEndorsers)
+		cc = &scoped
+		policy = scoped.Policy
+	} else if doOpts.PolicyEndorsers {
+		endorsers, err := b.plannedEndorsers(doOpts, cc.Policy)
+		if err != nil {
+			return ``, nil, err
+		}
+		scoped := *cc
+		scoped.Policy = util.NewMembersPolicy(endorsers)
+		cc = &scoped
+		// without chaincode policy endorsements are checked against members policy of selected organizations
+		if policy == `` {
+			policy = scoped.Policy
+		}
+	}
+
+	_, proposalSpan := b.ccCore.tracer.Start(ctx, `proposal`)
 	proposal, tx, err := b.processor.CreateProposal(cc, b.identity, b.fn, b.args, b.transientArgs)
+	timing.Proposal = time.Since(stageStarted)
+	endSpan(proposalSpan, err)
 	if err != nil {
-		return nil, ``, errors.Wrap(err, `failed to get signed proposal`)
+		return ``, nil, errors.Wrap(err, `failed to get signed proposal`)
+	}
+
+	if gw := b.ccCore.gateway; gw != nil {
+		return b.invokeGateway(ctx, gw, tx, proposal, doOpts, timing)
 	}
 
-	peerResponses, err := b.processor.Send(ctx, proposal, cc, b.peerPool)
+	// endorsements are always instrumented to find slowest endorser
+	pool := &instrumentedPool{PeerPool: &tracedPool{PeerPool: b.peerPool, tracer: b.ccCore.tracer}}
+
+	endorseCtx, cancelEndorse := stageContext(ctx, EndorseDeadlineFromContext, doOpts.Timeouts.Endorse, b.ccCore.timeouts.Endorse)
+	defer cancelEndorse()
+
+	stageStarted = time.Now()
+	peerResponses, err := b.processor.Send(endorseCtx, proposal, cc, pool)
+	timing.Endorsement = time.Since(stageStarted)
+	endorsements := pool.snapshot()
+	if doOpts.Endorsements != nil {
+		*doOpts.Endorsements = append(*doOpts.Endorsements, endorsements...)
+	}
+	for _, info := range endorsements {
+		if info.Latency > timing.SlowestEndorsement {
+			timing.SlowestEndorsement = info.Latency
+			timing.SlowestEndorserMspID = info.MspID
+		}
+	}
 	if err != nil {
-		return nil, tx, errors.Wrap(err, `failed to collect peer responses`)
+		// cancellation and deadline are returned as is, so caller can match them
+		if ctxErr := endorseCtx.Err(); ctxErr != nil {
+			return tx, nil, ctxErr
+		}
+		if decoded, ok := decodeEndorseError(b.ccCore.errDecoder, err); ok {
+			return tx, nil, decoded
+		}
+		return tx, nil, errors.Wrap(err, `failed to collect peer responses`)
+	}
+
+	if !doOpts.SkipPolicyCheck {
+		if err = util.CheckEndorsementPolicy(policy, peerResponses); err != nil {
+			return tx, nil, err
+		}
+	}
+
+	// event is validated before broadcast, so transaction with foreign event isn't committed
+	if b.event, err = endorsedEvent(peerResponses[0], tx, b.ccCore.name); err != nil {
+		return tx, nil, err
 	}
 
 	envelope, err := b.getTransaction(proposal, peerResponses)
 	if err != nil {
-		return nil, tx, errors.Wrap(err, `failed to get envelope`)
+		return tx, nil, errors.Wrap(err, `failed to get envelope`)
 	}
 
-	_, err = b.ccCore.orderer.Broadcast(ctx, envelope)
+	if doOpts.Sizes != nil {
+		doOpts.Sizes.Proposal = proto.Size(proposal)
+		doOpts.Sizes.LargestResponse = largestResponseSize(peerResponses)
+		doOpts.Sizes.Envelope = proto.Size(envelope)
+	}
+
+	commitCtx, cancelCommit := stageContext(ctx, CommitDeadlineFromContext, doOpts.Timeouts.Commit, b.ccCore.timeouts.Commit)
+	defer cancelCommit()
+
+	stageStarted = time.Now()
+	broadcastCtx, broadcastSpan := b.ccCore.tracer.Start(commitCtx, `broadcast`)
+	broadcastResp, err := b.ccCore.orderer.Broadcast(broadcastCtx, envelope)
+	timing.Broadcast = time.Since(stageStarted)
+	endSpan(broadcastSpan, err)
+	b.broadcastInfo = broadcastResp.GetInfo()
 	if err != nil {
-		return nil, tx, errors.Wrap(err, `failed to get orderer response`)
+		return tx, nil, errors.Wrap(err, `failed to get orderer response`)
 	}
 
-	if err = b.txWaiter.Wait(ctx, b.ccCore.channelName, tx); err != nil {
-		return nil, tx, err
+	stageStarted = time.Now()
+	waitCtx, waitSpan := b.ccCore.tracer.Start(commitCtx, `commit wait`)
+	if blockWaiter, ok := b.txWaiter.(api.TxBlockWaiter); ok {
+		var (
+			number uint64
+			found  bool
+		)
+		if number, found, err = blockWaiter.WaitBlock(waitCtx, b.ccCore.channelName, tx); found {
+			b.commitBlock = &number
+		}
+	} else {
+		err = b.txWaiter.Wait(waitCtx, b.ccCore.channelName, tx)
+	}
+	timing.Commit = time.Since(stageStarted)
+	endSpan(waitSpan, err)
+	if err != nil {
+		return tx, nil, err
 	}
 
-	return peerResponses[0].Response, tx, nil
+	if len(doOpts.PostCommitVerifyKeys) > 0 {
+		if err = b.verifyCommitted(ctx, tx, peerResponses[0], doOpts.PostCommitReadFn, doOpts.PostCommitVerifyKeys); err != nil {
+			return tx, nil, err
+		}
+	}
+
+	return tx, peerResponses, nil
+}
+
+// verifyCommitted reads values of keys from ledger by chaincode query function and compares them with endorsed writes
+func (b *invokeBuilder) verifyCommitted(ctx context.Context, tx api.ChaincodeTx, endorsed *fabricPeer.ProposalResponse, readFn string, keys []string) error {
+	endorsedWrites, err := util.GetWritesFromProposalResponse(endorsed)
+	if err != nil {
+		return errors.Wrap(err, `failed to get endorsed writes`)
+	}
+
+	mismatchErr := api.PostCommitMismatchError{TxId: tx}
+	for _, key := range keys {
+		committed, err := b.ccCore.Query(readFn, key).WithIdentity(b.identity).AsBytes(ctx)
+		if err != nil {
+			return errors.Wrapf(err, `failed to read committed value of key %s`, key)
+		}
+
+		endorsedWrite, ok := findWrite(endorsedWrites, b.ccCore.name, key)
+		if !ok || (endorsedWrite.IsDelete && len(committed) > 0) ||
+			(!endorsedWrite.IsDelete && !bytes.Equal(endorsedWrite.Value, committed)) {
+			mismatchErr.Keys = append(mismatchErr.Keys, key)
+		}
+	}
+
+	if len(mismatchErr.Keys) > 0 {
+		return mismatchErr
+	}
+	return nil
+}
+
+func findWrite(writes []util.Write, namespace, key string) (util.Write, bool) {
+	for _, w := range writes {
+		if w.Namespace == namespace && w.Key == key {
+			return w, true
+		}
+	}
+	return util.Write{}, false
+}
+
+func largestResponseSize(responses []*fabricPeer.ProposalResponse) int {
+	var largest int
+	for _, resp := range responses {
+		if size := proto.Size(resp); size > largest {
+			largest = size
+		}
+	}
+	return largest
 }
 
 func NewInvokeBuilder(ccCore *Core, fn string) api.ChaincodeInvokeBuilder {
-	processor := peer.NewProcessor(ccCore.channelName)
+	processor := peer.NewProcessor(ccCore.channelName, peer.WithTxIDGenerator(ccCore.txID))
 	return &invokeBuilder{
 		ccCore:    ccCore,
 		peerPool:  ccCore.peerPool,
 		fn:        fn,
 		processor: processor,
-		identity:  ccCore.identity,
+		identity:  api.ResolveIdentity(ccCore.identity),
 
 		err: newErrArgMap(),
 	}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/hyperledger/fabric/protoutil"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -24,7 +26,9 @@ import (
 	_ "github.com/s7techlab/hlf-sdk-go/discovery/local"
 	"github.com/s7techlab/hlf-sdk-go/identity"
 	"github.com/s7techlab/hlf-sdk-go/logger"
+	sdkpeer "github.com/s7techlab/hlf-sdk-go/peer"
 	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 )
 
@@ -43,6 +47,9 @@ func (m *mockOrderer) Broadcast(ctx context.Context, envelope *common.Envelope)
 func (m *mockOrderer) Deliver(ctx context.Context, envelope *common.Envelope) (*common.Block, error) {
 	return nil, nil
 }
+func (m *mockOrderer) Close() error {
+	return nil
+}
 
 // simple mock deliver
 func newMockDeliverClient(channelConfig map[string]deliverChannelRouter) *mockDeliverClient {
@@ -90,17 +97,37 @@ func (t *mockTxSubscription) Inc() {
 func (t *mockTxSubscription) Result() (peer.TxValidationCode, error) {
 	t.Inc()
 	if t.txCode != peer.TxValidationCode_VALID {
-		err := fmt.Errorf("TxId validation code failed: %s", peer.TxValidationCode_name[int32(t.txCode)])
+		err := mockTxError{api.InvalidTxError{TxId: t.tx, Code: t.txCode}}
 		println(err.Error())
 		return t.txCode, err
 	}
 	return t.txCode, nil
 }
 
+// mockTxError is api.InvalidTxError with message not depending on tx id
+type mockTxError struct {
+	api.InvalidTxError
+}
+
+func (e mockTxError) Error() string {
+	return fmt.Sprintf("TxId validation code failed: %s", peer.TxValidationCode_name[int32(e.Code)])
+}
+
+func (e mockTxError) Unwrap() error {
+	return e.InvalidTxError
+}
+
 func (t *mockTxSubscription) Close() error {
 	return nil
 }
 
+// mockCommitBlock is number of block which mock tx subscription reports for transaction
+const mockCommitBlock = 7
+
+func (t *mockTxSubscription) BlockNumber() (uint64, bool) {
+	return mockCommitBlock, true
+}
+
 func (m *mockDeliverClient) SubscribeBlock(ctx context.Context, channelName string, seekOpt ...api.EventCCSeekOption) (api.BlockSubscription, error) {
 	return nil, nil
 }
@@ -110,6 +137,9 @@ type mockPeer struct {
 	deliver      *mockDeliverClient
 	endorser     msp.SigningIdentity
 	checkEndorse map[string]int
+	// creators are creators of endorsed proposals by tx id
+	creators map[string][]byte
+	mx       sync.Mutex
 }
 
 // Endorse mock echo answer from peer
@@ -128,7 +158,18 @@ func (p *mockPeer) Endorse(ctx context.Context, proposal *peer.SignedProposal, o
 		return nil, errors.Wrap(err, `failed to unmarshal`)
 	}
 
+	sigHeader, err := protoutil.UnmarshalSignatureHeader(header.SignatureHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal SignatureHeader`)
+	}
+
+	p.mx.Lock()
 	p.checkEndorse[chheader.ChannelId+`/`+chheader.TxId]++
+	if p.creators == nil {
+		p.creators = make(map[string][]byte)
+	}
+	p.creators[chheader.TxId] = sigHeader.Creator
+	p.mx.Unlock()
 
 	peerResp := &peer.Response{
 		Status:  200,
@@ -425,4 +466,204 @@ func TestInvokeBuilder_Do(t *testing.T) {
 			}
 		})
 	}
+
+	var endorseCount = func(channelName string) int {
+		var count int
+		for key, v := range peerOrg1.checkEndorse {
+			if strings.HasPrefix(key, channelName+`/`) {
+				count += v
+			}
+		}
+		return count
+	}
+
+	for _, tc := range []struct {
+		name            string
+		channel         string
+		waiter          api.DoOption
+		expEndorsements int
+	}{
+		{`retry on mvcc conflict`, `fail-mvcc-network`, chaincode.WithTxWaiter(txwaiter.Self), 3},
+		{`retry on mvcc conflict with all peers`, `fail-mvcc-network`, chaincode.WithTxWaiter(txwaiter.All), 3},
+		{`no retry on other validation codes`, `fail-invalid-org3-network`, chaincode.WithTxWaiter(txwaiter.All), 1},
+	} {
+		t.Run(tc.name, func(tt *testing.T) {
+			before := endorseCount(tc.channel)
+			_, _, err := core.Channel(tc.channel).Chaincode(`my-chaincode`).Invoke(`call`).
+				RetryOnConflict(3, time.Millisecond).Do(context.Background(), tc.waiter)
+			require.Error(tt, err)
+			require.Equal(tt, tc.expEndorsements, endorseCount(tc.channel)-before)
+		})
+	}
+
+	t.Run(`query batch`, func(tt *testing.T) {
+		requests := make([]api.QueryRequest, 5)
+		for i := range requests {
+			requests[i] = api.QueryRequest{Fn: `get`, Args: []string{fmt.Sprint(i)}}
+		}
+
+		before := endorseCount(`success-network`)
+		responses := core.Channel(`success-network`).Chaincode(`my-chaincode`).QueryBatch(context.Background(), requests, 2)
+		require.Len(tt, responses, len(requests))
+		for _, resp := range responses {
+			require.NoError(tt, resp.Err)
+		}
+		require.Equal(tt, len(requests), endorseCount(`success-network`)-before)
+	})
+
+	t.Run(`post commit verify`, func(tt *testing.T) {
+		// mock peers endorse no writes, so value read after commit doesn't match
+		_, _, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).Invoke(`call`).
+			Do(context.Background(), chaincode.WithPostCommitVerify(`get`, `key`))
+		var mismatchErr api.PostCommitMismatchError
+		require.True(tt, errors.As(err, &mismatchErr))
+		require.Equal(tt, []string{`key`}, mismatchErr.Keys)
+	})
+
+	t.Run(`identity override`, func(tt *testing.T) {
+		_, tx, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).Invoke(`call`).
+			As(org2mspID).Do(context.Background())
+		require.NoError(tt, err)
+
+		creator, err := org2mspID.GetSigningIdentity(cryptoSuite).Serialize()
+		require.NoError(tt, err)
+		peerOrg1.mx.Lock()
+		defer peerOrg1.mx.Unlock()
+		require.Equal(tt, creator, peerOrg1.creators[string(tx)])
+	})
+
+	t.Run(`nil identity override`, func(tt *testing.T) {
+		_, _, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).Invoke(`call`).
+			As(nil).Do(context.Background())
+		require.Error(tt, err)
+		require.Contains(tt, err.Error(), api.ErrNoIdentity.Error())
+	})
+
+	t.Run(`offline signing`, func(tt *testing.T) {
+		// identity is used as offline signer, so only its serialized form is passed to sdk
+		signer := core.CurrentIdentity()
+		creator, err := signer.Serialize()
+		require.NoError(tt, err)
+
+		proposal, tx, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).Invoke(`call`).
+			ArgString(`arg`).Unsigned(creator)
+		require.NoError(tt, err)
+
+		signature, err := signer.Sign(proposal)
+		require.NoError(tt, err)
+
+		envelope, err := core.SubmitSigned(context.Background(), proposal, signature)
+		require.NoError(tt, err)
+		require.Empty(tt, envelope.Signature)
+		require.NoError(tt, checkEndorsingCount(`success-network`, string(tx), `org1msp`))
+
+		envelope.Signature, err = signer.Sign(envelope.Payload)
+		require.NoError(tt, err)
+		_, err = core.SubmitEnvelope(context.Background(), `success-network`, envelope)
+		require.NoError(tt, err)
+	})
+
+	t.Run(`unsigned envelope of other identity`, func(tt *testing.T) {
+		creator, err := org2mspID.GetSigningIdentity(cryptoSuite).Serialize()
+		require.NoError(tt, err)
+
+		proposal, _, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).Invoke(`call`).
+			ArgString(`arg`).Unsigned(creator)
+		require.NoError(tt, err)
+
+		signature, err := org2mspID.GetSigningIdentity(cryptoSuite).Sign(proposal)
+		require.NoError(tt, err)
+
+		envelope, err := core.SubmitSigned(context.Background(), proposal, signature)
+		require.NoError(tt, err)
+
+		_, err = core.SubmitEnvelope(context.Background(), `success-network`, envelope)
+		require.Error(tt, err)
+	})
+
+	t.Run(`invoke with event without commit wait`, func(tt *testing.T) {
+		_, _, err := invoker.InvokeWithEvent(context.Background(), org1mspID.GetSigningIdentity(cryptoSuite), `success-network`, `my-chaincode`,
+			`call`, nil, nil, chaincode.WithTxWaiter(txwaiter.None))
+		require.ErrorIs(tt, err, api.ErrCommitNotAwaited)
+	})
+}
+
+func TestCreateProposalTransient(t *testing.T) {
+	mspID, err := identity.NewMSPIdentityFromPath(`org1msp`, `./testdata/msp`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cryptoSuite, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposalPayload := func(transient api.TransArgs) *peer.ChaincodeProposalPayload {
+		signedProp, _, err := sdkpeer.NewProcessor(`channel`).CreateProposal(
+			&api.DiscoveryChaincode{Name: `my-chaincode`, Type: api.CCTypeGoLang},
+			mspID.GetSigningIdentity(cryptoSuite), `call`, [][]byte{[]byte(`arg`)}, transient)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		prop, err := protoutil.UnmarshalProposal(signedProp.ProposalBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payload, err := protoutil.UnmarshalChaincodeProposalPayload(prop.Payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return payload
+	}
+
+	if payload := proposalPayload(api.TransArgs{`secret`: []byte(`value`)}); string(payload.TransientMap[`secret`]) != `value` {
+		t.Errorf("Unexpected transient map: %v", payload.TransientMap)
+	}
+
+	// empty transient map produces same proposal payload as nil one
+	if !proto.Equal(proposalPayload(nil), proposalPayload(api.TransArgs{})) {
+		t.Error("Empty transient map changes proposal payload")
+	}
+}
+
+func TestInvokeBuilder_DoResult(t *testing.T) {
+	cryptoSuite, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{})
+	peers := make(map[string]*mockPeer)
+	for _, mspID := range []string{`org1msp`, `org2msp`, `org3msp`} {
+		id, err := identity.NewMSPIdentityFromPath(mspID, `./testdata/msp`)
+		require.NoError(t, err)
+		peers[mspID] = &mockPeer{
+			deliver: newMockDeliverClient(map[string]deliverChannelRouter{
+				`success-network`: {txCode: peer.TxValidationCode_VALID},
+			}),
+			endorser:     id.GetSigningIdentity(cryptoSuite),
+			checkEndorse: make(map[string]int),
+		}
+		require.NoError(t, peerPool.Add(mspID, peers[mspID], defaultAlivePeer))
+	}
+
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `./testdata/msp`)
+	require.NoError(t, err)
+	core, err := client.NewCore(`org1msp`, id,
+		client.WithOrderer(&mockOrderer{}),
+		client.WithPeerPool(peerPool),
+		client.WithConfigYaml(`./testdata/config.yaml`),
+	)
+	require.NoError(t, err)
+
+	result, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).Invoke(`call`).
+		DoResult(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, result.CommitBlock)
+	require.Equal(t, uint64(mockCommitBlock), *result.CommitBlock)
+
+	// commit block number is taken from tx event, so block isn't fetched from peer
+	require.Len(t, peers[`org1msp`].checkEndorse, 1)
+	require.Equal(t, 1, peers[`org1msp`].checkEndorse[`success-network/`+string(result.TxID)])
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/golang/protobuf/proto"
 	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/pkg/errors"
@@ -19,6 +20,12 @@ type QueryBuilder struct {
 	processor     api.PeerProcessor
 	peerPool      api.PeerPool
 	transientArgs api.TransArgs
+	sizes         *api.TxSizes
+	freshest      bool
+	// target is peer which query is sent to before falling back to pool
+	target api.Peer
+	// err is error of builder options, it is returned by query
+	err error
 }
 
 func (q *QueryBuilder) WithIdentity(identity msp.SigningIdentity) api.ChaincodeQueryBuilder {
@@ -26,6 +33,15 @@ func (q *QueryBuilder) WithIdentity(identity msp.SigningIdentity) api.ChaincodeQ
 	return q
 }
 
+func (q *QueryBuilder) As(identity api.Identity) api.ChaincodeQueryBuilder {
+	signing, err := signingIdentity(identity, q.ccCore.cs)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	return q.WithIdentity(signing)
+}
+
 // TODO: think about interface in one style with Invoke
 func (q *QueryBuilder) AsBytes(ctx context.Context) ([]byte, error) {
 	if response, err := q.AsProposalResponse(ctx); err != nil {
@@ -47,14 +63,102 @@ func (q *QueryBuilder) AsJSON(ctx context.Context, out interface{}) error {
 }
 
 func (q *QueryBuilder) AsProposalResponse(ctx context.Context) (*fabricPeer.ProposalResponse, error) {
+	_, resp, err := q.query(ctx)
+	return resp, err
+}
+
+func (q *QueryBuilder) AsResult(ctx context.Context) (*api.Result, error) {
+	tx, resp, err := q.query(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get proposal response`)
+	}
+
+	event, err := endorsedEvent(resp, tx, q.ccCore.name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.Result{
+		TxID:              tx,
+		Payload:           resp.Response.Payload,
+		ProposalResponses: []*fabricPeer.ProposalResponse{resp},
+		Event:             event,
+	}, nil
+}
+
+// query traces chaincode query, proposal and endorsements are traced as child spans
+func (q *QueryBuilder) query(ctx context.Context) (api.ChaincodeTx, *fabricPeer.ProposalResponse, error) {
+	ctx, span := q.ccCore.startSpan(ctx, `query`, q.fn)
+	tx, resp, err := q.doQuery(ctx)
+	if tx != `` {
+		span.SetAttributes(AttrTxID.String(string(tx)))
+	}
+	endSpan(span, err)
+	return tx, resp, err
+}
+
+func (q *QueryBuilder) doQuery(ctx context.Context) (api.ChaincodeTx, *fabricPeer.ProposalResponse, error) {
+	if q.err != nil {
+		return ``, nil, q.err
+	}
+
 	ccDef, err := q.ccCore.dp.Chaincode(q.ccCore.channelName, q.ccCore.name)
 	if err != nil {
-		return nil, errors.Wrap(err, `failed to get chaincode definition from discovery provider`)
+		return ``, nil, errors.Wrap(err, `failed to get chaincode definition from discovery provider`)
 	}
 
-	proposal, _, err := q.processor.CreateProposal(ccDef, q.identity, q.fn, argsToBytes(q.args...), q.transientArgs)
+	_, proposalSpan := q.ccCore.tracer.Start(ctx, `proposal`)
+	proposal, tx, err := q.processor.CreateProposal(ccDef, q.identity, q.fn, argsToBytes(q.args...), q.transientArgs)
+	endSpan(proposalSpan, err)
 	if err != nil {
-		return nil, errors.Wrap(err, `failed to create peer proposal`)
+		return ``, nil, errors.Wrap(err, `failed to create peer proposal`)
+	}
+
+	var resp *fabricPeer.ProposalResponse
+	if gw := q.ccCore.gateway; gw != nil {
+		resp, err = q.evaluate(ctx, gw, tx, proposal)
+	} else {
+		resp, err = q.process(ctx, proposal)
+	}
+	if q.sizes != nil {
+		q.sizes.Proposal = proto.Size(proposal)
+		q.sizes.LargestResponse = proto.Size(resp)
+	}
+
+	if decoded, ok := decodeEndorseError(q.ccCore.errDecoder, err); ok {
+		return tx, nil, decoded
+	}
+
+	return tx, resp, err
+}
+
+func (q *QueryBuilder) process(ctx context.Context, proposal *fabricPeer.SignedProposal) (*fabricPeer.ProposalResponse, error) {
+	if q.target != nil {
+		resp, err := q.target.Endorse(ctx, proposal)
+		// fall back only if peer is unavailable, not if chaincode returned error
+		if err == nil || ctx.Err() != nil {
+			return resp, err
+		}
+		if _, ok := errors.Cause(err).(api.PeerEndorseError); ok {
+			return resp, err
+		}
+	}
+
+	if q.freshest {
+		if resp, ok, err := q.processFreshest(ctx, proposal); ok {
+			return resp, err
+		}
+	}
+
+	if affinity := q.ccCore.affinity; affinity != nil {
+		resp, err := q.peerPool.Process(ctx, affinity.MspID, proposal)
+		// fall back only if peers are unavailable, not if chaincode returned error
+		if err == nil || affinity.Strict || ctx.Err() != nil {
+			return resp, err
+		}
+		if _, ok := errors.Cause(err).(api.PeerEndorseError); ok {
+			return resp, err
+		}
 	}
 
 	return q.peerPool.Process(ctx, q.identity.GetMSPIdentifier(), proposal)
@@ -65,7 +169,12 @@ func (q *QueryBuilder) Transient(args api.TransArgs) api.ChaincodeQueryBuilder {
 	return q
 }
 
+func (q *QueryBuilder) WithSizes(sizes *api.TxSizes) api.ChaincodeQueryBuilder {
+	q.sizes = sizes
+	return q
+}
+
 func NewQueryBuilder(ccCore *Core, identity msp.SigningIdentity, fn string, args ...string) api.ChaincodeQueryBuilder {
-	peerProcessor := peer.NewProcessor(ccCore.channelName)
-	return &QueryBuilder{ccCore: ccCore, fn: fn, args: args, identity: identity, processor: peerProcessor, peerPool: ccCore.peerPool}
+	peerProcessor := peer.NewProcessor(ccCore.channelName, peer.WithTxIDGenerator(ccCore.txID))
+	return &QueryBuilder{ccCore: ccCore, fn: fn, args: args, identity: identity, processor: peerProcessor, peerPool: &tracedPool{PeerPool: ccCore.peerPool, tracer: ccCore.tracer}}
 }
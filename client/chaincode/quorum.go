@@ -0,0 +1,107 @@
+package chaincode
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+type quorumPeer struct {
+	mspID string
+	peer  api.Peer
+}
+
+func (q *QueryBuilder) AsQuorum(ctx context.Context, quorum int) ([]byte, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if quorum < 1 {
+		return nil, errors.New(`quorum must be positive`)
+	}
+
+	peers := selectQuorumPeers(q.peerPool.Peers(), q.peerPool.Status())
+	if len(peers) < quorum {
+		return nil, errors.Errorf(`quorum %d is greater than number of ready pool peers %d`, quorum, len(peers))
+	}
+
+	ccDef, err := q.ccCore.dp.Chaincode(q.ccCore.channelName, q.ccCore.name)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get chaincode definition from discovery provider`)
+	}
+
+	proposal, _, err := q.processor.CreateProposal(ccDef, q.identity, q.fn, argsToBytes(q.args...), q.transientArgs)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to create peer proposal`)
+	}
+
+	// peers which haven't responded yet are cancelled when quorum is reached
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make([]api.QuorumResponse, len(peers))
+	responded := make(chan int, len(peers))
+	for i, p := range peers {
+		go func(i int, p quorumPeer) {
+			response := api.QuorumResponse{MspID: p.mspID, Address: p.peer.Uri()}
+			if resp, err := p.peer.Endorse(ctx, proposal); err != nil {
+				response.Err = err
+			} else {
+				response.Payload = resp.GetResponse().GetPayload()
+			}
+			responses[i] = response
+			responded <- i
+		}(i, p)
+	}
+
+	identical := make(map[string]int)
+	for range peers {
+		resp := responses[<-responded]
+		if resp.Err != nil {
+			continue
+		}
+		if identical[string(resp.Payload)]++; identical[string(resp.Payload)] >= quorum {
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, api.QuorumError{Quorum: quorum, Responses: responses}
+}
+
+// selectQuorumPeers returns ready pool peers taking one peer of each organization in turn,
+// so responses of distinct organizations come first where possible
+func selectQuorumPeers(pool map[string][]api.Peer, status map[string][]api.PeerStatus) []quorumPeer {
+	ready := make(map[string][]api.Peer, len(pool))
+	mspIDs := make([]string, 0, len(pool))
+	for mspID, peers := range pool {
+		readyAddresses := make(map[string]bool)
+		for _, s := range status[mspID] {
+			readyAddresses[s.Address] = s.Ready
+		}
+		for _, p := range peers {
+			if readyAddresses[p.Uri()] {
+				ready[mspID] = append(ready[mspID], p)
+			}
+		}
+		if len(ready[mspID]) > 0 {
+			mspIDs = append(mspIDs, mspID)
+		}
+	}
+	sort.Strings(mspIDs)
+
+	var selected []quorumPeer
+	for round := 0; ; round++ {
+		added := false
+		for _, mspID := range mspIDs {
+			if round < len(ready[mspID]) {
+				selected = append(selected, quorumPeer{mspID: mspID, peer: ready[mspID][round]})
+				added = true
+			}
+		}
+		if !added {
+			return selected
+		}
+	}
+}
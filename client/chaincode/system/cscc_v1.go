@@ -86,6 +86,31 @@ func (c *csccV1) endorse(ctx context.Context, fn string, args ...string) ([]byte
 	return resp.Response.Payload, nil
 }
 
+// PeerChannels returns ids of channels joined by presented peer
+func PeerChannels(ctx context.Context, p api.Peer, identity msp.SigningIdentity) ([]string, error) {
+	prop, _, err := peerSDK.NewProcessor(``).CreateProposal(
+		&api.DiscoveryChaincode{Name: csccName, Type: api.CCTypeGoLang}, identity, GetChannels, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to create proposal`)
+	}
+
+	resp, err := p.Endorse(ctx, prop)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to endorse proposal`)
+	}
+
+	channelResp := new(peer.ChannelQueryResponse)
+	if err = proto.Unmarshal(resp.Response.Payload, channelResp); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal protobuf`)
+	}
+
+	channels := make([]string, len(channelResp.Channels))
+	for i, ch := range channelResp.Channels {
+		channels[i] = ch.ChannelId
+	}
+	return channels, nil
+}
+
 func NewCSCCV1(peerPool api.PeerPool, identity msp.SigningIdentity) api.CSCC {
 	return &csccV1{peerPool: peerPool, identity: identity, processor: peerSDK.NewProcessor(``)}
 }
@@ -2,25 +2,37 @@ package system
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
-	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
+
 	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode/txwaiter"
 	peerSDK "github.com/s7techlab/hlf-sdk-go/peer"
+	"github.com/s7techlab/hlf-sdk-go/util"
 )
 
+// getInstalledChaincodePackageFuncName is _lifecycle function added in Fabric 2.2, it isn't declared by fabric version used
+const getInstalledChaincodePackageFuncName = `GetInstalledChaincodePackage`
+
 type lifecycleCC struct {
-	peerPool  api.PeerPool
-	identity  msp.SigningIdentity
-	processor api.PeerProcessor
+	peerPool api.PeerPool
+	orderer  api.Orderer
+	identity msp.SigningIdentity
 }
 
 func (c *lifecycleCC) QueryInstalledChaincodes(ctx context.Context) (*lb.QueryInstalledChaincodesResult, error) {
-	resp, err := c.endorse(ctx, lifecycle.QueryInstalledChaincodesFuncName, ``)
+	resp, err := c.endorse(ctx, ``, lifecycle.QueryInstalledChaincodesFuncName, &lb.QueryInstalledChaincodesArgs{})
 	if err != nil {
 		return nil, err
 	}
@@ -31,10 +43,386 @@ func (c *lifecycleCC) QueryInstalledChaincodes(ctx context.Context) (*lb.QueryIn
 	return ccData, nil
 }
 
-func (c *lifecycleCC) endorse(ctx context.Context, fn string, args ...string) ([]byte, error) {
-	prop, _, err := c.processor.CreateProposal(&api.DiscoveryChaincode{Name: lifecycleName, Type: api.CCTypeGoLang}, c.identity, fn, util.ToChaincodeArgs(args...), nil)
+func (c *lifecycleCC) QueryInstalledChaincodesOn(ctx context.Context, p api.Peer) (*lb.QueryInstalledChaincodesResult, error) {
+	installed := new(lb.QueryInstalledChaincodesResult)
+	if err := c.endorseOn(ctx, p, ``, lifecycle.QueryInstalledChaincodesFuncName,
+		&lb.QueryInstalledChaincodesArgs{}, installed); err != nil {
+		return nil, err
+	}
+	return installed, nil
+}
+
+func (c *lifecycleCC) GetInstalledChaincodePackage(ctx context.Context, p api.Peer, packageID string) ([]byte, error) {
+	result := new(lb.GetInstalledChaincodePackageResult)
+	if err := c.endorseOn(ctx, p, ``, getInstalledChaincodePackageFuncName,
+		&lb.GetInstalledChaincodePackageArgs{PackageId: packageID}, result); err != nil {
+		return nil, err
+	}
+	return result.ChaincodeInstallPackage, nil
+}
+
+func (c *lifecycleCC) InstallChaincode(ctx context.Context, pkg []byte) (*lb.InstallChaincodeResult, error) {
+	resp, err := c.endorse(ctx, ``, lifecycle.InstallChaincodeFuncName, &lb.InstallChaincodeArgs{ChaincodeInstallPackage: pkg})
 	if err != nil {
-		return nil, errors.Wrap(err, `failed to create proposal`)
+		return nil, err
+	}
+	result := new(lb.InstallChaincodeResult)
+	if err = proto.Unmarshal(resp, result); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal protobuf`)
+	}
+	return result, nil
+}
+
+func (c *lifecycleCC) ApproveForMyOrg(ctx context.Context, channelName string, args *lb.ApproveChaincodeDefinitionForMyOrgArgs) error {
+	if err := util.ValidateCollectionsConfig(args.Collections); err != nil {
+		return errors.Wrap(err, `invalid collections config`)
+	}
+	return c.submit(ctx, channelName, lifecycle.ApproveChaincodeDefinitionForMyOrgFuncName, args, c.identity.GetMSPIdentifier())
+}
+
+func (c *lifecycleCC) CheckCommitReadiness(ctx context.Context, channelName string, args *lb.CheckCommitReadinessArgs) (*lb.CheckCommitReadinessResult, error) {
+	resp, err := c.endorse(ctx, channelName, lifecycle.CheckCommitReadinessFuncName, args)
+	if err != nil {
+		return nil, err
+	}
+	result := new(lb.CheckCommitReadinessResult)
+	if err = proto.Unmarshal(resp, result); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal protobuf`)
+	}
+	return result, nil
+}
+
+func (c *lifecycleCC) CommitReadiness(ctx context.Context, channelName string, def *lb.ApproveChaincodeDefinitionForMyOrgArgs) (map[string]bool, error) {
+	readiness, err := c.CheckCommitReadiness(ctx, channelName, NewCheckCommitReadinessArgs(def))
+	if err != nil {
+		return nil, err
+	}
+	approvals := readiness.GetApprovals()
+	if approvals == nil {
+		approvals = make(map[string]bool)
+	}
+	return approvals, nil
+}
+
+// NewCheckCommitReadinessArgs returns args of commit readiness check of approved chaincode definition
+func NewCheckCommitReadinessArgs(def *lb.ApproveChaincodeDefinitionForMyOrgArgs) *lb.CheckCommitReadinessArgs {
+	return &lb.CheckCommitReadinessArgs{
+		Sequence:            def.Sequence,
+		Name:                def.Name,
+		Version:             def.Version,
+		EndorsementPlugin:   def.EndorsementPlugin,
+		ValidationPlugin:    def.ValidationPlugin,
+		ValidationParameter: def.ValidationParameter,
+		Collections:         def.Collections,
+		InitRequired:        def.InitRequired,
+	}
+}
+
+// NewCommitArgs returns args of commit of approved chaincode definition
+func NewCommitArgs(def *lb.ApproveChaincodeDefinitionForMyOrgArgs) *lb.CommitChaincodeDefinitionArgs {
+	return &lb.CommitChaincodeDefinitionArgs{
+		Sequence:            def.Sequence,
+		Name:                def.Name,
+		Version:             def.Version,
+		EndorsementPlugin:   def.EndorsementPlugin,
+		ValidationPlugin:    def.ValidationPlugin,
+		ValidationParameter: def.ValidationParameter,
+		Collections:         def.Collections,
+		InitRequired:        def.InitRequired,
+	}
+}
+
+func (c *lifecycleCC) QueryChaincodeDefinition(ctx context.Context, channelName, ccName string) (*lb.QueryChaincodeDefinitionResult, error) {
+	resp, err := c.endorse(ctx, channelName, lifecycle.QueryChaincodeDefinitionFuncName,
+		&lb.QueryChaincodeDefinitionArgs{Name: ccName})
+	if err != nil {
+		return nil, err
+	}
+	result := new(lb.QueryChaincodeDefinitionResult)
+	if err = proto.Unmarshal(resp, result); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal protobuf`)
+	}
+	return result, nil
+}
+
+func (c *lifecycleCC) QueryChaincodeDefinitions(ctx context.Context, channelName string) (*lb.QueryChaincodeDefinitionsResult, error) {
+	resp, err := c.endorse(ctx, channelName, lifecycle.QueryChaincodeDefinitionsFuncName, &lb.QueryChaincodeDefinitionsArgs{})
+	if err != nil {
+		return nil, err
+	}
+	result := new(lb.QueryChaincodeDefinitionsResult)
+	if err = proto.Unmarshal(resp, result); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal protobuf`)
+	}
+	return result, nil
+}
+
+func (c *lifecycleCC) Commit(ctx context.Context, channelName string, args *lb.CommitChaincodeDefinitionArgs, endorserMSPs ...string) error {
+	if len(endorserMSPs) == 0 {
+		endorserMSPs = []string{c.identity.GetMSPIdentifier()}
+	}
+	if err := util.ValidateCollectionsConfig(args.Collections); err != nil {
+		return errors.Wrap(err, `invalid collections config`)
+	}
+	return c.submit(ctx, channelName, lifecycle.CommitChaincodeDefinitionFuncName, args, endorserMSPs...)
+}
+
+func (c *lifecycleCC) Upgrade(ctx context.Context, req *api.LifecycleUpgradeRequest) (*api.LifecycleUpgradeResult, error) {
+	if req.Definition == nil {
+		return nil, errors.New(`chaincode definition is not presented`)
+	}
+
+	result := &api.LifecycleUpgradeResult{}
+	step := func(s api.LifecycleStep, err error) error {
+		if req.OnStep != nil {
+			req.OnStep(s, err)
+		}
+		if err != nil {
+			return errors.Wrapf(err, `failed to %s`, s)
+		}
+		result.Completed = append(result.Completed, s)
+		return nil
+	}
+
+	var err error
+	if result.PackageID, err = packageID(req.Package); err != nil {
+		return nil, err
+	}
+
+	installed, err := c.QueryInstalledChaincodes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to query installed chaincodes`)
+	}
+	if !isInstalled(installed, result.PackageID) {
+		if _, err = c.InstallChaincode(ctx, req.Package); err != nil {
+			return result, step(api.LifecycleStepInstall, err)
+		}
+		_ = step(api.LifecycleStepInstall, nil)
+	}
+
+	def := proto.Clone(req.Definition).(*lb.ApproveChaincodeDefinitionForMyOrgArgs)
+	def.Source = &lb.ChaincodeSource{
+		Type: &lb.ChaincodeSource_LocalPackage{
+			LocalPackage: &lb.ChaincodeSource_Local{PackageId: result.PackageID},
+		},
+	}
+
+	readiness, err := c.CheckCommitReadiness(ctx, req.ChannelName, NewCheckCommitReadinessArgs(def))
+	if err != nil {
+		return result, errors.Wrap(err, `failed to check commit readiness`)
+	}
+
+	if readiness.Approvals == nil {
+		readiness.Approvals = make(map[string]bool)
+	}
+
+	mspID := c.identity.GetMSPIdentifier()
+	if !readiness.Approvals[mspID] {
+		if err = step(api.LifecycleStepApprove, c.ApproveForMyOrg(ctx, req.ChannelName, def)); err != nil {
+			return result, err
+		}
+		readiness.Approvals[mspID] = true
+	}
+
+	for org, approved := range readiness.Approvals {
+		if !approved {
+			result.PendingApprovals = append(result.PendingApprovals, org)
+		}
+	}
+	sort.Strings(result.PendingApprovals)
+	_ = step(api.LifecycleStepCheckCommitReadiness, nil)
+
+	// other organizations have to approve definition themselves, commit is possible only after that
+	if len(result.PendingApprovals) > 0 {
+		return result, nil
+	}
+
+	if err = step(api.LifecycleStepCommit, c.Commit(ctx, req.ChannelName, NewCommitArgs(def), req.Orgs...)); err != nil {
+		return result, err
+	}
+	result.Committed = true
+
+	return result, nil
+}
+
+const defaultApproveConcurrency = 4
+
+func (c *lifecycleCC) ApproveForMyOrgOnChannels(ctx context.Context, req *api.LifecycleBatchApproveRequest) (*api.LifecycleBatchApproveResult, error) {
+	if req.Definition == nil {
+		return nil, errors.New(`chaincode definition is not presented`)
+	}
+
+	def := proto.Clone(req.Definition).(*lb.ApproveChaincodeDefinitionForMyOrgArgs)
+	result := &api.LifecycleBatchApproveResult{
+		PackageID: def.GetSource().GetLocalPackage().GetPackageId(),
+		Channels:  make([]api.LifecycleChannelApproval, len(req.Channels)),
+	}
+
+	// package id is resolved once for all channels
+	if len(req.Package) > 0 {
+		var err error
+		if result.PackageID, err = packageID(req.Package); err != nil {
+			return nil, err
+		}
+		def.Source = &lb.ChaincodeSource{
+			Type: &lb.ChaincodeSource_LocalPackage{
+				LocalPackage: &lb.ChaincodeSource_Local{PackageId: result.PackageID},
+			},
+		}
+	}
+
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultApproveConcurrency
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for i, channelName := range req.Channels {
+		result.Channels[i].ChannelName = channelName
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// each channel gets own copy, as marshaling of shared message concurrently isn't safe
+			channelDef := proto.Clone(def).(*lb.ApproveChaincodeDefinitionForMyOrgArgs)
+			if err := c.ApproveForMyOrg(ctx, result.Channels[i].ChannelName, channelDef); err != nil {
+				result.Channels[i].Err = errors.Wrapf(err, `failed to approve on channel %s`, result.Channels[i].ChannelName)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+func (c *lifecycleCC) DefinitionDrift(ctx context.Context, channelName, ccName string, sequence int64) (*api.LifecycleDefinitionDrift, error) {
+	prop, _, err := c.proposal(channelName, lifecycle.QueryApprovedChaincodeDefinitionFuncName,
+		&lb.QueryApprovedChaincodeDefinitionArgs{Name: ccName, Sequence: sequence})
+	if err != nil {
+		return nil, err
+	}
+
+	drift := &api.LifecycleDefinitionDrift{
+		Approved: make(map[string]*lb.QueryApprovedChaincodeDefinitionResult),
+		Errors:   make(map[string]error),
+	}
+
+	for mspID := range c.peerPool.Peers() {
+		resp, err := c.peerPool.Process(ctx, mspID, prop)
+		if err != nil {
+			drift.Errors[mspID] = errors.Wrap(err, `failed to endorse proposal`)
+			continue
+		}
+
+		approved := new(lb.QueryApprovedChaincodeDefinitionResult)
+		if err = proto.Unmarshal(resp.Response.Payload, approved); err != nil {
+			drift.Errors[mspID] = errors.Wrap(err, `failed to unmarshal protobuf`)
+			continue
+		}
+		drift.Approved[mspID] = approved
+	}
+
+	fieldValues := make(map[string]map[string]string)
+	for mspID, approved := range drift.Approved {
+		for field, value := range definitionFields(approved) {
+			if fieldValues[field] == nil {
+				fieldValues[field] = make(map[string]string)
+			}
+			fieldValues[field][mspID] = value
+		}
+	}
+
+	for field, values := range fieldValues {
+		distinct := make(map[string]struct{})
+		for _, value := range values {
+			distinct[value] = struct{}{}
+		}
+		if len(distinct) > 1 {
+			drift.Fields = append(drift.Fields, api.LifecycleFieldDrift{Field: field, Values: values})
+		}
+	}
+	sort.Slice(drift.Fields, func(i, j int) bool {
+		return drift.Fields[i].Field < drift.Fields[j].Field
+	})
+
+	return drift, nil
+}
+
+// definitionFields returns printable values of definition fields which must be equal for commit,
+// package id isn't included as organizations can approve different packages
+func definitionFields(def *lb.QueryApprovedChaincodeDefinitionResult) map[string]string {
+	validationParameter := fmt.Sprintf(`%x`, def.ValidationParameter)
+	policy := new(fabricPeer.ApplicationPolicy)
+	if err := proto.Unmarshal(def.ValidationParameter, policy); err == nil {
+		validationParameter = proto.CompactTextString(policy)
+	}
+
+	return map[string]string{
+		`sequence`:             fmt.Sprint(def.Sequence),
+		`version`:              def.Version,
+		`endorsement_plugin`:   def.EndorsementPlugin,
+		`validation_plugin`:    def.ValidationPlugin,
+		`validation_parameter`: validationParameter,
+		`collections`:          proto.CompactTextString(def.GetCollections()),
+		`init_required`:        fmt.Sprint(def.InitRequired),
+	}
+}
+
+func (c *lifecycleCC) RolloutStatus(ctx context.Context, channelName, ccName string) []api.LifecyclePeerStatus {
+	var (
+		statuses []api.LifecyclePeerStatus
+		peers    []api.Peer
+	)
+
+	for mspID, mspPeers := range c.peerPool.Peers() {
+		for _, p := range mspPeers {
+			statuses = append(statuses, api.LifecyclePeerStatus{MspID: mspID, Address: p.Uri()})
+			peers = append(peers, p)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range peers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.peerRolloutStatus(ctx, channelName, ccName, peers[i], &statuses[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+func (c *lifecycleCC) peerRolloutStatus(ctx context.Context, channelName, ccName string, p api.Peer, status *api.LifecyclePeerStatus) {
+	installed := new(lb.QueryInstalledChaincodesResult)
+	if status.InstalledErr = c.endorseOn(ctx, p, ``,
+		lifecycle.QueryInstalledChaincodesFuncName, &lb.QueryInstalledChaincodesArgs{}, installed); status.InstalledErr == nil {
+		for _, cc := range installed.InstalledChaincodes {
+			status.InstalledPackageIDs = append(status.InstalledPackageIDs, cc.PackageId)
+		}
+	}
+
+	approved := new(lb.QueryApprovedChaincodeDefinitionResult)
+	// zero sequence means latest approved definition
+	if status.ApprovedErr = c.endorseOn(ctx, p, channelName, lifecycle.QueryApprovedChaincodeDefinitionFuncName,
+		&lb.QueryApprovedChaincodeDefinitionArgs{Name: ccName}, approved); status.ApprovedErr == nil {
+		status.ApprovedSequence = approved.Sequence
+		status.ApprovedPackageID = approved.GetSource().GetLocalPackage().GetPackageId()
+	}
+}
+
+// endorse sends proposal to peer of current identity organization and returns response payload
+func (c *lifecycleCC) endorse(ctx context.Context, channelName string, fn string, arg proto.Message) ([]byte, error) {
+	prop, _, err := c.proposal(channelName, fn, arg)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := c.peerPool.Process(ctx, c.identity.GetMSPIdentifier(), prop)
@@ -44,6 +432,103 @@ func (c *lifecycleCC) endorse(ctx context.Context, fn string, args ...string) ([
 	return resp.Response.Payload, nil
 }
 
-func NewLifecycle(peerPool api.PeerPool, identity msp.SigningIdentity) api.Lifecycle {
-	return &lifecycleCC{peerPool: peerPool, identity: identity, processor: peerSDK.NewProcessor(``)}
+// endorseOn sends proposal to presented peer and unmarshals response payload to result
+func (c *lifecycleCC) endorseOn(ctx context.Context, p api.Peer, channelName string, fn string, arg, result proto.Message) error {
+	prop, _, err := c.proposal(channelName, fn, arg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.Endorse(ctx, prop)
+	if err != nil {
+		return errors.Wrap(err, `failed to endorse proposal`)
+	}
+
+	if err = proto.Unmarshal(resp.Response.Payload, result); err != nil {
+		return errors.Wrap(err, `failed to unmarshal protobuf`)
+	}
+	return nil
+}
+
+func (c *lifecycleCC) proposal(channelName string, fn string, arg proto.Message) (*fabricPeer.SignedProposal, api.ChaincodeTx, error) {
+	argBytes, err := proto.Marshal(arg)
+	if err != nil {
+		return nil, ``, errors.Wrap(err, `failed to marshal args`)
+	}
+
+	prop, tx, err := peerSDK.NewProcessor(channelName).CreateProposal(
+		&api.DiscoveryChaincode{Name: lifecycleName, Type: api.CCTypeGoLang}, c.identity, fn, [][]byte{argBytes}, nil)
+	if err != nil {
+		return nil, ``, errors.Wrap(err, `failed to create proposal`)
+	}
+	return prop, tx, nil
+}
+
+// submit endorses proposal on peers of presented organizations, sends transaction to orderer and waits for commit
+func (c *lifecycleCC) submit(ctx context.Context, channelName string, fn string, arg proto.Message, endorserMSPs ...string) error {
+	if c.orderer == nil {
+		return errors.New(`orderer is not presented`)
+	}
+
+	signedProp, tx, err := c.proposal(channelName, fn, arg)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]*fabricPeer.ProposalResponse, 0, len(endorserMSPs))
+	for _, mspID := range endorserMSPs {
+		resp, err := c.peerPool.Process(ctx, mspID, signedProp)
+		if err != nil {
+			return errors.Wrapf(err, `failed to endorse proposal on %s`, mspID)
+		}
+		responses = append(responses, resp)
+	}
+
+	prop := new(fabricPeer.Proposal)
+	if err = proto.Unmarshal(signedProp.ProposalBytes, prop); err != nil {
+		return errors.Wrap(err, `failed to unmarshal proposal`)
+	}
+
+	envelope, err := protoutil.CreateSignedTx(prop, c.identity, responses...)
+	if err != nil {
+		return errors.Wrap(err, `failed to create transaction`)
+	}
+
+	if _, err = c.orderer.Broadcast(ctx, envelope); err != nil {
+		return errors.Wrap(err, `failed to broadcast transaction`)
+	}
+
+	waiter, err := txwaiter.Self(&api.DoOptions{Pool: c.peerPool, Identity: c.identity})
+	if err != nil {
+		return errors.Wrap(err, `failed to create tx waiter`)
+	}
+	return waiter.Wait(ctx, channelName, tx)
+}
+
+type noDBArtifacts struct{}
+
+func (noDBArtifacts) GetDBArtifacts([]byte) ([]byte, error) {
+	return nil, nil
+}
+
+// packageID returns id of chaincode package which is assigned by peer on installation
+func packageID(pkg []byte) (string, error) {
+	ccPkg, err := persistence.ChaincodePackageParser{MetadataProvider: noDBArtifacts{}}.Parse(pkg)
+	if err != nil {
+		return ``, errors.Wrap(err, `failed to parse chaincode package`)
+	}
+	return fmt.Sprintf(`%s:%x`, ccPkg.Metadata.Label, sha256.Sum256(pkg)), nil
+}
+
+func isInstalled(installed *lb.QueryInstalledChaincodesResult, packageID string) bool {
+	for _, cc := range installed.InstalledChaincodes {
+		if cc.PackageId == packageID {
+			return true
+		}
+	}
+	return false
+}
+
+func NewLifecycle(peerPool api.PeerPool, orderer api.Orderer, identity msp.SigningIdentity) api.Lifecycle {
+	return &lifecycleCC{peerPool: peerPool, orderer: orderer, identity: identity}
 }
@@ -35,7 +35,7 @@ func (c *qscc) GetChainInfo(ctx context.Context, channelName string) (*common.Bl
 }
 
 func (c *qscc) GetBlockByNumber(ctx context.Context, channelName string, blockNumber int64) (*common.Block, error) {
-	if blockBytes, err := c.endorse(ctx, qsccPkg.GetBlockByNumber, strconv.FormatInt(blockNumber, 10)); err != nil {
+	if blockBytes, err := c.endorse(ctx, qsccPkg.GetBlockByNumber, channelName, strconv.FormatInt(blockNumber, 10)); err != nil {
 		return nil, errors.Wrap(err, `failed to get block`)
 	} else {
 		block := new(common.Block)
@@ -47,7 +47,7 @@ func (c *qscc) GetBlockByNumber(ctx context.Context, channelName string, blockNu
 }
 
 func (c *qscc) GetBlockByHash(ctx context.Context, channelName string, blockHash []byte) (*common.Block, error) {
-	if blockBytes, err := c.endorse(ctx, qsccPkg.GetBlockByHash, string(blockHash)); err != nil {
+	if blockBytes, err := c.endorse(ctx, qsccPkg.GetBlockByHash, channelName, string(blockHash)); err != nil {
 		return nil, errors.Wrap(err, `failed to get block`)
 	} else {
 		block := new(common.Block)
@@ -59,7 +59,7 @@ func (c *qscc) GetBlockByHash(ctx context.Context, channelName string, blockHash
 }
 
 func (c *qscc) GetTransactionByID(ctx context.Context, channelName string, tx api.ChaincodeTx) (*peer.ProcessedTransaction, error) {
-	if txBytes, err := c.endorse(ctx, qsccPkg.GetTransactionByID, string(tx)); err != nil {
+	if txBytes, err := c.endorse(ctx, qsccPkg.GetTransactionByID, channelName, string(tx)); err != nil {
 		return nil, errors.Wrap(err, `failed to get transaction`)
 	} else {
 		transaction := new(peer.ProcessedTransaction)
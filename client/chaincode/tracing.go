@@ -0,0 +1,51 @@
+package chaincode
+
+import (
+	"context"
+
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// TracerName is name of tracer used for spans of chaincode invocations
+const TracerName = `github.com/s7techlab/hlf-sdk-go`
+
+const (
+	AttrChannel   = attribute.Key(`hlf.channel`)
+	AttrChaincode = attribute.Key(`hlf.chaincode`)
+	AttrFn        = attribute.Key(`hlf.fn`)
+	AttrTxID      = attribute.Key(`hlf.tx_id`)
+	AttrMspID     = attribute.Key(`hlf.msp_id`)
+)
+
+// startSpan starts span of chaincode operation with channel, chaincode and function attributes
+func (c *Core) startSpan(ctx context.Context, name, fn string) (context.Context, trace.Span) {
+	return c.tracer.Start(ctx, name, trace.WithAttributes(
+		AttrChannel.String(c.channelName), AttrChaincode.String(c.name), AttrFn.String(fn)))
+}
+
+// endSpan records error if it occurred and ends span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedPool starts span for each endorsement processed by pool, so endorsements of target MSPs are traced separately
+type tracedPool struct {
+	api.PeerPool
+	tracer trace.Tracer
+}
+
+func (p *tracedPool) Process(ctx context.Context, mspId string, proposal *fabricPeer.SignedProposal) (*fabricPeer.ProposalResponse, error) {
+	ctx, span := p.tracer.Start(ctx, `endorse`, trace.WithAttributes(AttrMspID.String(mspId)))
+	resp, err := p.PeerPool.Process(ctx, mspId, proposal)
+	endSpan(span, err)
+	return resp, err
+}
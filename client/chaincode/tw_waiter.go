@@ -1,6 +1,8 @@
 package chaincode
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/s7techlab/hlf-sdk-go/api"
 )
 
@@ -14,3 +16,92 @@ func WithTxWaiter(builder TxWaitBuilder) api.DoOption {
 		return
 	}
 }
+
+// WithSizes - add option for getting sizes of proposal, responses and envelope of invoke
+func WithSizes(sizes *api.TxSizes) api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		cfg.Sizes = sizes
+		return nil
+	}
+}
+
+// WithPostCommitVerify - add option for comparing values of keys read after commit by chaincode query function readFn
+// with endorsed ones. readFn takes key as single argument and returns its current value, empty for deleted key.
+// Key can be changed by later transaction before read, so mismatch requires investigation rather than retry.
+// It takes additional round trip to peer per key, so use it only for high-value transactions
+func WithPostCommitVerify(readFn string, keys ...string) api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		if readFn == `` {
+			return errors.New(`post commit read function is empty`)
+		}
+		cfg.PostCommitReadFn = readFn
+		cfg.PostCommitVerifyKeys = keys
+		return nil
+	}
+}
+
+// WithEndorsementInfo - add option for getting status and latency of endorsement by each organization peer
+func WithEndorsementInfo(infos *[]api.EndorsementInfo) api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		cfg.Endorsements = infos
+		return nil
+	}
+}
+
+// WithCollections - add option for endorsing invoke only on peers of organizations which are members
+// of presented private data collections
+func WithCollections(collections ...string) api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		cfg.Collections = collections
+		return nil
+	}
+}
+
+// WithRequiredEndorsers - add option for endorsing invoke by one peer of each presented organization
+// instead of organizations derived from endorsement policy or collections.
+// Invoke fails if any of organizations has no ready peer in pool
+func WithRequiredEndorsers(mspIDs ...string) api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		if len(mspIDs) == 0 {
+			return errors.New(`required endorsers are empty`)
+		}
+		cfg.RequiredEndorsers = mspIDs
+		return nil
+	}
+}
+
+// WithPolicyEndorsers - add option for endorsing invoke by minimal set of organizations which have ready peers
+// and satisfy chaincode endorsement policy instead of all organizations mentioned in policy
+func WithPolicyEndorsers() api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		cfg.PolicyEndorsers = true
+		return nil
+	}
+}
+
+// WithReturnWriteSet - add option for returning public state writes of committed transaction in invoke result,
+// writes are taken from commit block
+func WithReturnWriteSet() api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		cfg.ReturnWriteSet = true
+		return nil
+	}
+}
+
+// WithInvokeTimeouts - add option for limiting endorsement and commit stages of invoke,
+// deadlines set in context by ContextWithEndorseDeadline and ContextWithCommitDeadline take precedence
+func WithInvokeTimeouts(timeouts api.InvokeTimeouts) api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		cfg.Timeouts = timeouts
+		return nil
+	}
+}
+
+// WithoutPolicyCheck - add option for broadcasting transaction without checking that collected endorsements
+// satisfy chaincode endorsement policy
+func WithoutPolicyCheck() api.DoOption {
+	return func(cfg *api.DoOptions) error {
+		cfg.SkipPolicyCheck = true
+		return nil
+	}
+}
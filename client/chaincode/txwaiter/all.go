@@ -52,18 +52,31 @@ func (w *allMspWaiter) setErr() {
 
 // Wait - implementation of api.TxWaiter interface
 func (w *allMspWaiter) Wait(ctx context.Context, channel string, txid api.ChaincodeTx) error {
+	_, _, err := w.WaitBlock(ctx, channel, txid)
+	return err
+}
+
+// WaitBlock - implementation of api.TxBlockWaiter interface, block number is reported by any of delivers
+func (w *allMspWaiter) WaitBlock(ctx context.Context, channel string, txid api.ChaincodeTx) (uint64, bool, error) {
 	var (
-		wg   = new(sync.WaitGroup)
-		errS = make(chan error, len(w.delivers))
+		wg      = new(sync.WaitGroup)
+		errS    = make(chan error, len(w.delivers))
+		block   uint64
+		found   bool
+		blockMx sync.Mutex
 	)
 
 	for i := range w.delivers {
 		wg.Add(1)
 		go func(j int) {
-			err := waitPerOne(ctx, w.delivers[j], channel, txid)
+			number, ok, err := waitPerOne(ctx, w.delivers[j], channel, txid)
 			if err != nil {
 				w.setErr()
 				errS <- err
+			} else if ok {
+				blockMx.Lock()
+				block, found = number, true
+				blockMx.Unlock()
 			}
 			wg.Done()
 		}(i)
@@ -78,19 +91,31 @@ func (w *allMspWaiter) Wait(ctx context.Context, channel string, txid api.Chainc
 				mErr.Errors = append(mErr.Errors, e)
 			}
 		}
-		return mErr
+		return 0, false, mErr
 	}
 
-	return nil
+	return block, found, nil
 }
 
-func waitPerOne(ctx context.Context, deliver api.DeliverClient, channelName string, txid api.ChaincodeTx) error {
+// waitPerOne waits for transaction by deliver client and returns number of its block, false if block is unknown
+func waitPerOne(ctx context.Context, deliver api.DeliverClient, channelName string, txid api.ChaincodeTx) (uint64, bool, error) {
 	sub, err := deliver.SubscribeTx(ctx, channelName, txid)
 	if err != nil {
-		return errors.Wrap(err, "failed to subscribe on tx event")
+		return 0, false, errors.Wrap(err, "failed to subscribe on tx event")
 	}
 	defer sub.Close()
 
-	_, err = sub.Result()
-	return err
+	if _, err = sub.Result(); err != nil {
+		return 0, false, err
+	}
+	number, found := blockNumber(sub)
+	return number, found, nil
+}
+
+// blockNumber returns number of block with transaction if subscription reports it
+func blockNumber(sub api.TxSubscription) (uint64, bool) {
+	if blockSub, ok := sub.(api.TxBlockSubscription); ok {
+		return blockSub.BlockNumber()
+	}
+	return 0, false
 }
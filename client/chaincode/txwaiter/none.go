@@ -0,0 +1,24 @@
+package txwaiter
+
+import (
+	"context"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// None doesn't wait for transaction commit, so invoke returns right after broadcast
+func None(*api.DoOptions) (api.TxWaiter, error) {
+	return noneWaiter{}, nil
+}
+
+type noneWaiter struct{}
+
+func (noneWaiter) Wait(context.Context, string, api.ChaincodeTx) error {
+	return nil
+}
+
+// Waits reports whether waiter waits for transaction commit
+func Waits(waiter api.TxWaiter) bool {
+	_, none := waiter.(noneWaiter)
+	return !none
+}
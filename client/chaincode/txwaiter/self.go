@@ -25,17 +25,27 @@ type selfPeerWaiter struct {
 
 // Wait - implementation of api.TxWaiter interface
 func (w *selfPeerWaiter) Wait(ctx context.Context, channel string, txid api.ChaincodeTx) error {
+	_, _, err := w.WaitBlock(ctx, channel, txid)
+	return err
+}
+
+// WaitBlock - implementation of api.TxBlockWaiter interface
+func (w *selfPeerWaiter) WaitBlock(ctx context.Context, channel string, txid api.ChaincodeTx) (uint64, bool, error) {
 	mspID := w.identity.GetMSPIdentifier()
 	deliver, err := w.pool.DeliverClient(mspID, w.identity)
 	if err != nil {
-		return errors.Wrapf(err, "%s: failed to get delivery client", mspID)
+		return 0, false, errors.Wrapf(err, "%s: failed to get delivery client", mspID)
 	}
+
 	sub, err := deliver.SubscribeTx(ctx, channel, txid)
 	if err != nil {
-		return errors.Wrapf(err, "%s: failed to subscribe on tx event", mspID)
+		return 0, false, errors.Wrapf(err, "%s: failed to subscribe on tx event", mspID)
 	}
 	defer sub.Close()
 
-	_, err = sub.Result()
-	return err
+	if _, err = sub.Result(); err != nil {
+		return 0, false, err
+	}
+	number, found := blockNumber(sub)
+	return number, found, nil
 }
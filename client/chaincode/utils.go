@@ -1,5 +1,14 @@
 package chaincode
 
+import (
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
 func argsToBytes(args ...string) [][]byte {
 	retArgs := make([][]byte, 0)
 	for _, arg := range args {
@@ -7,3 +16,60 @@ func argsToBytes(args ...string) [][]byte {
 	}
 	return retArgs
 }
+
+// decodeEndorseError returns error decoded from chaincode response of endorsement error,
+// false is returned if there is no decoder or response can't be decoded
+func decodeEndorseError(decoder api.ResponseErrorDecoder, err error) (error, bool) {
+	if decoder == nil || err == nil {
+		return nil, false
+	}
+
+	errs := []error{errors.Cause(err)}
+	if multiErr, ok := errs[0].(*api.MultiError); ok {
+		errs = multiErr.Errors
+	}
+
+	for _, e := range errs {
+		if endorseErr, ok := errors.Cause(e).(api.PeerEndorseError); ok {
+			decoded := decoder(&fabricPeer.Response{
+				Status:  endorseErr.Status,
+				Message: endorseErr.Message,
+				Payload: endorseErr.Payload,
+			})
+			if decoded != nil {
+				return decoded, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// signingIdentity returns signing identity which uses crypto suite of core
+func signingIdentity(identity api.Identity, cs api.CryptoSuite) (msp.SigningIdentity, error) {
+	if identity == nil {
+		return nil, api.ErrNoIdentity
+	}
+	if cs == nil {
+		return nil, api.ErrNoCryptoSuite
+	}
+	return identity.GetSigningIdentity(cs), nil
+}
+
+// endorsedEvent returns chaincode event from proposal response and checks that it is emitted by transaction chaincode
+func endorsedEvent(resp *fabricPeer.ProposalResponse, tx api.ChaincodeTx, ccName string) (*fabricPeer.ChaincodeEvent, error) {
+	event, err := util.GetEventFromProposalResponse(resp)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get chaincode event`)
+	}
+	if event == nil {
+		return nil, nil
+	}
+
+	if event.TxId != string(tx) {
+		return nil, errors.Errorf(`chaincode event tx id %s differs from tx %s`, event.TxId, tx)
+	}
+	if event.ChaincodeId != ccName {
+		return nil, errors.Errorf(`chaincode event is emitted by chaincode %s instead of %s`, event.ChaincodeId, ccName)
+	}
+	return event, nil
+}
@@ -0,0 +1,47 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/peer/deliver"
+)
+
+func (c *Core) Blocks(ctx context.Context, seekOpt ...api.EventCCSeekOption) (api.RawBlockSubscription, error) {
+	// deliver client of pool peer verifies and records delivered blocks as peer is configured
+	deliverClient, err := c.peerPool.DeliverClient(c.mspId, api.ResolveIdentity(c.identity))
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get delivery client`)
+	}
+
+	blocks, err := deliverClient.SubscribeBlock(ctx, c.name, seekOpt...)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to subscribe on blocks`)
+	}
+	return deliver.NewRawBlockSubscription(ctx, blocks), nil
+}
+
+func (c *Core) FilteredBlocks(ctx context.Context, onReconnect api.ReconnectHandler, seekOpt ...api.EventCCSeekOption) (api.FilteredBlockSubscription, error) {
+	return deliver.SubscribeFilteredBlocks(ctx, c.readyPeerConn, c.name, api.ResolveIdentity(c.identity), onReconnect, seekOpt...)
+}
+
+// readyPeerConn returns connection of first ready peer of current identity organization.
+// Filtered blocks have no data to verify, so filtered subscription uses peer connection directly
+func (c *Core) readyPeerConn() (*grpc.ClientConn, error) {
+	for _, status := range c.peerPool.Status()[c.mspId] {
+		if !status.Ready {
+			continue
+		}
+
+		conn, err := c.peerPool.Conn(c.mspId, status.Address)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to get peer connection`)
+		}
+		return conn, nil
+	}
+
+	return nil, api.ErrNoReadyPeers{MspId: c.mspId}
+}
@@ -0,0 +1,24 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+func (c *Core) Config(ctx context.Context) (*common.Config, error) {
+	configBlock, err := util.GetConfigBlockFromOrderer(ctx, api.ResolveIdentity(c.identity), c.orderer, c.name)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get config block`)
+	}
+
+	conf, err := util.GetConfigFromBlock(configBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get channel config`)
+	}
+	return conf, nil
+}
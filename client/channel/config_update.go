@@ -0,0 +1,134 @@
+package channel
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/configtx"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+// ConfigUpdateBuilder modifies copy of current channel config and computes config update
+// from current config to modified one. First error of modification is returned by Build
+type ConfigUpdateBuilder struct {
+	channelName string
+	current     *common.Config
+	tx          configtx.ConfigTx
+	err         error
+}
+
+// ConfigUpdate returns builder of config update for channel with presented current config,
+// signed update can be submitted with util.ProceedChannelUpdate
+func ConfigUpdate(channelName string, current *common.Config) *ConfigUpdateBuilder {
+	return &ConfigUpdateBuilder{channelName: channelName, current: current, tx: configtx.New(current)}
+}
+
+// AddOrdererEndpoint adds address in host:port format to endpoints of orderer organization with presented MSP id
+func (b *ConfigUpdateBuilder) AddOrdererEndpoint(mspID, address string) *ConfigUpdateBuilder {
+	b.updateOrdererOrg(mspID, address, (*configtx.OrdererOrg).SetEndpoint)
+	return b
+}
+
+// RemoveOrdererEndpoint removes address in host:port format from endpoints of orderer organization
+// with presented MSP id
+func (b *ConfigUpdateBuilder) RemoveOrdererEndpoint(mspID, address string) *ConfigUpdateBuilder {
+	b.updateOrdererOrg(mspID, address, (*configtx.OrdererOrg).RemoveEndpoint)
+	return b
+}
+
+func (b *ConfigUpdateBuilder) updateOrdererOrg(mspID, address string, fn func(*configtx.OrdererOrg, configtx.Address) error) {
+	if b.err != nil {
+		return
+	}
+
+	endpoint, err := parseAddress(address)
+	if err != nil {
+		b.err = err
+		return
+	}
+
+	orgName, err := util.GetOrdererOrgNameFromChannelConfig(b.current, mspID)
+	if err != nil {
+		b.err = errors.Wrap(err, `failed to get orderer organization`)
+		return
+	}
+
+	if err = fn(b.tx.Orderer().Organization(orgName), endpoint); err != nil {
+		b.err = errors.Wrapf(err, `failed to update endpoints of orderer organization %s`, orgName)
+	}
+}
+
+// AddApplicationOrg adds organization group, i.e. output of configtxgen -printOrg, to channel application
+// organizations with presented name
+func (b *ConfigUpdateBuilder) AddApplicationOrg(orgName string, org *common.ConfigGroup) *ConfigUpdateBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	appGroup, ok := b.tx.UpdatedConfig().GetChannelGroup().GetGroups()[channelconfig.ApplicationGroupKey]
+	if !ok {
+		b.err = errors.New(`application group not found`)
+		return b
+	}
+	if _, ok = appGroup.Groups[orgName]; ok {
+		b.err = errors.Errorf(`application organization %s already exists`, orgName)
+		return b
+	}
+	if appGroup.Groups == nil {
+		appGroup.Groups = make(map[string]*common.ConfigGroup)
+	}
+
+	appGroup.Groups[orgName] = proto.Clone(org).(*common.ConfigGroup)
+	return b
+}
+
+// RemoveApplicationOrg removes application organization with presented MSP id
+func (b *ConfigUpdateBuilder) RemoveApplicationOrg(mspID string) *ConfigUpdateBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	orgName, err := util.GetApplicationOrgNameFromChannelConfig(b.current, mspID)
+	if err != nil {
+		b.err = errors.Wrap(err, `failed to get application organization`)
+		return b
+	}
+
+	b.tx.Application().RemoveOrganization(orgName)
+	return b
+}
+
+// Build returns config update from current config to modified one
+func (b *ConfigUpdateBuilder) Build() (*common.ConfigUpdate, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	updateBytes, err := b.tx.ComputeMarshaledUpdate(b.channelName)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to compute config update`)
+	}
+
+	update := new(common.ConfigUpdate)
+	if err = proto.Unmarshal(updateBytes, update); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal config update`)
+	}
+	return update, nil
+}
+
+func parseAddress(address string) (configtx.Address, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return configtx.Address{}, errors.Wrapf(err, `invalid address %s`, address)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return configtx.Address{}, errors.Wrapf(err, `invalid port of address %s`, address)
+	}
+	return configtx.Address{Host: host, Port: port}, nil
+}
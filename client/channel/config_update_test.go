@@ -0,0 +1,59 @@
+package channel_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/client/channel"
+)
+
+func orgGroup(t *testing.T, mspID string) *common.ConfigGroup {
+	fabricConfig, err := proto.Marshal(&mspproto.FabricMSPConfig{Name: mspID})
+	require.NoError(t, err)
+	mspConfig, err := proto.Marshal(&mspproto.MSPConfig{Config: fabricConfig})
+	require.NoError(t, err)
+	return &common.ConfigGroup{
+		Values:   map[string]*common.ConfigValue{channelconfig.MSPKey: {Value: mspConfig}},
+		Groups:   map[string]*common.ConfigGroup{},
+		Policies: map[string]*common.ConfigPolicy{},
+	}
+}
+
+func TestConfigUpdate(t *testing.T) {
+	conf := &common.Config{ChannelGroup: &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{
+			channelconfig.ApplicationGroupKey: {Groups: map[string]*common.ConfigGroup{
+				`Org1`: orgGroup(t, `Org1MSP`),
+			}},
+			channelconfig.OrdererGroupKey: {Groups: map[string]*common.ConfigGroup{
+				`Orderer`: orgGroup(t, `OrdererMSP`),
+			}},
+		},
+	}}
+
+	update, err := channel.ConfigUpdate(`channel`, conf).
+		AddOrdererEndpoint(`OrdererMSP`, `orderer1:7050`).
+		AddApplicationOrg(`Org2`, orgGroup(t, `Org2MSP`)).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, `channel`, update.ChannelId)
+
+	endpoints := update.WriteSet.Groups[channelconfig.OrdererGroupKey].Groups[`Orderer`].Values[channelconfig.EndpointsKey]
+	require.NotNil(t, endpoints)
+	addresses := new(common.OrdererAddresses)
+	require.NoError(t, proto.Unmarshal(endpoints.Value, addresses))
+	require.Equal(t, []string{`orderer1:7050`}, addresses.Addresses)
+
+	require.Contains(t, update.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups, `Org2`)
+
+	_, err = channel.ConfigUpdate(`channel`, conf).AddOrdererEndpoint(`UnknownMSP`, `orderer1:7050`).Build()
+	require.Error(t, err)
+
+	_, err = channel.ConfigUpdate(`channel`, conf).AddOrdererEndpoint(`OrdererMSP`, `orderer1`).Build()
+	require.Error(t, err)
+}
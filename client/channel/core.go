@@ -20,14 +20,18 @@ type Core struct {
 	dp           api.DiscoveryProvider
 	identity     msp.SigningIdentity
 	fabricV2     bool
-	log          *zap.Logger
+	// ccOpts are applied to chaincodes of channel
+	ccOpts []chaincode.Opt
+	log    *zap.Logger
+	msps   *channelMSPs
+	mspsMx sync.Mutex
 }
 
 func (c *Core) Chaincode(name string) api.Chaincode {
 	c.chaincodesMx.Lock()
 	defer c.chaincodesMx.Unlock()
 	if cc, ok := c.chaincodes[name]; !ok {
-		cc = chaincode.NewCore(c.mspId, name, c.name, c.peerPool, c.orderer, c.dp, c.identity)
+		cc = chaincode.NewCore(c.mspId, name, c.name, c.peerPool, c.orderer, c.dp, c.identity, c.ccOpts...)
 		c.chaincodes[name] = cc
 		return cc
 	} else {
@@ -35,9 +39,15 @@ func (c *Core) Chaincode(name string) api.Chaincode {
 	}
 }
 
+// Orderer returns orderer used by channel
+func (c *Core) Orderer() api.Orderer {
+	return c.orderer
+}
+
+// NewCore returns channel, chaincode options are applied to each chaincode of channel
 func NewCore(mspId string, name string, peerPool api.PeerPool,
 	orderer api.Orderer, dp api.DiscoveryProvider, identity msp.SigningIdentity,
-	fabricV2 bool, log *zap.Logger) api.Channel {
+	fabricV2 bool, log *zap.Logger, ccOpts ...chaincode.Opt) api.Channel {
 	return &Core{
 		mspId:      mspId,
 		name:       name,
@@ -47,6 +57,7 @@ func NewCore(mspId string, name string, peerPool api.PeerPool,
 		dp:         dp,
 		identity:   identity,
 		fabricV2:   fabricV2,
+		ccOpts:     ccOpts,
 		log:        log,
 	}
 }
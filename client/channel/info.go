@@ -0,0 +1,106 @@
+package channel
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/util"
+	qsccPkg "github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/peer"
+)
+
+func (c *Core) Info(ctx context.Context) (*common.BlockchainInfo, error) {
+	payload, err := c.queryQSCC(ctx, qsccPkg.GetChainInfo, c.name)
+	if err != nil {
+		if _, ok := err.(api.ErrNoReadyPeers); ok || ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, api.ErrChannelNotJoined{Channel: c.name, MspId: c.mspId, Err: err}
+	}
+
+	info := new(common.BlockchainInfo)
+	if err = proto.Unmarshal(payload, info); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal chain info`)
+	}
+	return info, nil
+}
+
+func (c *Core) GetBlock(ctx context.Context, number uint64) (*common.Block, []byte, error) {
+	payload, err := c.queryQSCC(ctx, qsccPkg.GetBlockByNumber, c.name, strconv.FormatUint(number, 10))
+	if err != nil {
+		// peer doesn't distinguish missing block from other failures, so height is checked
+		info, infoErr := c.Info(ctx)
+		if infoErr != nil {
+			return nil, nil, infoErr
+		}
+		if number >= info.Height {
+			return nil, nil, api.ErrBlockOutOfRange{Number: number, Height: info.Height}
+		}
+		return nil, nil, errors.Wrapf(err, `failed to get block %d`, number)
+	}
+
+	return decodeBlock(payload)
+}
+
+func (c *Core) GetBlockByTxID(ctx context.Context, txID api.ChaincodeTx) (*common.Block, []byte, error) {
+	payload, err := c.queryQSCC(ctx, qsccPkg.GetBlockByTxID, c.name, string(txID))
+	if err != nil {
+		if _, infoErr := c.Info(ctx); infoErr != nil {
+			return nil, nil, infoErr
+		}
+		return nil, nil, errors.Wrapf(err, `failed to get block by tx %s`, txID)
+	}
+
+	return decodeBlock(payload)
+}
+
+func decodeBlock(payload []byte) (*common.Block, []byte, error) {
+	block := new(common.Block)
+	if err := proto.Unmarshal(payload, block); err != nil {
+		return nil, nil, errors.Wrap(err, `failed to unmarshal block`)
+	}
+	return block, payload, nil
+}
+
+// queryQSCC invokes qscc function on ready peers of current identity organization one by one
+// until some peer responds successfully. Error of last tried peer is returned if all peers fail
+func (c *Core) queryQSCC(ctx context.Context, fn string, args ...string) ([]byte, error) {
+	proposal, _, err := peer.NewProcessor(``).CreateProposal(
+		&api.DiscoveryChaincode{Name: `qscc`, Type: api.CCTypeGoLang},
+		api.ResolveIdentity(c.identity), fn, util.ToChaincodeArgs(args...), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to create proposal`)
+	}
+
+	ready := make(map[string]bool)
+	for _, status := range c.peerPool.Status()[c.mspId] {
+		ready[status.Address] = status.Ready
+	}
+
+	var lastErr error
+	for _, p := range c.peerPool.Peers()[c.mspId] {
+		if !ready[p.Uri()] {
+			continue
+		}
+
+		resp, err := p.Endorse(ctx, proposal)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = errors.Wrap(err, p.Uri())
+			continue
+		}
+		return resp.GetResponse().GetPayload(), nil
+	}
+
+	if lastErr == nil {
+		return nil, api.ErrNoReadyPeers{MspId: c.mspId}
+	}
+	return nil, lastErr
+}
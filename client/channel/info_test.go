@@ -0,0 +1,117 @@
+package channel_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/client/channel"
+	"github.com/s7techlab/hlf-sdk-go/crypto"
+	"github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+	"github.com/s7techlab/hlf-sdk-go/identity"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+)
+
+// infoPeer responds with chain info if it joined channel
+type infoPeer struct {
+	api.Peer
+	uri    string
+	joined bool
+}
+
+func (p *infoPeer) Uri() string { return p.uri }
+
+func (p *infoPeer) Endorse(_ context.Context, proposal *fabricPeer.SignedProposal, _ ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	if !p.joined {
+		return nil, api.PeerEndorseError{Status: 500, Message: `channel not found`}
+	}
+
+	prop, err := protoutil.UnmarshalProposal(proposal.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	cpp, err := protoutil.UnmarshalChaincodeProposalPayload(prop.Payload)
+	if err != nil {
+		return nil, err
+	}
+	spec := new(fabricPeer.ChaincodeInvocationSpec)
+	if err = proto.Unmarshal(cpp.Input, spec); err != nil {
+		return nil, err
+	}
+	args := spec.ChaincodeSpec.Input.Args
+
+	var msg proto.Message
+	switch string(args[0]) {
+	case `GetChainInfo`:
+		msg = &common.BlockchainInfo{Height: 10}
+	case `GetBlockByNumber`:
+		number, _ := strconv.ParseUint(string(args[2]), 10, 64)
+		if number >= 10 {
+			return nil, api.PeerEndorseError{Status: 500, Message: `entry not found in index`}
+		}
+		msg = &common.Block{Header: &common.BlockHeader{Number: number}}
+	}
+
+	payload, _ := proto.Marshal(msg)
+	return &fabricPeer.ProposalResponse{Response: &fabricPeer.Response{Status: 200, Payload: payload}}, nil
+}
+
+func noCheck(ctx context.Context, _ api.Peer, _ chan bool) {
+	<-ctx.Done()
+}
+
+func TestInfo(t *testing.T) {
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `../chaincode/testdata/msp`)
+	require.NoError(t, err)
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	newChannel := func(peers ...*infoPeer) api.Channel {
+		peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{})
+		for _, p := range peers {
+			require.NoError(t, peerPool.Add(`org1msp`, p, noCheck))
+		}
+		return channel.NewCore(`org1msp`, `channel`, peerPool, nil, nil, id.GetSigningIdentity(cs), true, logger.DefaultLogger)
+	}
+
+	info, err := newChannel(&infoPeer{uri: `peer0`}, &infoPeer{uri: `peer1`, joined: true}).Info(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), info.Height)
+
+	_, err = newChannel(&infoPeer{uri: `peer0`}).Info(context.Background())
+	require.IsType(t, api.ErrChannelNotJoined{}, err)
+
+	_, err = newChannel().Info(context.Background())
+	require.IsType(t, api.ErrNoReadyPeers{}, err)
+}
+
+func TestGetBlock(t *testing.T) {
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `../chaincode/testdata/msp`)
+	require.NoError(t, err)
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{})
+	require.NoError(t, peerPool.Add(`org1msp`, &infoPeer{uri: `peer0`, joined: true}, noCheck))
+	ch := channel.NewCore(`org1msp`, `channel`, peerPool, nil, nil, id.GetSigningIdentity(cs), true, logger.DefaultLogger)
+
+	block, raw, err := ch.GetBlock(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), block.Header.Number)
+
+	decoded := new(common.Block)
+	require.NoError(t, proto.Unmarshal(raw, decoded))
+	require.True(t, proto.Equal(block, decoded))
+
+	_, _, err = ch.GetBlock(context.Background(), 10)
+	require.Equal(t, api.ErrBlockOutOfRange{Number: 10, Height: 10}, err)
+}
@@ -2,10 +2,12 @@ package channel
 
 import (
 	"context"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/s7techlab/hlf-sdk-go/api"
 	"github.com/s7techlab/hlf-sdk-go/client/chaincode/system"
@@ -18,18 +20,52 @@ func (c *Core) Join(ctx context.Context) error {
 		return errors.Wrap(err, `failed to retrieve genesis block from orderer`)
 	}
 
+	return c.JoinWithBlock(ctx, channelGenesis)
+}
+
+func (c *Core) JoinWithBlock(ctx context.Context, genesisBlock *common.Block) error {
 	var cscc api.CSCC
 
 	if c.fabricV2 {
-		cscc = system.NewCSCCV2(c.peerPool, c.identity)
+		cscc = system.NewCSCCV2(c.peerPool, api.ResolveIdentity(c.identity))
 	} else {
-		cscc = system.NewCSCCV1(c.peerPool, c.identity)
+		cscc = system.NewCSCCV1(c.peerPool, api.ResolveIdentity(c.identity))
 	}
 
-	return cscc.JoinChain(ctx, c.name, channelGenesis)
+	err := cscc.JoinChain(ctx, c.name, genesisBlock)
+	// peer refuses to create ledger which already exists
+	if err != nil && strings.Contains(err.Error(), `already exists`) {
+		return api.ErrChannelAlreadyJoined{Channel: c.name, MspId: c.mspId, Err: err}
+	}
+	return err
+}
+
+func (c *Core) Create(ctx context.Context, envelope *common.Envelope) error {
+	payload, err := protoutil.UnmarshalPayload(envelope.GetPayload())
+	if err != nil {
+		return errors.Wrap(err, `failed to unmarshal envelope payload`)
+	}
+	chHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+	if err != nil {
+		return errors.Wrap(err, `failed to unmarshal channel header`)
+	}
+	if chHeader.ChannelId != c.name {
+		return errors.Errorf(`envelope is for channel %s, not %s`, chHeader.ChannelId, c.name)
+	}
+
+	if _, err = c.orderer.Broadcast(ctx, envelope); err != nil {
+		// orderer processes creation of existing channel as its config update, which fails on versions of config
+		if strings.Contains(err.Error(), `existing channel`) || strings.Contains(err.Error(), `already exists`) {
+			return api.ErrChannelAlreadyExists{Channel: c.name, Err: err}
+		}
+		return errors.Wrap(err, `failed to broadcast channel creation transaction`)
+	}
+	return nil
 }
 
 func (c *Core) getGenesisBlockFromOrderer(ctx context.Context) (*common.Block, error) {
+	identity := api.ResolveIdentity(c.identity)
+
 	ordererSeekInfo := &orderer.SeekInfo{
 		Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
 		Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
@@ -41,7 +77,7 @@ func (c *Core) getGenesisBlockFromOrderer(ctx context.Context) (*common.Block, e
 		return nil, errors.Wrap(err, `failed to marshal seekInfo bytes`)
 	}
 
-	txId, nonce, err := util.NewTxWithNonce(c.identity)
+	txId, nonce, err := util.NewTxWithNonce(identity)
 	if err != nil {
 		return nil, errors.Wrap(err, `failed to get new txId`)
 	}
@@ -51,7 +87,7 @@ func (c *Core) getGenesisBlockFromOrderer(ctx context.Context) (*common.Block, e
 		return nil, errors.Wrap(err, `failed to get channel header`)
 	}
 
-	sigHeader, err := util.NewSignatureHeader(c.identity, nonce)
+	sigHeader, err := util.NewSignatureHeader(identity, nonce)
 	if err != nil {
 		return nil, errors.Wrap(err, `failed to get signature header`)
 	}
@@ -61,7 +97,7 @@ func (c *Core) getGenesisBlockFromOrderer(ctx context.Context) (*common.Block, e
 		return nil, errors.Wrap(err, `failed to get payload`)
 	}
 
-	payloadSignature, err := c.identity.Sign(payload)
+	payloadSignature, err := identity.Sign(payload)
 	if err != nil {
 		return nil, errors.Wrap(err, `failed to sign payload`)
 	}
@@ -0,0 +1,46 @@
+package channel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/channel"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+)
+
+type rejectingOrderer struct {
+	api.Orderer
+	err error
+}
+
+func (o *rejectingOrderer) Broadcast(context.Context, *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	return nil, o.err
+}
+
+func creationEnvelope(channelName string) *common.Envelope {
+	chHeader := protoutil.MakeChannelHeader(common.HeaderType_CONFIG_UPDATE, 0, channelName, 0)
+	payload := protoutil.MakePayloadHeader(chHeader, &common.SignatureHeader{})
+	return &common.Envelope{Payload: protoutil.MarshalOrPanic(&common.Payload{Header: payload})}
+}
+
+func TestCreate(t *testing.T) {
+	newChannel := func(err error) api.Channel {
+		return channel.NewCore(`org1msp`, `channel`, nil, &rejectingOrderer{err: err}, nil, nil, true, logger.DefaultLogger)
+	}
+
+	require.NoError(t, newChannel(nil).Create(context.Background(), creationEnvelope(`channel`)))
+
+	err := newChannel(nil).Create(context.Background(), creationEnvelope(`other`))
+	require.Error(t, err)
+
+	err = newChannel(errors.New(`unexpected status: BAD_REQUEST: error applying config update to existing channel 'channel'`)).
+		Create(context.Background(), creationEnvelope(`channel`))
+	require.IsType(t, api.ErrChannelAlreadyExists{}, err)
+}
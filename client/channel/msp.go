@@ -0,0 +1,83 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+)
+
+type channelMSPs struct {
+	configSequence uint64
+	manager        msp.MSPManager
+}
+
+func (c *Core) LoadMSPs(ctx context.Context) (map[string]msp.MSP, error) {
+	manager, err := c.loadMSPManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return manager.GetMSPs()
+}
+
+func (c *Core) ValidateIdentity(ctx context.Context, serializedIdentity []byte) error {
+	manager, err := c.cachedMSPManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := manager.DeserializeIdentity(serializedIdentity)
+	if err != nil {
+		// organization could be added to channel after MSPs were cached
+		if manager, err = c.loadMSPManager(ctx); err != nil {
+			return err
+		}
+		if id, err = manager.DeserializeIdentity(serializedIdentity); err != nil {
+			return errors.Wrap(err, `failed to deserialize identity`)
+		}
+	}
+
+	return id.Validate()
+}
+
+// cachedMSPManager returns cached MSPs or loads them if they aren't loaded yet
+func (c *Core) cachedMSPManager(ctx context.Context) (msp.MSPManager, error) {
+	c.mspsMx.Lock()
+	msps := c.msps
+	c.mspsMx.Unlock()
+
+	if msps != nil {
+		return msps.manager, nil
+	}
+	return c.loadMSPManager(ctx)
+}
+
+// loadMSPManager fetches channel config and recreates MSPs if config sequence is changed
+func (c *Core) loadMSPManager(ctx context.Context) (msp.MSPManager, error) {
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mspsMx.Lock()
+	defer c.mspsMx.Unlock()
+
+	if c.msps != nil && c.msps.configSequence == conf.Sequence {
+		return c.msps.manager, nil
+	}
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to initialize crypto provider`)
+	}
+
+	bundle, err := channelconfig.NewBundle(c.name, conf, cryptoProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to parse channel config`)
+	}
+
+	c.msps = &channelMSPs{configSequence: conf.Sequence, manager: bundle.MSPManager()}
+	return c.msps.manager, nil
+}
@@ -0,0 +1,47 @@
+package channel
+
+import (
+	"context"
+
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+func (c *Core) TxValidationCode(ctx context.Context, txID api.ChaincodeTx) (fabricPeer.TxValidationCode, error) {
+	block, _, err := c.GetBlockByTxID(ctx, txID)
+	if err != nil {
+		return 0, err
+	}
+	return util.GetTxValidationCode(block, string(txID))
+}
+
+func (c *Core) WaitTx(ctx context.Context, txID api.ChaincodeTx) (fabricPeer.TxValidationCode, error) {
+	deliver, err := c.peerPool.DeliverClient(c.mspId, api.ResolveIdentity(c.identity))
+	if err != nil {
+		return 0, errors.Wrap(err, `failed to get delivery client`)
+	}
+
+	// subscription is started before lookup, so transaction committed in between isn't missed
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sub, err := deliver.SubscribeTx(ctx, c.name, txID)
+	if err != nil {
+		return 0, errors.Wrap(err, `failed to subscribe on tx event`)
+	}
+	defer sub.Close()
+
+	if code, err := c.TxValidationCode(ctx, txID); err == nil {
+		return code, nil
+	}
+
+	code, err := sub.Result()
+	// subscription returns error for invalid transaction, which is reported by code only
+	if err != nil && code == fabricPeer.TxValidationCode_VALID {
+		return 0, err
+	}
+	return code, nil
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/channel"
+)
+
+func (c *core) Close() error {
+	if !atomic.CompareAndSwapUint32(&c.closed, 0, 1) {
+		return nil
+	}
+	c.cancel()
+
+	c.discoveryMx.Lock()
+	if c.discoveryPlanCancel != nil {
+		c.discoveryPlanCancel()
+	}
+	c.discoveryMx.Unlock()
+
+	var errs api.MultiError
+	addErr := func(err error, msg string) {
+		if err != nil {
+			errs.Add(errors.Wrap(err, msg))
+		}
+	}
+
+	if c.peerPool != nil {
+		addErr(c.peerPool.Close(), `failed to close peer pool`)
+	}
+
+	// channel orderers are dialed by channel instances unless orderer set by option is used
+	c.channelMx.Lock()
+	for _, ch := range append(c.droppedChannels, channelValues(c.channels)...) {
+		if chCore, ok := ch.(*channel.Core); ok && chCore.Orderer() != nil && chCore.Orderer() != c.orderer {
+			addErr(chCore.Orderer().Close(), `failed to close channel orderer`)
+		}
+	}
+	c.droppedChannels = nil
+	c.channelMx.Unlock()
+
+	c.contextOrderersMx.Lock()
+	for endpoint, ord := range c.contextOrderers {
+		addErr(ord.Close(), `failed to close orderer `+endpoint)
+	}
+	c.contextOrderers = nil
+	c.contextOrderersMx.Unlock()
+
+	if c.orderer != nil {
+		addErr(c.orderer.Close(), `failed to close orderer`)
+	}
+	if c.gateway != nil {
+		addErr(c.gateway.Close(), `failed to close gateway`)
+	}
+
+	// broadcasts are finished after orderers are closed, so buffered audit records are complete
+	if c.auditor != nil {
+		addErr(c.auditor.Close(), `failed to close auditor`)
+	}
+	if c.recorder != nil {
+		addErr(c.recorder.Close(), `failed to close recorder`)
+	}
+
+	if len(errs.Errors) > 0 {
+		return &errs
+	}
+	return nil
+}
+
+func channelValues(channels map[string]api.Channel) []api.Channel {
+	values := make([]api.Channel, 0, len(channels))
+	for _, ch := range channels {
+		values = append(values, ch)
+	}
+	return values
+}
@@ -1,18 +1,26 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/hyperledger/fabric-protos-go/common"
+	ordererProto "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/s7techlab/hlf-sdk-go/api"
 	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/audit"
 	"github.com/s7techlab/hlf-sdk-go/client/chaincode"
 	"github.com/s7techlab/hlf-sdk-go/client/chaincode/system"
 	"github.com/s7techlab/hlf-sdk-go/client/channel"
@@ -21,50 +29,185 @@ import (
 	"github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
 	"github.com/s7techlab/hlf-sdk-go/discovery"
 	"github.com/s7techlab/hlf-sdk-go/logger"
+	"github.com/s7techlab/hlf-sdk-go/metrics"
 	"github.com/s7techlab/hlf-sdk-go/orderer"
 	"github.com/s7techlab/hlf-sdk-go/peer"
+	"github.com/s7techlab/hlf-sdk-go/peer/gateway"
 	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+	"github.com/s7techlab/hlf-sdk-go/recorder"
 	"github.com/s7techlab/hlf-sdk-go/util"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
 )
 
+// DefaultPeerCheckPeriod is period of pool peer state check if dial timeout isn't set by option
+var DefaultPeerCheckPeriod = 5 * time.Second
+
 type core struct {
-	ctx               context.Context
-	logger            *zap.Logger
-	config            *config.Config
-	mspId             string
-	identity          msp.SigningIdentity
-	peerPool          api.PeerPool
-	orderer           api.Orderer
-	discoveryProvider api.DiscoveryProvider
-	channels          map[string]api.Channel
-	channelMx         sync.Mutex
-	chaincodes        map[string]api.ChaincodePackage
-	chaincodeMx       sync.Mutex
-	cs                api.CryptoSuite
-	fetcher           api.CCFetcher
-	fabricV2          bool
+	ctx                  context.Context
+	cancel               context.CancelFunc
+	closed               uint32
+	logger               *zap.Logger
+	config               *config.Config
+	mspId                string
+	identity             msp.SigningIdentity
+	identityMx           sync.RWMutex
+	identityProvider     api.IdentityProvider
+	identityRefreshMx    sync.Mutex
+	peerPool             api.PeerPool
+	orderer              api.Orderer
+	baseOrderer          api.Orderer              // default orderer without decorators
+	ordererTemplate      *config.ConnectionConfig // connection config of default orderer
+	preBroadcastHooks    []api.PreBroadcastHook
+	breakerConfig        *breaker.Config
+	ordererBreakers      map[string]*breaker.Breaker // circuit breakers of orderer endpoints by address
+	ordererBreakersMx    sync.Mutex
+	ordererRetry         *orderer.RetryConfig
+	ordererFailover      *orderer.RetryConfig
+	ordererSources       []api.OrdererSource
+	invokeTimeouts       api.InvokeTimeouts
+	gatewayConfig        *config.ConnectionConfig
+	gateway              api.Gateway
+	contextOrderers      map[string]api.Orderer // orderers dialed for endpoints from context
+	contextOrderersMx    sync.Mutex
+	queryAffinity        *api.QueryAffinity
+	probeTimeout         time.Duration
+	verifyBlocks         bool
+	configBlocks         map[string]map[uint64]*common.Block // config blocks of channels by number for block verifiers
+	configBlocksMx       sync.Mutex
+	clientID             string
+	tlsClientCerts       TLSClientCertMapper
+	tlsServerNames       TLSServerNameMapper
+	dialTimeout          time.Duration
+	callTimeout          time.Duration
+	sharedDeliver        bool
+	txIDGenerator        api.TxIDGenerator
+	warmUpChannels       []string
+	errDecoder           api.ResponseErrorDecoder
+	peerCheck            api.PeerPoolCheckStrategy
+	poolMembership       api.PoolMembershipHandler
+	peerSelection        pool.Strategy
+	recorder             *recorder.Recorder
+	metrics              *metrics.Metrics
+	tracer               trace.Tracer
+	replayer             *recorder.Replayer
+	auditor              *audit.Auditor
+	discoveryProvider    api.DiscoveryProvider
+	discoveryType        string // configured type of discovery provider, empty if provider is set by option
+	discoveryMx          sync.RWMutex
+	discoveryPlanPath    string
+	discoveryPlanRefresh time.Duration
+	discoveryPlanCancel  context.CancelFunc // stops refresh of discovery plan of current provider
+	discoveryCacheTTL    time.Duration
+	discoveryRefresh     time.Duration
+	refreshedPeers       map[string]map[string]struct{} // peers added to pool by membership refresh
+	refreshedPeersMx     sync.Mutex
+	channels             map[string]api.Channel
+	channelChains        map[string]api.Orderer               // undecorated orderer chains of channels, which are probed by warm up
+	channelOrderers      map[string][]config.ConnectionConfig // dialed orderers of channels from discovery or channel config
+	channelOrderersMx    sync.Mutex
+	droppedChannels      []api.Channel // channels replaced on discovery provider change, closed with core
+	channelMx            sync.Mutex
+	chaincodes           map[string]*chaincodeEntry
+	chaincodeMx          sync.Mutex
+	cs                   api.CryptoSuite
+	envelopeCS           api.CryptoSuite
+	envelopeSigner       msp.SigningIdentity
+	fetcher              api.CCFetcher
+	fabricV2             bool
+}
+
+// chaincodeEntry is chaincode package created once for all concurrent callers
+type chaincodeEntry struct {
+	once sync.Once
+	cc   api.ChaincodePackage
 }
 
 func (c *core) Chaincode(name string) api.ChaincodePackage {
+	c.refreshIdentity()
+
+	// lock is held only to get entry, so packages of different chaincodes are created concurrently
 	c.chaincodeMx.Lock()
-	defer c.chaincodeMx.Unlock()
-	if cc, ok := c.chaincodes[name]; !ok {
-		cc = chaincode.NewCorePackage(name, system.NewLSCC(c.peerPool, c.identity), c.fetcher, c.orderer, c.identity)
-		c.chaincodes[name] = cc
-		return cc
-	} else {
-		return cc
+	entry, ok := c.chaincodes[name]
+	if !ok {
+		entry = new(chaincodeEntry)
+		c.chaincodes[name] = entry
 	}
+	c.chaincodeMx.Unlock()
+
+	entry.once.Do(func() {
+		identity := c.signingIdentity()
+		entry.cc = chaincode.NewCorePackage(name, system.NewLSCC(c.peerPool, identity), c.fetcher, c.orderer, identity)
+	})
+	return entry.cc
 }
 
 func (c *core) System() api.SystemCC {
-	return system.NewSCC(c.peerPool, c.identity, c.fabricV2)
+	c.refreshIdentity()
+	return system.NewSCC(c.peerPool, c.orderer, c.signingIdentity(), c.fabricV2)
 }
 
 func (c *core) CurrentIdentity() msp.SigningIdentity {
+	c.identityMx.RLock()
+	defer c.identityMx.RUnlock()
 	return c.identity
 }
 
+// SetIdentity replaces default identity of core, nil identity clears it, so operations must be made
+// with per-call identity as on core created without identity
+func (c *core) SetIdentity(identity api.Identity) {
+	var signingIdentity, envelopeSigner msp.SigningIdentity
+	if identity != nil {
+		signingIdentity = identity.GetSigningIdentity(c.cs)
+		if c.envelopeCS != nil {
+			envelopeSigner = identity.GetSigningIdentity(c.envelopeCS)
+		}
+	}
+
+	c.chaincodeMx.Lock()
+	defer c.chaincodeMx.Unlock()
+	c.identityMx.Lock()
+	defer c.identityMx.Unlock()
+
+	c.identity = signingIdentity
+	c.envelopeSigner = envelopeSigner
+	// channels resolve current identity for each operation, chaincode packages keep identity,
+	// so they will be recreated with new one on demand
+	c.chaincodes = make(map[string]*chaincodeEntry)
+}
+
+func (c *core) SetDiscoveryProvider(provider api.DiscoveryProvider) {
+	c.channelMx.Lock()
+	defer c.channelMx.Unlock()
+	c.discoveryMx.Lock()
+	defer c.discoveryMx.Unlock()
+
+	// plan of replaced provider is no longer refreshed
+	if c.discoveryPlanCancel != nil {
+		c.discoveryPlanCancel()
+		c.discoveryPlanCancel = nil
+	}
+	c.discoveryProvider = provider
+	c.discoveryType = ``
+	// channel instances keep discovery provider, so they will be recreated with new one on demand.
+	// Operations can still use replaced channels, so their orderers are kept until core is closed
+	for _, ch := range c.channels {
+		c.droppedChannels = append(c.droppedChannels, ch)
+	}
+	c.channels = make(map[string]api.Channel)
+	c.channelChains = make(map[string]api.Orderer)
+
+	c.channelOrderersMx.Lock()
+	c.channelOrderers = make(map[string][]config.ConnectionConfig)
+	c.channelOrderersMx.Unlock()
+}
+
+// discovery returns current discovery provider
+func (c *core) discovery() api.DiscoveryProvider {
+	c.discoveryMx.RLock()
+	defer c.discoveryMx.RUnlock()
+	return c.discoveryProvider
+}
+
 func (c *core) CryptoSuite() api.CryptoSuite {
 	return c.cs
 }
@@ -74,38 +217,38 @@ func (c *core) PeerPool() api.PeerPool {
 }
 
 func (c *core) Channel(name string) api.Channel {
+	c.refreshIdentity()
+
 	log := c.logger.Named(`Channel`).With(zap.String(`channel`, name))
 	c.channelMx.Lock()
 	defer c.channelMx.Unlock()
 	if ch, ok := c.channels[name]; ok {
 		return ch
 	} else {
-		var ord api.Orderer
-
 		log.Debug(`Channel instance doesn't exist, initiating new`)
-		discChannel, err := c.discoveryProvider.Channel(name)
+		dp := c.discovery()
+		discChannel, err := dp.Channel(name)
 		if err != nil {
 			log.Error(`Failed to get channel declaration in discovery`, zap.Error(err))
-		} else {
-			// if custom orderers are enabled
-			if len(discChannel.Orderers) > 0 {
-				ordConn, err := util.NewGRPCConnectionFromConfigs(c.ctx, c.logger, discChannel.Orderers...)
-				if err != nil {
-					log.Error(`Failed to initialize custom GRPC connection for orderer`, zap.String(`channel`, name), zap.Error(err))
-				}
-				if ord, err = orderer.NewFromGRPC(c.ctx, ordConn); err != nil {
-					log.Error(`Failed to construct orderer from GRPC connection`)
-				}
-			}
 		}
 
-		// using default orderer
-		if ord == nil {
-			ord = c.orderer
+		// orderer set by option without config is used as is
+		ord := c.orderer
+		if links := c.ordererChain(name, discChannel); len(links) > 0 {
+			if chain, err := orderer.NewChain(links, log); err == nil {
+				ord = c.decorateOrderer(chain)
+				c.channelChains[name] = chain
+			}
 		}
 
-		ch = channel.NewCore(c.mspId, name, c.peerPool, ord,
-			c.discoveryProvider, c.identity, c.fabricV2, c.logger)
+		ch = channel.NewCore(c.mspId, name, c.peerPool, ord, dp, providedIdentity{core: c}, c.fabricV2, c.logger,
+			chaincode.WithCryptoSuite(c.cs),
+			chaincode.WithQueryAffinity(c.queryAffinity),
+			chaincode.WithTxIDGenerator(c.txIDGenerator),
+			chaincode.WithErrorDecoder(c.errDecoder),
+			chaincode.WithDefaultInvokeTimeouts(c.invokeTimeouts),
+			chaincode.WithGateway(c.gateway),
+			chaincode.WithTracer(c.tracer))
 		c.channels[name] = ch
 		return ch
 	}
@@ -115,12 +258,441 @@ func (c *core) FabricV2() bool {
 	return c.fabricV2
 }
 
-func NewCore(mspId string, identity api.Identity, opts ...CoreOpt) (api.Core, error) {
-	var err error
+// dialPeer returns peer connected with connection config mapped by options. In replay mode peer isn't connected,
+// as its interactions are served from records
+func (c *core) dialPeer(conf config.ConnectionConfig) (api.Peer, error) {
+	if c.replayer != nil {
+		return recorder.StubPeer(conf.Host), nil
+	}
+	return peer.New(c.connectionConfig(conf), c.logger)
+}
+
+// dialOrderer returns orderer connected with connection config mapped by options. In replay mode orderer isn't connected,
+// as its interactions are served from records
+func (c *core) dialOrderer(conf config.ConnectionConfig) (api.Orderer, error) {
+	if c.replayer != nil {
+		return recorder.StubOrderer(), nil
+	}
+	return orderer.New(c.connectionConfig(conf), c.logger)
+}
+
+// replayedPeerCheck keeps peer ready, as replayed peer isn't connected
+func replayedPeerCheck(ctx context.Context, _ api.Peer, _ chan bool) {
+	<-ctx.Done()
+}
+
+// addPeer adds decorated peer to pool. If probe is enabled, unreachable peer is closed and skipped with warning
+func (c *core) addPeer(mspID string, p api.Peer) error {
+	if c.probeTimeout > 0 && c.replayer == nil {
+		// core context is not set yet if peer is added by option
+		ctx := c.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(ctx, c.probeTimeout)
+		defer cancel()
+
+		if err := peer.Probe(ctx, p); err != nil {
+			c.logger.Warn(`Peer is unreachable, skipping`,
+				zap.String(`mspId`, mspID), zap.String(`uri`, p.Uri()), zap.Error(err))
+			_ = p.Close()
+			return nil
+		}
+	}
+
+	checkStrategy := c.peerCheck
+	if c.replayer != nil {
+		checkStrategy = replayedPeerCheck
+	} else if checkStrategy == nil {
+		// peer state is checked with period of dial timeout set by option
+		checkPeriod := c.dialTimeout
+		if checkPeriod == 0 {
+			checkPeriod = DefaultPeerCheckPeriod
+		}
+		checkStrategy = api.StrategyGRPC(checkPeriod)
+	}
+	return c.peerPool.Add(mspID, c.decoratePeer(mspID, p), checkStrategy)
+}
+
+// connectionConfig returns connection config with client id and timeouts set by options, if config doesn't have own,
+// with TLS client certificate mapped to its host by option and shared deliver if it's enabled by option
+func (c *core) connectionConfig(conf config.ConnectionConfig) config.ConnectionConfig {
+	if c.sharedDeliver {
+		conf.SharedDeliver = true
+	}
+	if c.clientID != `` && conf.GRPC.ClientID == `` {
+		conf.GRPC.ClientID = c.clientID
+	}
+	if c.dialTimeout > 0 && conf.DialTimeout.Duration == 0 {
+		conf.DialTimeout.Duration = c.dialTimeout
+	}
+	if c.callTimeout > 0 && conf.CallTimeout.Duration == 0 {
+		conf.CallTimeout.Duration = c.callTimeout
+	}
+	if c.tlsClientCerts != nil && conf.Tls.Enabled {
+		if certPEM, keyPEM, ok := c.tlsClientCerts(conf.Host); ok {
+			conf.Tls.CertPEM, conf.Tls.KeyPEM = string(certPEM), string(keyPEM)
+		}
+	}
+	if c.tlsServerNames != nil && conf.Tls.Enabled {
+		if serverName, ok := c.tlsServerNames(conf.Host); ok {
+			conf.Tls.HostOverride = serverName
+		}
+	}
+	return conf
+}
+
+// decoratePeer applies block verification, recording or replaying, metrics and circuit breaker to peer if they're configured
+func (c *core) decoratePeer(mspID string, p api.Peer) api.Peer {
+	// blocks aren't delivered in replay mode, so they aren't verified
+	if c.verifyBlocks && c.replayer == nil {
+		p = peer.WithBlockVerification(p, c.blockVerifier)
+	}
+	if c.recorder != nil {
+		p = c.recorder.Peer(p)
+	} else if c.replayer != nil {
+		p = c.replayer.Peer(p)
+	}
+	if c.metrics != nil {
+		p = c.metrics.Peer(mspID, p)
+	}
+	if c.breakerConfig != nil {
+		p = peer.WithCircuitBreaker(p, *c.breakerConfig)
+	}
+	return p
+}
+
+// blockVerifier returns verifier of blocks using channel config in effect at start position fetched from orderer.
+// Config blocks are cached by channel, so they are fetched once for all subscriptions
+func (c *core) blockVerifier(ctx context.Context, channelName string, start *ordererProto.SeekPosition) (*util.BlockVerifier, error) {
+	if c.orderer == nil {
+		return nil, errors.New(`orderer is not presented`)
+	}
+
+	var (
+		index uint64
+		err   error
+	)
+	switch pos := start.GetType().(type) {
+	case *ordererProto.SeekPosition_Oldest:
+		// genesis block is in effect for oldest block
+	case *ordererProto.SeekPosition_Specified:
+		index, err = util.GetConfigIndexAtHeightFromOrderer(ctx, c.signingIdentity(), c.orderer, channelName, pos.Specified.GetNumber())
+	default:
+		index, err = util.GetLastConfigIndexFromOrderer(ctx, c.signingIdentity(), c.orderer, channelName)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get config block number`)
+	}
+
+	configBlock, err := c.configBlock(ctx, channelName, index)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get config block`)
+	}
+
+	return util.NewBlockVerifier(channelName, configBlock)
+}
+
+// configBlock returns cached config block of channel by number or fetches it from orderer
+func (c *core) configBlock(ctx context.Context, channelName string, number uint64) (*common.Block, error) {
+	c.configBlocksMx.Lock()
+	block, ok := c.configBlocks[channelName][number]
+	c.configBlocksMx.Unlock()
+	if ok {
+		return block, nil
+	}
+
+	block, err := util.GetBlockFromOrderer(ctx, c.signingIdentity(), c.orderer, channelName, number)
+	if err != nil {
+		return nil, err
+	}
+
+	c.configBlocksMx.Lock()
+	defer c.configBlocksMx.Unlock()
+	if c.configBlocks[channelName] == nil {
+		c.configBlocks[channelName] = make(map[uint64]*common.Block)
+	}
+	c.configBlocks[channelName][number] = block
+	return block, nil
+}
+
+// breakOrderer wraps orderer of endpoint with circuit breaker if it's enabled by option. Breaker is kept by address,
+// so its state survives orderer of the same endpoint dialed again, e.g. by orderer chain
+func (c *core) breakOrderer(address string, ord api.Orderer) api.Orderer {
+	if c.breakerConfig == nil {
+		return ord
+	}
+
+	c.ordererBreakersMx.Lock()
+	defer c.ordererBreakersMx.Unlock()
+
+	b, ok := c.ordererBreakers[address]
+	if !ok {
+		b = breaker.New(*c.breakerConfig)
+		if c.ordererBreakers == nil {
+			c.ordererBreakers = make(map[string]*breaker.Breaker)
+		}
+		c.ordererBreakers[address] = b
+		if c.metrics != nil {
+			c.metrics.WatchOrdererBreaker(address, b)
+		}
+	}
+	return orderer.WithBreaker(ord, b)
+}
+
+// decorateOrderer applies recording or replaying, metrics, broadcast retries, orderer override from context
+// and pre broadcast hooks to orderer. Circuit breakers are applied to each orderer endpoint by breakOrderer
+func (c *core) decorateOrderer(ord api.Orderer) api.Orderer {
+	if c.recorder != nil {
+		ord = c.recorder.Orderer(ord)
+	} else if c.replayer != nil {
+		ord = c.replayer.Orderer(ord)
+	}
+	if c.metrics != nil {
+		ord = c.metrics.Orderer(ord)
+	}
+	if c.ordererRetry != nil {
+		retry := *c.ordererRetry
+		if retry.OnRecover == nil {
+			retry.OnRecover = func(attempts uint, lastErr error) {
+				c.logger.Info(`Broadcast recovered after ordering service unavailability`,
+					zap.Uint(`attempts`, attempts), zap.Error(lastErr))
+			}
+		}
+		ord = orderer.WithBroadcastRetry(ord, retry)
+	}
+	ord = orderer.WithContextOverride(ord, c.dialContextOrderer)
+	if c.auditor != nil {
+		ord = c.auditor.Orderer(ord)
+	}
+	return orderer.WithPreBroadcastHooks(ord, c.preBroadcastHooks...)
+}
+
+// dialContextOrderer returns orderer connected to endpoint set by orderer.WithOrderer,
+// connection settings except host are taken from default orderer config
+func (c *core) dialContextOrderer(_ context.Context, endpoint string) (api.Orderer, error) {
+	c.contextOrderersMx.Lock()
+	defer c.contextOrderersMx.Unlock()
+
+	if atomic.LoadUint32(&c.closed) == 1 {
+		return nil, api.ErrClientClosed
+	}
+	if ord, ok := c.contextOrderers[endpoint]; ok {
+		return ord, nil
+	}
+
+	if c.ordererTemplate == nil {
+		return nil, errors.New(`default orderer connection config is not presented`)
+	}
+
+	connConfig := *c.ordererTemplate
+	connConfig.Host = endpoint
+	ord, err := c.dialOrderer(c.connectionConfig(connConfig))
+	if err != nil {
+		return nil, err
+	}
+	ord = c.breakOrderer(endpoint, ord)
+
+	if c.contextOrderers == nil {
+		c.contextOrderers = make(map[string]api.Orderer)
+	}
+	c.contextOrderers[endpoint] = ord
+	return ord, nil
+}
+
+// ordererChain returns orderer sources of channel in order of configured precedence, sources without
+// known endpoints are skipped. Connection settings except host of channel config orderers are taken
+// from discovery orderers or default orderer config
+func (c *core) ordererChain(channelName string, discChannel *api.DiscoveryChannel) []orderer.ChainLink {
+	var discOrderers []config.ConnectionConfig
+	if discChannel != nil {
+		discOrderers = discChannel.Orderers
+	}
+
+	template := c.ordererTemplate
+	if len(discOrderers) > 0 {
+		template = &discOrderers[0]
+	}
+
+	var links []orderer.ChainLink
+	for _, source := range c.ordererSources {
+		switch source {
+		case api.OrdererSourceDiscovery:
+			if len(discOrderers) > 0 {
+				links = append(links, orderer.ChainLink{Source: string(source), Dial: func(context.Context) (api.Orderer, error) {
+					c.setChannelOrderers(channelName, discOrderers)
+					return c.newOrderer(discOrderers)
+				}})
+			}
+
+		case api.OrdererSourceConfig:
+			if c.baseOrderer != nil {
+				links = append(links, orderer.ChainLink{Source: string(source), Shared: true, Dial: func(context.Context) (api.Orderer, error) {
+					return c.baseOrderer, nil
+				}})
+			}
+
+		case api.OrdererSourceChannelConfig:
+			if template != nil {
+				connTemplate := *template
+				links = append(links, orderer.ChainLink{Source: string(source), Dial: func(ctx context.Context) (api.Orderer, error) {
+					return c.channelConfigOrderer(ctx, channelName, connTemplate)
+				}})
+			}
+		}
+	}
+
+	return links
+}
+
+// channelConfigOrderer returns orderer connected to orderer endpoints from actual channel config fetched from peer.
+// Connection settings except host are taken from template
+func (c *core) channelConfigOrderer(ctx context.Context, channelName string, template config.ConnectionConfig) (api.Orderer, error) {
+	configBlock, err := c.System().CSCC().GetConfigBlock(ctx, channelName)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get config block`)
+	}
+
+	conf, err := util.GetConfigFromBlock(configBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get channel config`)
+	}
+
+	addresses, err := util.GetOrdererAddressesFromChannelConfig(conf)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get orderer addresses`)
+	}
+
+	c.logger.Info(`Using orderer endpoints from channel config`,
+		zap.String(`channel`, channelName), zap.Strings(`addresses`, addresses))
+
+	connConfigs := make([]config.ConnectionConfig, 0, len(addresses))
+	for _, address := range addresses {
+		connConfig := template
+		connConfig.Host = address
+		connConfigs = append(connConfigs, connConfig)
+	}
+
+	c.setChannelOrderers(channelName, connConfigs)
+	return c.newOrderer(connConfigs)
+}
+
+// setChannelOrderers keeps orderers dialed for channel with connection configs mapped by options
+func (c *core) setChannelOrderers(channelName string, configs []config.ConnectionConfig) {
+	mapped := make([]config.ConnectionConfig, len(configs))
+	for i, conf := range configs {
+		mapped[i] = c.connectionConfig(conf)
+	}
+
+	c.channelOrderersMx.Lock()
+	defer c.channelOrderersMx.Unlock()
+	c.channelOrderers[channelName] = mapped
+}
+
+// newOrderer returns orderer connected to presented endpoints with connection configs mapped by options.
+// If failover is enabled or TLS settings are mapped per address, each endpoint has own connection, requests are sent
+// to endpoints in turn and failed requests are repeated on next endpoint if failover is enabled.
+// Otherwise one connection is balanced between endpoints
+func (c *core) newOrderer(configs []config.ConnectionConfig) (api.Orderer, error) {
+	if c.replayer != nil {
+		return recorder.StubOrderer(), nil
+	}
+	if len(configs) == 1 {
+		ord, err := c.dialOrderer(configs[0])
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to initialize orderer connection`)
+		}
+		return c.breakOrderer(configs[0].Host, ord), nil
+	}
+
+	mapped := make([]config.ConnectionConfig, len(configs))
+	for i, conf := range configs {
+		mapped[i] = c.connectionConfig(conf)
+	}
+
+	if c.ordererFailover == nil && c.tlsClientCerts == nil && c.tlsServerNames == nil {
+		ord, err := orderer.NewBalanced(mapped, c.logger)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to initialize orderer connection`)
+		}
+		// connection balanced between endpoints has single breaker
+		hosts := make([]string, 0, len(mapped))
+		for _, conf := range mapped {
+			hosts = append(hosts, conf.Host)
+		}
+		return c.breakOrderer(strings.Join(hosts, `,`), ord), nil
+	}
+
+	orderers := make([]api.Orderer, 0, len(mapped))
+	for _, conf := range mapped {
+		ord, err := c.dialOrderer(conf)
+		if err != nil {
+			for _, dialed := range orderers {
+				_ = dialed.Close()
+			}
+			return nil, errors.Wrapf(err, `failed to initialize orderer %s`, conf.Host)
+		}
+		orderers = append(orderers, c.breakOrderer(conf.Host, ord))
+	}
+
+	attempts, backoff := uint(1), time.Duration(0)
+	if c.ordererFailover != nil {
+		attempts, backoff = c.ordererFailover.Attempts, c.ordererFailover.Backoff
+	}
+	return orderer.NewMulti(orderers, attempts, backoff)
+}
+
+// signEnvelope signs envelope created by core identity using envelope crypto suite
+func (c *core) signEnvelope(envelope *common.Envelope) (*common.Envelope, error) {
+	c.identityMx.RLock()
+	identity, signer := c.identity, c.envelopeSigner
+	c.identityMx.RUnlock()
+	// without core identity envelopes are created by per-call identities, which are left as is
+	if identity == nil {
+		return envelope, nil
+	}
+
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal payload`)
+	}
+	if payload.Header == nil {
+		return envelope, nil
+	}
+
+	sigHeader, err := protoutil.UnmarshalSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal signature header`)
+	}
+
+	creator, err := identity.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to serialize identity`)
+	}
+
+	// envelopes of other identities are left as is
+	if !bytes.Equal(sigHeader.Creator, creator) {
+		return envelope, nil
+	}
+
+	signature, err := signer.Sign(envelope.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to sign envelope`)
+	}
+
+	return &common.Envelope{Payload: envelope.Payload, Signature: signature}, nil
+}
+
+func NewCore(mspId string, identity api.Identity, opts ...CoreOpt) (_ api.Core, err error) {
 	core := &core{
-		mspId:      mspId,
-		channels:   make(map[string]api.Channel),
-		chaincodes: make(map[string]api.ChaincodePackage),
+		mspId:           mspId,
+		channels:        make(map[string]api.Channel),
+		channelChains:   make(map[string]api.Orderer),
+		channelOrderers: make(map[string][]config.ConnectionConfig),
+		chaincodes:      make(map[string]*chaincodeEntry),
+		configBlocks:    make(map[string]map[uint64]*common.Block),
+		ordererSources: []api.OrdererSource{
+			api.OrdererSourceDiscovery, api.OrdererSourceConfig, api.OrdererSourceChannelConfig},
 	}
 
 	for _, option := range opts {
@@ -129,9 +701,32 @@ func NewCore(mspId string, identity api.Identity, opts ...CoreOpt) (api.Core, er
 		}
 	}
 
+	// broadcast retries wrap orderer failover, so attempts of both policies would multiply
+	if core.ordererRetry != nil && core.ordererFailover != nil {
+		return nil, errors.New(`orderer broadcast retry can't be combined with orderer retry`)
+	}
+
 	if core.ctx == nil {
 		core.ctx = context.Background()
 	}
+	// background refreshes are stopped by Close
+	core.ctx, core.cancel = context.WithCancel(core.ctx)
+
+	// pool and orderer dialed by core are released if core initialization fails,
+	// pool and orderer set by options are left to caller
+	var ownPool, ownOrderer bool
+	defer func() {
+		if err == nil {
+			return
+		}
+		core.cancel()
+		if ownPool {
+			_ = core.peerPool.Close()
+		}
+		if ownOrderer && core.baseOrderer != nil {
+			_ = core.baseOrderer.Close()
+		}
+	}()
 
 	if core.logger == nil {
 		core.logger = logger.DefaultLogger
@@ -151,7 +746,30 @@ func NewCore(mspId string, identity api.Identity, opts ...CoreOpt) (api.Core, er
 		}
 	}
 
-	core.identity = identity.GetSigningIdentity(core.cs)
+	if core.envelopeCS == nil && core.config != nil && core.config.EnvelopeCrypto != nil {
+		if core.envelopeCS, err = crypto.GetSuite(core.config.EnvelopeCrypto.Type, core.config.EnvelopeCrypto.Options); err != nil {
+			return nil, errors.Wrap(err, `failed to initialize envelope crypto suite`)
+		}
+	}
+
+	if identity == nil && core.identityProvider != nil {
+		if identity, err = core.identityProvider(core.ctx); err != nil {
+			return nil, errors.Wrap(err, `failed to get identity from provider`)
+		}
+	}
+	// without default identity operations must be made with per-call identity,
+	// operations which need default identity fail with api.ErrNoIdentity
+	if identity != nil {
+		core.identity = identity.GetSigningIdentity(core.cs)
+	}
+
+	if core.envelopeCS != nil {
+		if identity != nil {
+			core.envelopeSigner = identity.GetSigningIdentity(core.envelopeCS)
+		}
+		// envelope is signed after all other hooks, which can replace it
+		core.preBroadcastHooks = append(core.preBroadcastHooks, core.signEnvelope)
+	}
 
 	// if peerPool is empty, set it from config
 	if core.peerPool == nil {
@@ -160,13 +778,21 @@ func NewCore(mspId string, identity api.Identity, opts ...CoreOpt) (api.Core, er
 		if core.config == nil {
 			return nil, api.ErrEmptyConfig
 		}
-		core.peerPool = pool.New(core.ctx, core.logger, core.config.Pool)
+		var poolOpts []pool.Opt
+		if core.poolMembership != nil {
+			poolOpts = append(poolOpts, pool.WithMembershipHandler(core.poolMembership))
+		}
+		if core.peerSelection != nil {
+			poolOpts = append(poolOpts, pool.WithStrategy(core.peerSelection))
+		}
+		core.peerPool = pool.New(core.ctx, core.logger, core.config.Pool, poolOpts...)
+		ownPool = true
 		for _, mspConfig := range core.config.MSP {
 			for _, peerConfig := range mspConfig.Endorsers {
-				if p, err := peer.New(peerConfig, core.logger); err != nil {
+				if p, err := core.dialPeer(peerConfig); err != nil {
 					return nil, errors.Errorf("failed to initialize endorsers for MSP: %s:%s", mspConfig.Name, err.Error())
 				} else {
-					if err = core.peerPool.Add(mspConfig.Name, p, api.StrategyGRPC(5*time.Second)); err != nil {
+					if err = core.addPeer(mspConfig.Name, p); err != nil {
 						return nil, errors.Wrap(err, `failed to add peer to pool`)
 					}
 				}
@@ -174,6 +800,10 @@ func NewCore(mspId string, identity api.Identity, opts ...CoreOpt) (api.Core, er
 		}
 	}
 
+	if core.metrics != nil {
+		core.metrics.WatchPool(core.peerPool)
+	}
+
 	if core.discoveryProvider == nil && core.config != nil {
 		core.logger.Info("initializing discovery provider")
 
@@ -182,24 +812,59 @@ func NewCore(mspId string, identity api.Identity, opts ...CoreOpt) (api.Core, er
 		} else if core.discoveryProvider, err = dp.Initialize(core.config.Discovery.Options, core.peerPool); err != nil {
 			return nil, errors.Wrap(err, `failed to initialize discovery provider`)
 		}
+		core.discoveryType = core.config.Discovery.Type
 	}
 
-	if core.orderer == nil && core.config != nil {
+	var discoveryPlan *discovery.CachedProvider
+	if core.discoveryProvider != nil && core.discoveryPlanPath != `` {
+		cached, err := discovery.NewCachedProvider(core.discoveryProvider, core.peerPool, core.discoveryPlanPath)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to initialize discovery plan cache`)
+		}
+		discoveryPlan = cached
+		core.discoveryProvider = cached
+	}
+
+	if core.discoveryProvider != nil && core.discoveryCacheTTL > 0 {
+		core.discoveryProvider = discovery.NewTTLProvider(core.discoveryProvider, core.discoveryCacheTTL)
+	}
+
+	if core.orderer != nil {
+		// orderer set by option is single endpoint for core
+		core.orderer = core.breakOrderer(``, core.orderer)
+	} else if core.config != nil {
 		core.logger.Info("initializing orderer")
+		ownOrderer = true
 		if len(core.config.Orderers) > 0 {
-			ordConn, err := util.NewGRPCConnectionFromConfigs(core.ctx, core.logger, core.config.Orderers...)
-			if err != nil {
-				return nil, errors.Wrap(err, `failed to initialize orderer connection`)
+			ordererConfigs := make([]config.ConnectionConfig, len(core.config.Orderers))
+			for i, ordererConfig := range core.config.Orderers {
+				ordererConfigs[i] = core.connectionConfig(ordererConfig)
 			}
-			core.orderer, err = orderer.NewFromGRPC(core.ctx, ordConn)
+			core.orderer, err = core.newOrderer(ordererConfigs)
 			if err != nil {
 				return nil, errors.Wrap(err, `failed to initialize orderer`)
 			}
+			core.ordererTemplate = &ordererConfigs[0]
 		} else if core.config.Orderer != nil {
-			core.orderer, err = orderer.New(*core.config.Orderer, core.logger)
+			ordererConfig := core.connectionConfig(*core.config.Orderer)
+			core.orderer, err = core.dialOrderer(ordererConfig)
 			if err != nil {
 				return nil, errors.Wrap(err, `failed to initialize orderer`)
 			}
+			core.orderer = core.breakOrderer(ordererConfig.Host, core.orderer)
+			core.ordererTemplate = &ordererConfig
+		}
+	}
+
+	if core.orderer != nil {
+		core.baseOrderer = core.orderer
+		core.orderer = core.decorateOrderer(core.orderer)
+	}
+
+	if core.gatewayConfig != nil {
+		core.logger.Info("initializing gateway")
+		if core.gateway, err = gateway.New(core.connectionConfig(*core.gatewayConfig), core.logger); err != nil {
+			return nil, errors.Wrap(err, `failed to initialize gateway`)
 		}
 	}
 
@@ -208,5 +873,15 @@ func NewCore(mspId string, identity api.Identity, opts ...CoreOpt) (api.Core, er
 		core.fetcher = fetcher.NewLocal(&golang.Platform{})
 	}
 
+	// background work is started after all initialization steps which can fail
+	if discoveryPlan != nil {
+		var planCtx context.Context
+		planCtx, core.discoveryPlanCancel = context.WithCancel(core.ctx)
+		go discoveryPlan.Run(planCtx, core.discoveryPlanRefresh, core.logger)
+	}
+	if core.discoveryRefresh > 0 {
+		go core.runMembershipRefresh(core.ctx, core.discoveryRefresh)
+	}
+
 	return core, nil
 }
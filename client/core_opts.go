@@ -3,18 +3,27 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v2"
 
 	"github.com/s7techlab/hlf-sdk-go/api"
 	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/audit"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode"
 	"github.com/s7techlab/hlf-sdk-go/crypto"
 	"github.com/s7techlab/hlf-sdk-go/discovery"
-	"github.com/s7techlab/hlf-sdk-go/peer"
+	"github.com/s7techlab/hlf-sdk-go/metrics"
+	"github.com/s7techlab/hlf-sdk-go/orderer"
+	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+	"github.com/s7techlab/hlf-sdk-go/recorder"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
 )
 
 // CoreOpt describes opt which will be applied to coreOptions
@@ -81,12 +90,11 @@ func WithPeerPool(pool api.PeerPool) CoreOpt {
 func WithPeers(mspID string, peers []config.ConnectionConfig) CoreOpt {
 	return func(c *core) error {
 		for _, p := range peers {
-			pp, err := peer.New(p, c.logger)
+			pp, err := c.dialPeer(p)
 			if err != nil {
 				return fmt.Errorf("create peer: %w", err)
 			}
-			err = c.peerPool.Add(mspID, pp, api.StrategyGRPC(5*time.Second))
-			if err != nil {
+			if err = c.addPeer(mspID, pp); err != nil {
 				return fmt.Errorf("add peer to pool: %w", err)
 			}
 		}
@@ -94,6 +102,198 @@ func WithPeers(mspID string, peers []config.ConnectionConfig) CoreOpt {
 	}
 }
 
+// WithClientID sets client application name and version sent in user agent and x-client-id header
+// of all calls to peers and orderers which connections are created by core.
+// Option must be passed before WithPeers to be applied to its peers
+func WithClientID(name, version string) CoreOpt {
+	return func(c *core) error {
+		c.clientID = name + `/` + version
+		return nil
+	}
+}
+
+// WithConnectionTimeouts sets dial and call timeouts of peers and orderers which connections are created by core,
+// if connection config doesn't have own. Zero timeout is not applied. Option must be passed before WithPeers
+// to be applied to its peers
+func WithConnectionTimeouts(dial, call time.Duration) CoreOpt {
+	return func(c *core) error {
+		if dial < 0 || call < 0 {
+			return errors.New(`connection timeouts must not be negative`)
+		}
+		c.dialTimeout, c.callTimeout = dial, call
+		return nil
+	}
+}
+
+// WithSharedDeliver makes block subscriptions from newest block of each peer which connection is created by core
+// share one deliver stream per channel and identity. Option must be passed before WithPeers to be applied to its peers
+func WithSharedDeliver() CoreOpt {
+	return func(c *core) error {
+		c.sharedDeliver = true
+		return nil
+	}
+}
+
+// TLSClientCertMapper returns client certificate and key in PEM format presented for mutual TLS to address,
+// ok is false if address has no own certificate and one from connection config is used
+type TLSClientCertMapper func(address string) (certPEM, keyPEM []byte, ok bool)
+
+// WithTLSClientCertMapper sets client certificates for mutual TLS per address of peers and orderers
+// which connections are created by core with TLS enabled. Option must be passed before WithPeers
+// to be applied to its peers
+func WithTLSClientCertMapper(mapper TLSClientCertMapper) CoreOpt {
+	return func(c *core) error {
+		c.tlsClientCerts = mapper
+		return nil
+	}
+}
+
+// TLSServerNameMapper returns name used to verify TLS server certificate of address,
+// ok is false if address has no own name and host override from connection config is used
+type TLSServerNameMapper func(address string) (serverName string, ok bool)
+
+// WithTLSServerNameMapper sets TLS server names per address of peers and orderers
+// which connections are created by core with TLS enabled. Option must be passed before WithPeers
+// to be applied to its peers
+func WithTLSServerNameMapper(mapper TLSServerNameMapper) CoreOpt {
+	return func(c *core) error {
+		c.tlsServerNames = mapper
+		return nil
+	}
+}
+
+// WithTxIDGenerator replaces Fabric transaction id scheme for chaincode invokes and queries.
+// Standard Fabric peers reject transactions with other ids, so use it only for custom networks or tests
+func WithTxIDGenerator(generator api.TxIDGenerator) CoreOpt {
+	return func(c *core) error {
+		c.txIDGenerator = generator
+		return nil
+	}
+}
+
+// WithWarmUpChannels sets channels which discovery is fetched by WarmUp
+func WithWarmUpChannels(channels ...string) CoreOpt {
+	return func(c *core) error {
+		c.warmUpChannels = channels
+		return nil
+	}
+}
+
+// WithDiscoveryPlanCache persists discovered channels, chaincodes and organizations satisfying their policies to file
+// and serves them from it after restart, so invokes with policy endorsers skip endorsers selection.
+// Plan is refreshed in background with presented interval and is dropped if peer pool membership changes
+func WithDiscoveryPlanCache(path string, refresh time.Duration) CoreOpt {
+	return func(c *core) error {
+		if refresh <= 0 {
+			return errors.New(`discovery plan refresh interval must be positive`)
+		}
+		c.discoveryPlanPath = path
+		c.discoveryPlanRefresh = refresh
+		return nil
+	}
+}
+
+// WithDiscoveryCache caches results of discovery provider for ttl, so discovery service is not requested
+// for every channel and chaincode instantiation
+func WithDiscoveryCache(ttl time.Duration) CoreOpt {
+	return func(c *core) error {
+		if ttl <= 0 {
+			return errors.New(`discovery cache ttl must be positive`)
+		}
+		c.discoveryCacheTTL = ttl
+		return nil
+	}
+}
+
+// WithDiscoveryRefresh periodically reconciles peer pool with channel members known by gossip of pool peers:
+// new peers are added with connection settings of configured endorsers of organization and departed ones are removed
+// after endorsements in progress complete. Only peers added by refresh are removed, configured peers are kept
+func WithDiscoveryRefresh(interval time.Duration) CoreOpt {
+	return func(c *core) error {
+		if interval <= 0 {
+			return errors.New(`discovery refresh interval must be positive`)
+		}
+		c.discoveryRefresh = interval
+		return nil
+	}
+}
+
+// WithPoolHealthCheck enables health check of pool peers, which dials peer connection with presented interval
+// (10s by default). Peer is skipped by endorsements after failureThreshold consecutive failed checks
+// and is used again after successful check. Option must be passed before WithPeers
+func WithPoolHealthCheck(interval time.Duration, failureThreshold int) CoreOpt {
+	return func(c *core) error {
+		c.peerCheck = pool.StrategyHealthCheck(interval, failureThreshold)
+		return nil
+	}
+}
+
+// WithIdentityProvider sets provider used to obtain identity if identity passed to NewCore is nil and to refresh it
+// when its certificate expires within IdentityRefreshMargin. Identity is refreshed when channel, chaincode
+// or system chaincodes are requested from core, so instances obtained earlier keep previous identity
+func WithIdentityProvider(provider api.IdentityProvider) CoreOpt {
+	return func(c *core) error {
+		c.identityProvider = provider
+		return nil
+	}
+}
+
+// WithGateway routes chaincode invokes and queries through Gateway service of presented peer (Fabric 2.4+),
+// which collects endorsements and submits transactions on server side. Peer pool is still used by system chaincodes,
+// block subscriptions and commit block lookup
+func WithGateway(peerEndpoint config.ConnectionConfig) CoreOpt {
+	return func(c *core) error {
+		c.gatewayConfig = &peerEndpoint
+		return nil
+	}
+}
+
+// WithPoolMembershipHandler sets handler notified when peers are added to or removed from peer pool created by core.
+// Custom pool passed by WithPeerPool should be created with pool.WithMembershipHandler instead
+func WithPoolMembershipHandler(handler api.PoolMembershipHandler) CoreOpt {
+	return func(c *core) error {
+		c.poolMembership = handler
+		return nil
+	}
+}
+
+// WithPeerSelection sets strategy of peer selection for peer pool created by core.
+// Custom pool passed by WithPeerPool should be created with pool.WithStrategy instead
+func WithPeerSelection(strategy pool.Strategy) CoreOpt {
+	return func(c *core) error {
+		c.peerSelection = strategy
+		return nil
+	}
+}
+
+// WithResponseErrorDecoder sets decoder of chaincode responses with error status into application errors,
+// which are returned by chaincode invokes and queries instead of api.PeerEndorseError
+func WithResponseErrorDecoder(decoder api.ResponseErrorDecoder) CoreOpt {
+	return func(c *core) error {
+		c.errDecoder = decoder
+		return nil
+	}
+}
+
+// WithProbeBeforeAdd makes core wait up to timeout for peer connection before adding peer to pool.
+// Unreachable peers are not added. Option must be passed before WithPeers to be applied to its peers
+func WithProbeBeforeAdd(timeout time.Duration) CoreOpt {
+	return func(c *core) error {
+		c.probeTimeout = timeout
+		return nil
+	}
+}
+
+// WithVerifyBlockSignatures makes deliver subscriptions of peers verify orderer signatures of delivered blocks
+// against block validation policy of channel config fetched from orderer.
+// Option must be passed before WithPeers to be applied to its peers
+func WithVerifyBlockSignatures() CoreOpt {
+	return func(c *core) error {
+		c.verifyBlocks = true
+		return nil
+	}
+}
+
 // WithCrypto allows to init core crypto suite.
 func WithCrypto(cc config.CryptoConfig) CoreOpt {
 	return func(c *core) error {
@@ -106,6 +306,19 @@ func WithCrypto(cc config.CryptoConfig) CoreOpt {
 	}
 }
 
+// WithEnvelopeCrypto allows to sign envelopes sent to orderer with crypto suite other than core one.
+// Only envelopes created by core identity are signed with it
+func WithEnvelopeCrypto(cc config.CryptoConfig) CoreOpt {
+	return func(c *core) error {
+		var err error
+		c.envelopeCS, err = crypto.GetSuite(cc.Type, cc.Options)
+		if err != nil {
+			return fmt.Errorf("get envelope crypto suite: %w", err)
+		}
+		return nil
+	}
+}
+
 // WithDiscovery allows to init core with discovery provider.
 func WithDiscovery(dc config.DiscoveryConfig) CoreOpt {
 	return func(c *core) error {
@@ -128,3 +341,140 @@ func WithFabricV2(fabricV2 bool) CoreOpt {
 		return nil
 	}
 }
+
+// WithPreBroadcastHook allows to inspect or replace envelope before broadcasting it to orderer.
+// Hooks are applied in order of options, error returned from hook aborts submission
+func WithPreBroadcastHook(hook api.PreBroadcastHook) CoreOpt {
+	return func(c *core) error {
+		c.preBroadcastHooks = append(c.preBroadcastHooks, hook)
+		return nil
+	}
+}
+
+// WithCircuitBreaker enables circuit breaker for each peer and orderer endpoint, requests to orderers skip endpoints
+// with open circuit. Breaker states are reported by peer pool Status and metrics.
+// Option must be passed before WithPeers to be applied to its peers
+func WithCircuitBreaker(config breaker.Config) CoreOpt {
+	return func(c *core) error {
+		c.breakerConfig = &config
+		return nil
+	}
+}
+
+// WithRecord captures peer endorsements, tx validation results and orderer interactions to writer.
+// Option must be passed before WithPeers to be applied to its peers
+func WithRecord(w io.Writer) CoreOpt {
+	return func(c *core) error {
+		c.recorder = recorder.NewRecorder(w)
+		return nil
+	}
+}
+
+// WithMetrics registers Prometheus metrics of peer endorsements, orderer broadcasts and ready pool peers with registerer.
+// Option must be passed before WithPeers to be applied to its peers
+func WithMetrics(registerer prometheus.Registerer) CoreOpt {
+	return func(c *core) error {
+		m, err := metrics.New(registerer)
+		if err != nil {
+			return errors.Wrap(err, `failed to register metrics`)
+		}
+		c.metrics = m
+		return nil
+	}
+}
+
+// WithTracerProvider traces chaincode invocations and queries with tracer of provider,
+// spans are not recorded by default
+func WithTracerProvider(tp trace.TracerProvider) CoreOpt {
+	return func(c *core) error {
+		c.tracer = tp.Tracer(chaincode.TracerName)
+		return nil
+	}
+}
+
+// WithAuditSink passes each envelope broadcast to orderer with its result to sink asynchronously.
+// Up to bufferSize records wait for sink, records exceeding buffer are dropped with warning
+func WithAuditSink(sink api.AuditSink, bufferSize int) CoreOpt {
+	return func(c *core) error {
+		c.auditor = audit.NewAuditor(sink, bufferSize, func(err error) {
+			c.logger.Warn(`Failed to audit broadcast`, zap.Error(err))
+		})
+		return nil
+	}
+}
+
+// WithReplay serves peer endorsements, tx validation results and orderer interactions from records
+// captured with WithRecord instead of network. Peers and orderers created by core aren't dialed and pool peers
+// are kept ready. Option must be passed before WithPeers to be applied to its peers
+func WithReplay(r io.Reader) CoreOpt {
+	return func(c *core) error {
+		var err error
+		if c.replayer, err = recorder.NewReplayer(r); err != nil {
+			return fmt.Errorf("read records: %w", err)
+		}
+		return nil
+	}
+}
+
+// WithQueryAffinity routes chaincode queries to peers of presented MSP.
+// If strict is true, queries fail when MSP peers are not available,
+// otherwise queries fall back to peers of querying identity MSP
+func WithQueryAffinity(mspID string, strict bool) CoreOpt {
+	return func(c *core) error {
+		c.queryAffinity = &api.QueryAffinity{MspID: mspID, Strict: strict}
+		return nil
+	}
+}
+
+// WithOrdererBroadcastRetry enables retries of broadcast while ordering service is temporary unavailable
+// (e.g. raft leader is changing). Backoff is doubled after each attempt.
+// Each attempt on channel orderer falls back between orderer sources. Can't be combined with WithOrdererRetry
+func WithOrdererBroadcastRetry(attempts uint, backoff time.Duration) CoreOpt {
+	return func(c *core) error {
+		c.ordererRetry = &orderer.RetryConfig{Attempts: attempts, Backoff: backoff}
+		return nil
+	}
+}
+
+// WithOrdererRetry enables failover between orderers of config: each orderer has own connection and request failed
+// with connection error or SERVICE_UNAVAILABLE status is repeated on next orderer up to maxAttempts attempts.
+// Channel orderer makes attempts on orderers of each source before falling back to next source.
+// Can't be combined with WithOrdererBroadcastRetry and WithOrdererBroadcastRetryConfig
+func WithOrdererRetry(maxAttempts uint, backoff time.Duration) CoreOpt {
+	return func(c *core) error {
+		c.ordererFailover = &orderer.RetryConfig{Attempts: maxAttempts, Backoff: backoff}
+		return nil
+	}
+}
+
+// WithInvokeTimeouts sets default limits of endorsement and commit stages of chaincode invokes.
+// Deadlines set in invoke context take precedence over invoke options, which take precedence over these defaults
+func WithInvokeTimeouts(timeouts api.InvokeTimeouts) CoreOpt {
+	return func(c *core) error {
+		c.invokeTimeouts = timeouts
+		return nil
+	}
+}
+
+// WithOrdererSources sets order in which sources of channel orderer endpoints are tried,
+// next source is used if orderers of previous are unreachable.
+// Default order is discovery, config, channel config
+func WithOrdererSources(sources ...api.OrdererSource) CoreOpt {
+	return func(c *core) error {
+		if len(sources) == 0 {
+			return errors.New(`orderer sources are empty`)
+		}
+		c.ordererSources = sources
+		return nil
+	}
+}
+
+// WithOrdererBroadcastRetryConfig enables retries of broadcast with presented config,
+// recoveries after failed attempts are logged if config doesn't have own callback.
+// It replaces retries set by WithOrdererBroadcastRetry and can't be combined with WithOrdererRetry
+func WithOrdererBroadcastRetryConfig(retry orderer.RetryConfig) CoreOpt {
+	return func(c *core) error {
+		c.ordererRetry = &retry
+		return nil
+	}
+}
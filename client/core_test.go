@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode/system"
+)
+
+func TestCoreChaincodeShared(t *testing.T) {
+	c := &core{chaincodes: make(map[string]*chaincodeEntry)}
+
+	var wg sync.WaitGroup
+	packages := make([]interface{}, 10)
+	for i := range packages {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			packages[i] = c.Chaincode(`cc`)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, cc := range packages {
+		require.True(t, cc == packages[0])
+	}
+}
+
+// BenchmarkCoreChaincode compares creation of chaincode packages under cache lock, as it was done before,
+// with creation outside of it. Each operation creates package of new chaincode
+func BenchmarkCoreChaincode(b *testing.B) {
+	b.Run(`creation under cache lock`, func(b *testing.B) {
+		var (
+			c          = &core{}
+			chaincodes = make(map[string]api.ChaincodePackage)
+			mx         sync.Mutex
+			n          uint64
+		)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				name := fmt.Sprintf(`cc%d`, atomic.AddUint64(&n, 1))
+				mx.Lock()
+				if _, ok := chaincodes[name]; !ok {
+					identity := c.signingIdentity()
+					chaincodes[name] = chaincode.NewCorePackage(name, system.NewLSCC(c.peerPool, identity), c.fetcher, c.orderer, identity)
+				}
+				mx.Unlock()
+			}
+		})
+	})
+
+	b.Run(`creation outside of cache lock`, func(b *testing.B) {
+		var (
+			c = &core{chaincodes: make(map[string]*chaincodeEntry)}
+			n uint64
+		)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				c.Chaincode(fmt.Sprintf(`cc%d`, atomic.AddUint64(&n, 1)))
+			}
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package fetcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+var (
+	ErrUnknownPlatform   = api.Error(`unable to detect chaincode platform`)
+	ErrAmbiguousPlatform = api.Error(`ambiguous chaincode platform`)
+)
+
+// platformMarkers contains files which presence in chaincode source directory defines platform
+var platformMarkers = []struct {
+	platform platforms.Platform
+	files    []string
+}{
+	{platform: &golang.Platform{}, files: []string{`go.mod`}},
+	{platform: &java.Platform{}, files: []string{`pom.xml`, `build.gradle`, `build.gradle.kts`}},
+	{platform: &node.Platform{}, files: []string{`package.json`}},
+}
+
+// NewAuto returns local fetcher with platform detected by chaincode source directory content
+func NewAuto(path string) (api.CCFetcher, error) {
+	platform, err := DetectPlatform(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocal(platform), nil
+}
+
+// DetectPlatform inspects chaincode source directory and returns matching platform
+func DetectPlatform(path string) (platforms.Platform, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf(`stat chaincode path=%s: %w`, path, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf(`chaincode path=%s is not a directory`, path)
+	}
+
+	var detected []platforms.Platform
+	for _, marker := range platformMarkers {
+		for _, file := range marker.files {
+			if _, err := os.Stat(filepath.Join(path, file)); err == nil {
+				detected = append(detected, marker.platform)
+				break
+			}
+		}
+	}
+
+	// go chaincode could be located in GOPATH without go.mod
+	if len(detected) == 0 {
+		if goFiles, _ := filepath.Glob(filepath.Join(path, `*.go`)); len(goFiles) > 0 {
+			detected = append(detected, &golang.Platform{})
+		}
+	}
+
+	switch len(detected) {
+	case 0:
+		return nil, fmt.Errorf(`%s: %w`, path, ErrUnknownPlatform)
+	case 1:
+		return detected[0], nil
+	}
+
+	names := make([]string, 0, len(detected))
+	for _, p := range detected {
+		names = append(names, p.Name())
+	}
+	return nil, fmt.Errorf(`%s: %w: %s`, path, ErrAmbiguousPlatform, strings.Join(names, `, `))
+}
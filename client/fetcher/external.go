@@ -0,0 +1,170 @@
+package fetcher
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+)
+
+// ExternalBuilderType is type of chaincode package handled by chaincode-as-a-service external builder
+const ExternalBuilderType = `ccaas`
+
+// ExternalConnection is content of connection.json used by peer to connect to chaincode service
+type ExternalConnection struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	ClientKey          string `json:"client_key,omitempty"`
+	ClientCert         string `json:"client_cert,omitempty"`
+	RootCert           string `json:"root_cert,omitempty"`
+}
+
+// External is chaincode package for chaincode-as-a-service external builder, which can be installed by lifecycle
+type External struct {
+	label   string
+	pkg     []byte
+	pkgID   string
+	connect ExternalConnection
+}
+
+// NewExternal creates package of chaincode service with presented label. Host of connection config is address of
+// chaincode service, TLS certificate and key are used by peer to connect to it, timeout is dial timeout (10s by default)
+func NewExternal(label string, conn config.ConnectionConfig) (*External, error) {
+	connection, err := NewExternalConnection(conn)
+	if err != nil {
+		return nil, err
+	}
+	return NewExternalFromConnection(label, connection)
+}
+
+// NewExternalConnection converts connection config to connection.json content, TLS files are read from presented paths
+func NewExternalConnection(conn config.ConnectionConfig) (ExternalConnection, error) {
+	connection := ExternalConnection{Address: conn.Host, DialTimeout: `10s`, TLSRequired: conn.Tls.Enabled}
+	if conn.Timeout.Duration > 0 {
+		connection.DialTimeout = conn.Timeout.String()
+	}
+
+	if !conn.Tls.Enabled {
+		return connection, nil
+	}
+
+	for _, file := range []struct {
+		path string
+		dst  *string
+	}{
+		{conn.Tls.CACertPath, &connection.RootCert},
+		{conn.Tls.CertPath, &connection.ClientCert},
+		{conn.Tls.KeyPath, &connection.ClientKey},
+	} {
+		if file.path == `` {
+			continue
+		}
+		content, err := ioutil.ReadFile(file.path)
+		if err != nil {
+			return connection, errors.Wrap(err, `failed to read TLS file`)
+		}
+		*file.dst = string(content)
+	}
+	connection.ClientAuthRequired = connection.ClientCert != ``
+
+	return connection, nil
+}
+
+// NewExternalFromConnection creates package of chaincode service with presented label and connection.json content
+func NewExternalFromConnection(label string, connection ExternalConnection) (*External, error) {
+	if label == `` {
+		return nil, errors.New(`package label is empty`)
+	}
+	if connection.Address == `` {
+		return nil, errors.New(`chaincode service address is empty`)
+	}
+
+	connectionJSON, err := json.Marshal(connection)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to marshal connection`)
+	}
+	code, err := targz(packageFile{`connection.json`, connectionJSON})
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to create code package`)
+	}
+
+	metadataJSON, err := json.Marshal(map[string]string{`type`: ExternalBuilderType, `label`: label})
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to marshal metadata`)
+	}
+	pkg, err := targz(packageFile{`metadata.json`, metadataJSON}, packageFile{`code.tar.gz`, code})
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to create chaincode package`)
+	}
+
+	return &External{
+		label:   label,
+		pkg:     pkg,
+		pkgID:   fmt.Sprintf(`%s:%x`, label, sha256.Sum256(pkg)),
+		connect: connection,
+	}, nil
+}
+
+// Label returns package label
+func (e *External) Label() string {
+	return e.label
+}
+
+// Package returns package bytes, which are passed to lifecycle install
+func (e *External) Package() []byte {
+	return e.pkg
+}
+
+// PackageID returns id assigned to package by peer on installation
+func (e *External) PackageID() string {
+	return e.pkgID
+}
+
+// Connection returns content of connection.json of package
+func (e *External) Connection() ExternalConnection {
+	return e.connect
+}
+
+type packageFile struct {
+	name    string
+	content []byte
+}
+
+// targz writes files in presented order to gzipped tar, headers don't contain modification time,
+// so package and its id are the same for the same content
+func targz(files ...packageFile) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+
+	for _, file := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     file.name,
+			Size:     int64(len(file.content)),
+			Mode:     0100644,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
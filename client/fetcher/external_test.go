@@ -0,0 +1,38 @@
+package fetcher_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/client/fetcher"
+)
+
+type noDBArtifacts struct{}
+
+func (noDBArtifacts) GetDBArtifacts([]byte) ([]byte, error) {
+	return nil, nil
+}
+
+func TestNewExternal(t *testing.T) {
+	ext, err := fetcher.NewExternal(`asset_1.0`, config.ConnectionConfig{Host: `asset-cc:9999`})
+	require.NoError(t, err)
+
+	pkg, err := persistence.ChaincodePackageParser{MetadataProvider: noDBArtifacts{}}.Parse(ext.Package())
+	require.NoError(t, err)
+	require.Equal(t, fetcher.ExternalBuilderType, pkg.Metadata.Type)
+	require.Equal(t, `asset_1.0`, pkg.Metadata.Label)
+	require.Equal(t, fmt.Sprintf(`asset_1.0:%x`, sha256.Sum256(ext.Package())), ext.PackageID())
+
+	// package id is stable for same connection
+	same, err := fetcher.NewExternal(`asset_1.0`, config.ConnectionConfig{Host: `asset-cc:9999`})
+	require.NoError(t, err)
+	require.Equal(t, ext.PackageID(), same.PackageID())
+
+	_, err = fetcher.NewExternal(`asset_1.0`, config.ConnectionConfig{})
+	require.Error(t, err)
+}
@@ -2,9 +2,15 @@ package fetcher
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/pkg/errors"
 	"github.com/s7techlab/hlf-sdk-go/api"
 )
 
@@ -13,27 +19,45 @@ type localFetcher struct {
 	pl platforms.Platform
 }
 
+// packager is implemented by golang, node and java platforms
+type packager interface {
+	ValidatePath(path string) error
+	GetDeploymentPayload(path string) ([]byte, error)
+}
+
+// Fetch packages chaincode source located at id path, spec type is set by fetcher platform
 func (f *localFetcher) Fetch(ctx context.Context, id *peer.ChaincodeID) (*peer.ChaincodeDeploymentSpec, error) {
-	panic(`implement me`)
-	//ccBytes, err := f.r.GetDeploymentPayload(f.pl.Name(), id.Path)
-	//if err != nil {
-	//	return nil, errors.Wrap(err, ``)
-	//}
-	//
-	//return &peer.ChaincodeDeploymentSpec{
-	//	ChaincodeSpec: &peer.ChaincodeSpec{
-	//		Type:        f.getTypeByPlatform(),
-	//		ChaincodeId: id,
-	//	},
-	//	CodePackage: ccBytes,
-	//	ExecEnv:     peer.ChaincodeDeploymentSpec_DOCKER,
-	//}, nil
+	pkg, ok := f.pl.(packager)
+	if !ok {
+		return nil, fmt.Errorf(`platform %s doesn't support packaging`, f.pl.Name())
+	}
+
+	if err := pkg.ValidatePath(id.Path); err != nil {
+		return nil, errors.Wrap(err, `invalid chaincode path`)
+	}
+
+	ccBytes, err := pkg.GetDeploymentPayload(id.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get deployment payload`)
+	}
+
+	return &peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        f.getTypeByPlatform(),
+			ChaincodeId: id,
+		},
+		CodePackage: ccBytes,
+	}, nil
 }
 
 func (f *localFetcher) getTypeByPlatform() peer.ChaincodeSpec_Type {
 	switch f.pl.Name() {
 	case peer.ChaincodeSpec_GOLANG.String():
 		return peer.ChaincodeSpec_GOLANG
+	case peer.ChaincodeSpec_JAVA.String():
+		return peer.ChaincodeSpec_JAVA
+	case peer.ChaincodeSpec_NODE.String():
+		return peer.ChaincodeSpec_NODE
 	}
 	return peer.ChaincodeSpec_UNDEFINED
 }
@@ -41,3 +65,19 @@ func (f *localFetcher) getTypeByPlatform() peer.ChaincodeSpec_Type {
 func NewLocal(platform platforms.Platform) api.CCFetcher {
 	return &localFetcher{r: platforms.NewRegistry(platform), pl: platform}
 }
+
+// NewLocalForPlatform returns local fetcher for platform with presented name: golang, node or java
+func NewLocalForPlatform(name string) (api.CCFetcher, error) {
+	var platform platforms.Platform
+	switch strings.ToUpper(name) {
+	case peer.ChaincodeSpec_GOLANG.String():
+		platform = &golang.Platform{}
+	case peer.ChaincodeSpec_NODE.String():
+		platform = &node.Platform{}
+	case peer.ChaincodeSpec_JAVA.String():
+		platform = &java.Platform{}
+	default:
+		return nil, fmt.Errorf(`platform %s: %w`, name, ErrUnknownPlatform)
+	}
+	return NewLocal(platform), nil
+}
@@ -0,0 +1,50 @@
+package fetcher_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/client/fetcher"
+)
+
+func TestNewLocalForPlatform_Node(t *testing.T) {
+	f, err := fetcher.NewLocalForPlatform(`node`)
+	require.NoError(t, err)
+
+	spec, err := f.Fetch(context.Background(), &peer.ChaincodeID{Name: `asset`, Path: `testdata/node`, Version: `1.0`})
+	require.NoError(t, err)
+	require.Equal(t, peer.ChaincodeSpec_NODE, spec.ChaincodeSpec.Type)
+	require.NoError(t, (&node.Platform{}).ValidateCodePackage(spec.CodePackage))
+
+	gr, err := gzip.NewReader(bytes.NewReader(spec.CodePackage))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var files []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		files = append(files, header.Name)
+	}
+	require.ElementsMatch(t, []string{`src/index.js`, `src/package.json`}, files)
+
+	_, err = f.Fetch(context.Background(), &peer.ChaincodeID{Name: `asset`, Path: `testdata/missing`})
+	require.Error(t, err)
+}
+
+func TestNewLocalForPlatform_Unknown(t *testing.T) {
+	_, err := fetcher.NewLocalForPlatform(`cobol`)
+	require.True(t, errors.Is(err, fetcher.ErrUnknownPlatform))
+}
@@ -0,0 +1,11 @@
+'use strict';
+
+const { Contract } = require('fabric-contract-api');
+
+class Asset extends Contract {
+    async Ping() {
+        return 'pong';
+    }
+}
+
+module.exports.contracts = [Asset];
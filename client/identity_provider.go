@@ -0,0 +1,150 @@
+package client
+
+import (
+	"time"
+
+	mspProto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/msp"
+	"go.uber.org/zap"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// IdentityRefreshMargin is time before certificate expiration when identity is requested from provider again
+var IdentityRefreshMargin = time.Minute
+
+// refreshIdentity replaces identity by one obtained from provider if current identity expires within refresh margin.
+// Channels resolve identity when operation starts, while operations already started complete with previous identity,
+// so each transaction is signed by single identity. If provider fails, current identity is kept
+func (c *core) refreshIdentity() {
+	if c.identityProvider == nil || !c.identityExpiring() {
+		return
+	}
+
+	c.identityRefreshMx.Lock()
+	defer c.identityRefreshMx.Unlock()
+
+	// identity could be refreshed while waiting for lock
+	if !c.identityExpiring() {
+		return
+	}
+
+	identity, err := c.identityProvider(c.ctx)
+	if err != nil {
+		c.logger.Warn(`Failed to refresh identity from provider, current identity is used`, zap.Error(err))
+		return
+	}
+	if identity == nil {
+		c.logger.Warn(`Identity provider returned no identity, current identity is used`)
+		return
+	}
+	c.SetIdentity(identity)
+}
+
+// identityExpiring reports whether certificate of current identity expires within refresh margin,
+// identities without known expiration never expire
+func (c *core) identityExpiring() bool {
+	expiring, ok := c.CurrentIdentity().(interface{ ExpiresAt() time.Time })
+	if !ok {
+		return false
+	}
+	return time.Until(expiring.ExpiresAt()) < IdentityRefreshMargin
+}
+
+// signingIdentity returns current identity refreshed from provider, or identity which fails operations
+// with api.ErrNoIdentity if core has no default identity
+func (c *core) signingIdentity() msp.SigningIdentity {
+	return providedIdentity{core: c}.ResolveIdentity()
+}
+
+// providedIdentity is current identity of core, which is refreshed from provider when operation resolves it,
+// so cached channels and chaincodes sign with actual identity. If core has no identity,
+// signing and serialization fail with api.ErrNoIdentity
+type providedIdentity struct {
+	core *core
+}
+
+func (i providedIdentity) ResolveIdentity() msp.SigningIdentity {
+	i.core.refreshIdentity()
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity
+	}
+	return i
+}
+
+func (i providedIdentity) ExpiresAt() time.Time {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.ExpiresAt()
+	}
+	return time.Time{}
+}
+
+func (i providedIdentity) GetIdentifier() *msp.IdentityIdentifier {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.GetIdentifier()
+	}
+	return nil
+}
+
+func (i providedIdentity) GetMSPIdentifier() string {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.GetMSPIdentifier()
+	}
+	return i.core.mspId
+}
+
+func (i providedIdentity) Validate() error {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.Validate()
+	}
+	return api.ErrNoIdentity
+}
+
+func (i providedIdentity) GetOrganizationalUnits() []*msp.OUIdentifier {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.GetOrganizationalUnits()
+	}
+	return nil
+}
+
+func (i providedIdentity) Anonymous() bool {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.Anonymous()
+	}
+	return false
+}
+
+func (i providedIdentity) Verify(msg []byte, sig []byte) error {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.Verify(msg, sig)
+	}
+	return api.ErrNoIdentity
+}
+
+func (i providedIdentity) Serialize() ([]byte, error) {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.Serialize()
+	}
+	return nil, api.ErrNoIdentity
+}
+
+func (i providedIdentity) SatisfiesPrincipal(principal *mspProto.MSPPrincipal) error {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.SatisfiesPrincipal(principal)
+	}
+	return api.ErrNoIdentity
+}
+
+func (i providedIdentity) Sign(msg []byte) ([]byte, error) {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.Sign(msg)
+	}
+	return nil, api.ErrNoIdentity
+}
+
+func (i providedIdentity) GetPublicVersion() msp.Identity {
+	if identity := i.core.CurrentIdentity(); identity != nil {
+		return identity.GetPublicVersion()
+	}
+	return i
+}
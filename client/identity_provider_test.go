@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/crypto"
+	"github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+	"github.com/s7techlab/hlf-sdk-go/identity"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+)
+
+func TestRefreshIdentity(t *testing.T) {
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	var calls int
+	provider := func(context.Context) (api.Identity, error) {
+		calls++
+		return identity.NewMSPIdentityFromPath(`org2msp`, `./chaincode/testdata/msp`)
+	}
+
+	c := &core{
+		ctx:              context.Background(),
+		cs:               cs,
+		logger:           logger.DefaultLogger,
+		channels:         make(map[string]api.Channel),
+		chaincodes:       make(map[string]*chaincodeEntry),
+		identityProvider: provider,
+	}
+
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `./chaincode/testdata/msp`)
+	require.NoError(t, err)
+	c.SetIdentity(id)
+
+	// certificate doesn't expire soon
+	c.refreshIdentity()
+	require.Equal(t, 0, calls)
+	require.Equal(t, `org1msp`, c.CurrentIdentity().GetMSPIdentifier())
+
+	defer func(margin time.Duration) { IdentityRefreshMargin = margin }(IdentityRefreshMargin)
+	IdentityRefreshMargin = 100 * 365 * 24 * time.Hour
+
+	c.refreshIdentity()
+	require.Equal(t, 1, calls)
+	require.Equal(t, `org2msp`, c.CurrentIdentity().GetMSPIdentifier())
+}
+
+func TestSetNilIdentity(t *testing.T) {
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	c := &core{
+		ctx:        context.Background(),
+		cs:         cs,
+		logger:     logger.DefaultLogger,
+		chaincodes: make(map[string]*chaincodeEntry),
+		identityProvider: func(context.Context) (api.Identity, error) {
+			return nil, nil
+		},
+	}
+
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `./chaincode/testdata/msp`)
+	require.NoError(t, err)
+	c.SetIdentity(id)
+
+	defer func(margin time.Duration) { IdentityRefreshMargin = margin }(IdentityRefreshMargin)
+	IdentityRefreshMargin = 100 * 365 * 24 * time.Hour
+
+	// provider without identity doesn't replace current one
+	c.refreshIdentity()
+	require.Equal(t, `org1msp`, c.CurrentIdentity().GetMSPIdentifier())
+
+	c.SetIdentity(nil)
+	require.Nil(t, c.CurrentIdentity())
+	_, err = c.signingIdentity().Serialize()
+	require.Equal(t, api.ErrNoIdentity, err)
+}
@@ -2,10 +2,16 @@ package invoker
 
 import (
 	"context"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/pkg/errors"
+
 	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode/system"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode/txwaiter"
+	"github.com/s7techlab/hlf-sdk-go/util"
 )
 
 type invoker struct {
@@ -31,6 +37,55 @@ func (i *invoker) Invoke(
 		Do(ctx, doOpts...)
 }
 
+func (i *invoker) InvokeWithEvent(
+	ctx context.Context,
+	from msp.SigningIdentity,
+	channel string,
+	chaincode string,
+	fn string,
+	args [][]byte,
+	transArgs api.TransArgs,
+	doOpts ...api.DoOption,
+) (*peer.ChaincodeEvent, api.ChaincodeTx, error) {
+	// event is read from ledger, so invoke fails before endorsement if tx waiter doesn't wait for commit
+	doOpts = append(doOpts, requireCommitWait)
+
+	_, tx, err := i.Invoke(ctx, from, channel, chaincode, fn, args, transArgs, doOpts...)
+	if err != nil {
+		return nil, tx, err
+	}
+
+	// transaction is committed, so get it's action from ledger by invoking identity
+	processedTx, err := system.NewQSCC(i.core.PeerPool(), from).GetTransactionByID(ctx, channel, tx)
+	if err != nil {
+		return nil, tx, errors.Wrap(err, `failed to get committed transaction`)
+	}
+
+	envBytes, err := proto.Marshal(processedTx.TransactionEnvelope)
+	if err != nil {
+		return nil, tx, errors.Wrap(err, `failed to marshal transaction envelope`)
+	}
+
+	event, err := util.GetEventFromEnvelope(envBytes)
+	if err != nil {
+		return nil, tx, errors.Wrap(err, `failed to get chaincode event`)
+	}
+
+	if event.GetEventName() == `` {
+		return nil, tx, nil
+	}
+
+	return event, tx, nil
+}
+
+// requireCommitWait rejects tx waiter set by options if it doesn't wait for commit, default tx waiter waits
+func requireCommitWait(opts *api.DoOptions) error {
+	if opts.TxWaiter != nil && !txwaiter.Waits(opts.TxWaiter) {
+		return api.ErrCommitNotAwaited
+	}
+	return nil
+}
+
 func (i *invoker) Query(ctx context.Context, from msp.SigningIdentity, channel string, chaincode string, fn string, args [][]byte, transArgs api.TransArgs) (*peer.Response, error) {
 	argSs := make([]string, 0)
 	for _, arg := range args {
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/peer"
+)
+
+// runMembershipRefresh reconciles pool membership with gossip with presented interval until context is done
+func (c *core) runMembershipRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.refreshMembership(ctx); err != nil {
+				c.logger.Warn(`Failed to refresh pool membership`, zap.Error(err))
+			}
+		}
+	}
+}
+
+// refreshMembership adds to pool peers known by gossip of pool peers on discovered and warm up channels and
+// removes peers added by refresh which left channels. Configured peers are never removed, gossip member is
+// considered as configured peer if its host matches host or TLS host override of configured peer.
+// Pool is left unchanged if membership of any channel can't be fetched
+func (c *core) refreshMembership(ctx context.Context) error {
+	channels := make(map[string]struct{})
+	for _, channelName := range c.warmUpChannels {
+		channels[channelName] = struct{}{}
+	}
+	if dp := c.discovery(); dp != nil {
+		discovered, err := dp.Channels()
+		if err != nil {
+			return errors.Wrap(err, `failed to discover channels`)
+		}
+		for _, ch := range discovered {
+			channels[ch.Name] = struct{}{}
+		}
+	}
+
+	members := make(map[string]map[string]struct{})
+	for channelName := range channels {
+		channelMembers, err := c.gossipMembers(ctx, channelName)
+		if err != nil {
+			return errors.Wrapf(err, `failed to get members of channel %s`, channelName)
+		}
+		for _, member := range channelMembers {
+			if member.Endpoint == `` {
+				continue
+			}
+			if members[member.MspID] == nil {
+				members[member.MspID] = make(map[string]struct{})
+			}
+			members[member.MspID][member.Endpoint] = struct{}{}
+		}
+	}
+
+	c.refreshedPeersMx.Lock()
+	defer c.refreshedPeersMx.Unlock()
+	if c.refreshedPeers == nil {
+		c.refreshedPeers = make(map[string]map[string]struct{})
+	}
+
+	for mspID, endpoints := range c.refreshedPeers {
+		for endpoint := range endpoints {
+			if _, ok := members[mspID][endpoint]; ok {
+				continue
+			}
+			delete(endpoints, endpoint)
+			c.logger.Info(`Removing peer left channels from pool`, zap.String(`mspId`, mspID), zap.String(`uri`, endpoint))
+			// pool closes peer after endorsements in progress complete, so it's done in background
+			go func(mspID, address string) {
+				if err := c.peerPool.Remove(mspID, address); err != nil {
+					c.logger.Warn(`Failed to remove peer from pool`,
+						zap.String(`mspId`, mspID), zap.String(`uri`, address), zap.Error(err))
+				}
+			}(mspID, endpoint)
+		}
+	}
+
+	poolPeers := c.peerPool.Peers()
+	for mspID, endpoints := range members {
+		known := c.knownHosts(mspID, poolPeers[mspID])
+		for endpoint := range endpoints {
+			if _, ok := known[endpointHost(endpoint)]; ok {
+				continue
+			}
+			if _, ok := c.refreshedPeers[mspID][endpoint]; ok {
+				continue
+			}
+			if err := c.addMember(mspID, endpoint); err != nil {
+				c.logger.Warn(`Failed to add peer to pool`,
+					zap.String(`mspId`, mspID), zap.String(`uri`, endpoint), zap.Error(err))
+				continue
+			}
+			if c.refreshedPeers[mspID] == nil {
+				c.refreshedPeers[mspID] = make(map[string]struct{})
+			}
+			c.refreshedPeers[mspID][endpoint] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// knownHosts returns hosts of organization pool peers and TLS host overrides of its configured endorsers
+func (c *core) knownHosts(mspID string, poolPeers []api.Peer) map[string]struct{} {
+	hosts := make(map[string]struct{})
+	for _, p := range poolPeers {
+		hosts[endpointHost(p.Uri())] = struct{}{}
+	}
+	if c.config != nil {
+		for _, mspConfig := range c.config.MSP {
+			if mspConfig.Name != mspID {
+				continue
+			}
+			for _, endorser := range mspConfig.Endorsers {
+				if endorser.Tls.HostOverride != `` {
+					hosts[endorser.Tls.HostOverride] = struct{}{}
+				}
+			}
+		}
+	}
+	return hosts
+}
+
+// endpointHost returns host of endpoint, endpoint without port is returned as is
+func endpointHost(endpoint string) string {
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host
+	}
+	return endpoint
+}
+
+// gossipMembers returns channel members known by gossip of first pool peer responded
+func (c *core) gossipMembers(ctx context.Context, channelName string) ([]api.GossipMember, error) {
+	lastErr := errors.New(`pool has no peers`)
+	for _, peers := range c.peerPool.Peers() {
+		for _, p := range peers {
+			members, err := peer.GossipMembers(ctx, p, c.signingIdentity(), channelName)
+			if err == nil {
+				return members, nil
+			}
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+// addMember adds peer with presented endpoint to pool, connection settings except host are taken
+// from configured endorsers of organization
+func (c *core) addMember(mspID, endpoint string) error {
+	template, ok := c.peerTemplate(mspID)
+	if !ok {
+		return errors.Errorf(`connection config of organization %s endorsers is not presented`, mspID)
+	}
+
+	template.Host = endpoint
+	p, err := c.dialPeer(template)
+	if err != nil {
+		return errors.Wrap(err, `failed to create peer`)
+	}
+
+	c.logger.Info(`Adding peer joined channels to pool`, zap.String(`mspId`, mspID), zap.String(`uri`, endpoint))
+	if err = c.addPeer(mspID, p); err != nil {
+		_ = p.Close()
+		return err
+	}
+	return nil
+}
+
+// peerTemplate returns connection config of first configured endorser of organization
+func (c *core) peerTemplate(mspID string) (config.ConnectionConfig, bool) {
+	if c.config == nil {
+		return config.ConnectionConfig{}, false
+	}
+	for _, mspConfig := range c.config.MSP {
+		if mspConfig.Name == mspID && len(mspConfig.Endorsers) > 0 {
+			return mspConfig.Endorsers[0], true
+		}
+	}
+	return config.ConnectionConfig{}, false
+}
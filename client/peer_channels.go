@@ -0,0 +1,13 @@
+package client
+
+import (
+	"context"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/chaincode/system"
+)
+
+func (c *core) PeerChannels(ctx context.Context, peer api.Peer) ([]string, error) {
+	c.refreshIdentity()
+	return system.PeerChannels(ctx, peer, c.signingIdentity())
+}
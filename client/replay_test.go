@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/crypto"
+	"github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+	_ "github.com/s7techlab/hlf-sdk-go/discovery/local"
+	"github.com/s7techlab/hlf-sdk-go/identity"
+	"github.com/s7techlab/hlf-sdk-go/recorder"
+)
+
+func TestReplayInvoke(t *testing.T) {
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	// endorsements of proposal made while recording, peers of config aren't running
+	creator, err := identity.NewMSPIdentityFromPath(`org1msp`, `./chaincode/testdata/msp`)
+	require.NoError(t, err)
+	creatorBytes, err := creator.GetSigningIdentity(cs).Serialize()
+	require.NoError(t, err)
+	prop, _, err := protoutil.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, `success-network`,
+		&fabricPeer.ChaincodeInvocationSpec{ChaincodeSpec: &fabricPeer.ChaincodeSpec{
+			ChaincodeId: &fabricPeer.ChaincodeID{Name: `my-chaincode`}}}, creatorBytes)
+	require.NoError(t, err)
+
+	records := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(records)
+	write := func(kind, target string, resp proto.Message) {
+		respBytes, err := proto.Marshal(resp)
+		require.NoError(t, err)
+		require.NoError(t, enc.Encode(recorder.Record{Kind: kind, Target: target, Response: respBytes}))
+	}
+
+	for _, endorser := range []struct{ mspID, address string }{
+		{`org1msp`, `127.0.0.1:7051`}, {`org2msp`, `localhost:7051`}, {`org3msp`, `localhost:7051`},
+	} {
+		id, err := identity.NewMSPIdentityFromPath(endorser.mspID, `./chaincode/testdata/msp`)
+		require.NoError(t, err)
+		resp, err := protoutil.CreateProposalResponse(prop.Header, prop.Payload,
+			&fabricPeer.Response{Status: 200}, nil, nil,
+			&fabricPeer.ChaincodeID{Name: `my-chaincode`}, id.GetSigningIdentity(cs))
+		require.NoError(t, err)
+		write(recorder.KindEndorse, endorser.address, resp)
+	}
+	write(recorder.KindBroadcast, ``, &fabricOrderer.BroadcastResponse{Status: common.Status_SUCCESS})
+	write(recorder.KindTx, `127.0.0.1:7051`, &fabricPeer.ProcessedTransaction{
+		ValidationCode: int32(fabricPeer.TxValidationCode_VALID)})
+
+	// configured peers and orderer aren't listening, so core works only if they aren't dialed
+	core, err := NewCore(`org1msp`, creator,
+		WithConfigYaml(`./chaincode/testdata/config.yaml`),
+		WithReplay(records),
+	)
+	require.NoError(t, err)
+	defer core.Close()
+
+	resp, _, err := core.Channel(`success-network`).Chaincode(`my-chaincode`).Invoke(`call`).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(200), resp.Status)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+)
+
+func (c *core) ResolvedConfig() api.ResolvedConfig {
+	c.discoveryMx.RLock()
+	discoveryType := c.discoveryType
+	c.discoveryMx.RUnlock()
+
+	resolved := api.ResolvedConfig{
+		Peers:           make(map[string][]api.ResolvedConnection),
+		ChannelOrderers: make(map[string][]api.ResolvedConnection),
+		DiscoveryType:   discoveryType,
+		CryptoType:      fmt.Sprintf(`%T`, c.cs),
+		FabricV2:        c.fabricV2,
+	}
+
+	// TLS settings of peers added by option are unknown, so they are looked up in config by host
+	peerConfigs := make(map[string]config.ConnectionConfig)
+	if c.config != nil {
+		for _, mspConfig := range c.config.MSP {
+			for _, peerConfig := range mspConfig.Endorsers {
+				peerConfigs[peerConfig.Host] = peerConfig
+			}
+		}
+	}
+
+	for mspID, peers := range c.peerPool.Peers() {
+		for _, p := range peers {
+			conn := api.ResolvedConnection{Host: p.Uri()}
+			if peerConfig, ok := c.peerConfig(mspID, p.Uri(), peerConfigs); ok {
+				conn.TLS = resolveTLS(c.connectionConfig(peerConfig).Tls)
+			}
+			resolved.Peers[mspID] = append(resolved.Peers[mspID], conn)
+		}
+	}
+
+	switch {
+	case c.ordererTemplate != nil && c.config != nil:
+		ordererConfigs := c.config.Orderers
+		if len(ordererConfigs) == 0 {
+			ordererConfigs = []config.ConnectionConfig{*c.config.Orderer}
+		}
+		resolved.Orderers = resolveConnections(ordererConfigs, c.connectionConfig)
+
+	case c.baseOrderer != nil:
+		// endpoints of orderer set by option are unknown
+		resolved.Orderers = []api.ResolvedConnection{{}}
+	}
+
+	// channel orderers are kept with connection configs mapped by options
+	c.channelOrderersMx.Lock()
+	for channelName, ordererConfigs := range c.channelOrderers {
+		resolved.ChannelOrderers[channelName] = resolveConnections(ordererConfigs, nil)
+	}
+	c.channelOrderersMx.Unlock()
+
+	return resolved
+}
+
+// peerConfig returns connection config of pool peer: configured endorser with the same host
+// or endorser template of organization for peer added by membership refresh
+func (c *core) peerConfig(mspID, host string, peerConfigs map[string]config.ConnectionConfig) (config.ConnectionConfig, bool) {
+	if peerConfig, ok := peerConfigs[host]; ok {
+		return peerConfig, true
+	}
+
+	c.refreshedPeersMx.Lock()
+	_, refreshed := c.refreshedPeers[mspID][host]
+	c.refreshedPeersMx.Unlock()
+	if !refreshed {
+		return config.ConnectionConfig{}, false
+	}
+
+	template, ok := c.peerTemplate(mspID)
+	template.Host = host
+	return template, ok
+}
+
+// resolveConnections describes connections with configs mapped by presented func, if it's set
+func resolveConnections(configs []config.ConnectionConfig, mapConfig func(config.ConnectionConfig) config.ConnectionConfig) []api.ResolvedConnection {
+	conns := make([]api.ResolvedConnection, 0, len(configs))
+	for _, conf := range configs {
+		if mapConfig != nil {
+			conf = mapConfig(conf)
+		}
+		conns = append(conns, api.ResolvedConnection{Host: conf.Host, TLS: resolveTLS(conf.Tls)})
+	}
+	return conns
+}
+
+func resolveTLS(tls config.TlsConfig) api.ResolvedTLS {
+	return api.ResolvedTLS{
+		Enabled:      tls.Enabled,
+		SkipVerify:   tls.SkipVerify,
+		HostOverride: tls.HostOverride,
+		CertPath:     tls.CertPath,
+		CACertPath:   tls.CACertPath,
+		ClientKey:    tls.KeyPath != `` || tls.KeyPEM != ``,
+	}
+}
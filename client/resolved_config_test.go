@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+)
+
+func TestResolvedConfig(t *testing.T) {
+	tlsConfig := config.TlsConfig{Enabled: true, CACertPath: `ca.pem`}
+	serverNames := func(address string) (string, bool) {
+		return `orderer.example.com`, address == `10.0.0.1:7050`
+	}
+
+	t.Run(`orderers are resolved by options`, func(t *testing.T) {
+		ordererConfig := config.ConnectionConfig{Host: `10.0.0.1:7050`, Tls: tlsConfig}
+		c := &core{
+			config:          &config.Config{Orderer: &ordererConfig},
+			peerPool:        pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{}),
+			ordererTemplate: &ordererConfig,
+			discoveryType:   `local`,
+			tlsServerNames:  serverNames,
+			channelOrderers: make(map[string][]config.ConnectionConfig),
+		}
+		c.setChannelOrderers(`channel`, []config.ConnectionConfig{{Host: `10.0.0.1:7050`, Tls: tlsConfig}, {Host: `10.0.0.2:7050`}})
+
+		resolved := c.ResolvedConfig()
+		require.Equal(t, `local`, resolved.DiscoveryType)
+		require.Equal(t, []api.ResolvedConnection{{
+			Host: `10.0.0.1:7050`,
+			TLS:  api.ResolvedTLS{Enabled: true, HostOverride: `orderer.example.com`, CACertPath: `ca.pem`},
+		}}, resolved.Orderers)
+		require.Equal(t, map[string][]api.ResolvedConnection{`channel`: {{
+			Host: `10.0.0.1:7050`,
+			TLS:  api.ResolvedTLS{Enabled: true, HostOverride: `orderer.example.com`, CACertPath: `ca.pem`},
+		}, {
+			Host: `10.0.0.2:7050`,
+		}}}, resolved.ChannelOrderers)
+	})
+
+	t.Run(`orderer set by option`, func(t *testing.T) {
+		c := &core{
+			peerPool:    pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{}),
+			baseOrderer: struct{ api.Orderer }{},
+		}
+
+		resolved := c.ResolvedConfig()
+		require.Empty(t, resolved.DiscoveryType)
+		require.Equal(t, []api.ResolvedConnection{{}}, resolved.Orderers)
+	})
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/client/channel"
+	"github.com/s7techlab/hlf-sdk-go/peer"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+func (c *core) SubmitEnvelope(ctx context.Context, channelName string, envelope *common.Envelope, opts ...api.DoOption) (*fabricOrderer.BroadcastResponse, error) {
+	identity := c.signingIdentity()
+	doOpts := &api.DoOptions{
+		Identity: identity,
+		Pool:     c.peerPool,
+	}
+	for _, applyOpt := range opts {
+		if err := applyOpt(doOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal payload`)
+	}
+	if payload.Header == nil {
+		return nil, errors.New(`envelope payload has no header`)
+	}
+
+	chHeader, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal channel header`)
+	}
+
+	if chHeader.ChannelId != channelName {
+		return nil, errors.Errorf(`envelope channel %s doesn't match %s`, chHeader.ChannelId, channelName)
+	}
+
+	switch common.HeaderType(chHeader.Type) {
+	case common.HeaderType_ENDORSER_TRANSACTION, common.HeaderType_CONFIG_UPDATE:
+	default:
+		return nil, errors.Errorf(`unsupported envelope type: %s`, common.HeaderType(chHeader.Type))
+	}
+
+	if len(envelope.Signature) == 0 {
+		// envelope is signed only by its creator, otherwise orderer rejects it or it is submitted on behalf of other identity
+		if err = checkCreator(payload.Header, identity); err != nil {
+			return nil, err
+		}
+		signature, err := identity.Sign(envelope.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to sign envelope`)
+		}
+		envelope = &common.Envelope{Payload: envelope.Payload, Signature: signature}
+	}
+
+	ord := c.orderer
+	if ch, ok := c.Channel(channelName).(*channel.Core); ok && ch.Orderer() != nil {
+		ord = ch.Orderer()
+	}
+	if ord == nil {
+		return nil, errors.New(`orderer is not configured`)
+	}
+
+	resp, err := ord.Broadcast(ctx, envelope)
+	if err != nil {
+		// response is returned with error, so orderer info of rejected envelope is available
+		return resp, errors.Wrap(err, `failed to broadcast envelope`)
+	}
+
+	if doOpts.TxWaiter != nil && common.HeaderType(chHeader.Type) == common.HeaderType_ENDORSER_TRANSACTION {
+		if err = doOpts.TxWaiter.Wait(ctx, channelName, api.ChaincodeTx(chHeader.TxId)); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+// checkCreator checks that creator of envelope is signing identity
+func checkCreator(header *common.Header, identity msp.SigningIdentity) error {
+	sigHeader, err := protoutil.UnmarshalSignatureHeader(header.SignatureHeader)
+	if err != nil {
+		return errors.Wrap(err, `failed to unmarshal signature header`)
+	}
+
+	creator, err := identity.Serialize()
+	if err != nil {
+		return errors.Wrap(err, `failed to serialize identity`)
+	}
+
+	if !bytes.Equal(sigHeader.Creator, creator) {
+		return errors.New(`unsigned envelope is created by other identity than signing one`)
+	}
+	return nil
+}
+
+func (c *core) SubmitSigned(ctx context.Context, proposalBytes, signature []byte, opts ...api.DoOption) (*common.Envelope, error) {
+	proposal, err := protoutil.UnmarshalProposal(proposalBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal proposal`)
+	}
+
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal proposal header`)
+	}
+
+	chHeader, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal channel header`)
+	}
+
+	sigHeader, err := protoutil.UnmarshalSignatureHeader(header.SignatureHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal signature header`)
+	}
+
+	extension, err := protoutil.UnmarshalChaincodeHeaderExtension(chHeader.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal chaincode header extension`)
+	}
+
+	cc, err := c.discovery().Chaincode(chHeader.ChannelId, extension.GetChaincodeId().GetName())
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get chaincode definition`)
+	}
+
+	doOpts := &api.DoOptions{
+		DiscoveryChaincode: cc,
+		Pool:               c.peerPool,
+	}
+	for _, applyOpt := range opts {
+		if err := applyOpt(doOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	signed := &fabricPeer.SignedProposal{ProposalBytes: proposalBytes, Signature: signature}
+	responses, err := peer.NewProcessor(chHeader.ChannelId).Send(ctx, signed, cc, doOpts.Pool)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to collect peer responses`)
+	}
+
+	if !doOpts.SkipPolicyCheck {
+		if err = util.CheckEndorsementPolicy(cc.Policy, responses); err != nil {
+			return nil, err
+		}
+	}
+
+	envelope, err := protoutil.CreateSignedTx(proposal, offlineSigner{creator: sigHeader.Creator}, responses...)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get envelope`)
+	}
+	return envelope, nil
+}
+
+// offlineSigner is creator of proposal signed outside of sdk, it leaves transaction envelope unsigned
+type offlineSigner struct {
+	creator []byte
+}
+
+func (s offlineSigner) Sign([]byte) ([]byte, error) {
+	return nil, nil
+}
+
+func (s offlineSigner) Serialize() ([]byte, error) {
+	return s.creator, nil
+}
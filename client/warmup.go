@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/orderer"
+	"github.com/s7techlab/hlf-sdk-go/peer"
+)
+
+func (c *core) WarmUp(ctx context.Context) []api.WarmUpFailure {
+	var (
+		failures []api.WarmUpFailure
+		mx       sync.Mutex
+		wg       sync.WaitGroup
+	)
+
+	fail := func(target string, err error) {
+		mx.Lock()
+		defer mx.Unlock()
+		failures = append(failures, api.WarmUpFailure{Target: target, Err: err})
+	}
+
+	for _, peers := range c.peerPool.Peers() {
+		for _, p := range peers {
+			wg.Add(1)
+			go func(p api.Peer) {
+				defer wg.Done()
+				if err := peer.Probe(ctx, p); err != nil {
+					fail(p.Uri(), err)
+				}
+			}(p)
+		}
+	}
+
+	if c.baseOrderer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := orderer.Probe(ctx, c.baseOrderer); err != nil {
+				fail(`orderer`, err)
+			}
+		}()
+	}
+
+	// orderers of warm up channels are dialed by channel instances
+	for _, channelName := range c.warmUpChannels {
+		c.Channel(channelName)
+		c.channelMx.Lock()
+		chain := c.channelChains[channelName]
+		c.channelMx.Unlock()
+		if chain == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(channelName string) {
+			defer wg.Done()
+			if err := orderer.Probe(ctx, chain); err != nil {
+				fail(`orderer:`+channelName, err)
+			}
+		}(channelName)
+	}
+
+	if dp := c.discovery(); dp != nil {
+		for _, channelName := range c.warmUpChannels {
+			wg.Add(1)
+			go func(channelName string) {
+				defer wg.Done()
+				if _, err := dp.Chaincodes(channelName); err != nil {
+					fail(`discovery:`+channelName, errors.Wrap(err, `failed to get channel chaincodes`))
+				}
+			}(channelName)
+		}
+	}
+
+	wg.Wait()
+	return failures
+}
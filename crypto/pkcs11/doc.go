@@ -0,0 +1,7 @@
+// Package pkcs11 implements crypto suite which keeps private keys in HSM and signs messages through PKCS#11 session.
+// Suite requires PKCS#11 C library and is built only with pkcs11 build tag, without tag package registers nothing.
+//
+// Suite is registered as pkcs11 and is initialized with options:
+// library (path to PKCS#11 library), label (token label) or slot (slot id if label is empty), pin,
+// keyLabel (label of default signing key) and curve, hash, signatureAlgorithm as for ecdsa suite
+package pkcs11
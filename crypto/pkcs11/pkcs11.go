@@ -0,0 +1,365 @@
+//go:build pkcs11
+// +build pkcs11
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"sync"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	sdkcrypto "github.com/s7techlab/hlf-sdk-go/crypto"
+	sdkecdsa "github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+)
+
+const Module = `pkcs11`
+
+func init() {
+	sdkcrypto.Register(Module, &pkcs11Suite{})
+}
+
+var (
+	// curves by names of ecdsa suite curve option
+	curves = map[string]elliptic.Curve{
+		`P256`: elliptic.P256(),
+		`P384`: elliptic.P384(),
+		`P512`: elliptic.P521(),
+	}
+
+	curveOIDs = map[elliptic.Curve]asn1.ObjectIdentifier{
+		elliptic.P256(): {1, 2, 840, 10045, 3, 1, 7},
+		elliptic.P384(): {1, 3, 132, 0, 34},
+		elliptic.P521(): {1, 3, 132, 0, 35},
+	}
+
+	errInvalidPrivateKey = errors.New(`invalid private key, expected PKCS#11 key`)
+	errKeyNotFound       = errors.New(`key not found in token`)
+	errUnknownCurve      = errors.New(`unknown elliptic curve`)
+)
+
+type pkcs11Opts struct {
+	Library            string
+	Label              string
+	Slot               uint
+	Pin                string
+	KeyLabel           string
+	Curve              string
+	SignatureAlgorithm string
+	Hash               string
+}
+
+// PrivateKey is EC private key stored in HSM, it implements crypto.Signer,
+// so it can be used for certificate requests
+type PrivateKey struct {
+	Label     string
+	handle    p11.ObjectHandle
+	publicKey *ecdsa.PublicKey
+	suite     *pkcs11Suite
+}
+
+func (k *PrivateKey) Public() crypto.PublicKey {
+	return k.publicKey
+}
+
+// Sign signs digest in HSM session and returns ASN.1 encoded ECDSA signature
+func (k *PrivateKey) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return k.suite.signDigest(k, digest)
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+type pkcs11Suite struct {
+	// software suite is used for hashing and signature verification
+	software   api.CryptoSuite
+	curve      elliptic.Curve
+	ctx        *p11.Ctx
+	session    p11.SessionHandle
+	defaultKey *PrivateKey
+	// PKCS#11 session must not be used concurrently
+	mx sync.Mutex
+}
+
+func (c *pkcs11Suite) Sign(msg []byte, key interface{}) ([]byte, error) {
+	if key == nil {
+		key = c.defaultKey
+	}
+
+	privateKey, ok := key.(*PrivateKey)
+	if !ok || privateKey == nil {
+		return nil, errInvalidPrivateKey
+	}
+	return c.signDigest(privateKey, c.Hash(msg))
+}
+
+func (c *pkcs11Suite) Verify(publicKey interface{}, msg, sig []byte) error {
+	return c.software.Verify(publicKey, msg, sig)
+}
+
+func (c *pkcs11Suite) Hash(data []byte) []byte {
+	return c.software.Hash(data)
+}
+
+// NewPrivateKey generates EC key pair in token, key label is hex encoded hash of public key
+func (c *pkcs11Suite) NewPrivateKey() (interface{}, error) {
+	ecParams, err := asn1.Marshal(curveOIDs[c.curve])
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to marshal curve params`)
+	}
+
+	tmpLabel, err := sdkcrypto.RandomBytes(16)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to generate key label`)
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	publicHandle, privateHandle, err := c.ctx.GenerateKeyPair(c.session,
+		[]*p11.Mechanism{p11.NewMechanism(p11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*p11.Attribute{
+			p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PUBLIC_KEY),
+			p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_EC),
+			p11.NewAttribute(p11.CKA_TOKEN, true),
+			p11.NewAttribute(p11.CKA_VERIFY, true),
+			p11.NewAttribute(p11.CKA_EC_PARAMS, ecParams),
+			p11.NewAttribute(p11.CKA_LABEL, hex.EncodeToString(tmpLabel)),
+		},
+		[]*p11.Attribute{
+			p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY),
+			p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_EC),
+			p11.NewAttribute(p11.CKA_TOKEN, true),
+			p11.NewAttribute(p11.CKA_PRIVATE, true),
+			p11.NewAttribute(p11.CKA_SIGN, true),
+			p11.NewAttribute(p11.CKA_SENSITIVE, true),
+			p11.NewAttribute(p11.CKA_EXTRACTABLE, false),
+			p11.NewAttribute(p11.CKA_LABEL, hex.EncodeToString(tmpLabel)),
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to generate key pair`)
+	}
+
+	publicKey, err := c.publicKey(publicHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	label := hex.EncodeToString(c.software.Hash(elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)))
+	for _, handle := range []p11.ObjectHandle{publicHandle, privateHandle} {
+		if err = c.ctx.SetAttributeValue(c.session, handle,
+			[]*p11.Attribute{p11.NewAttribute(p11.CKA_LABEL, label)}); err != nil {
+			return nil, errors.Wrap(err, `failed to set key label`)
+		}
+	}
+
+	return &PrivateKey{Label: label, handle: privateHandle, publicKey: publicKey, suite: c}, nil
+}
+
+func (c *pkcs11Suite) GetSignatureAlgorithm() x509.SignatureAlgorithm {
+	return c.software.GetSignatureAlgorithm()
+}
+
+func (c *pkcs11Suite) Initialize(opts config.CryptoSuiteOpts) (api.CryptoSuite, error) {
+	var options pkcs11Opts
+	var err error
+
+	if err = mapstructure.Decode(opts, &options); err != nil {
+		return nil, errors.Wrap(err, `failed to decode PKCS#11 options`)
+	}
+
+	cs := &pkcs11Suite{}
+	if cs.software, err = sdkcrypto.GetSuite(sdkecdsa.Module, config.CryptoSuiteOpts{
+		`curve`: options.Curve, `signatureAlgorithm`: options.SignatureAlgorithm, `hash`: options.Hash}); err != nil {
+		return nil, errors.Wrap(err, `failed to initialize software suite`)
+	}
+
+	var ok bool
+	if cs.curve, ok = curves[options.Curve]; !ok {
+		return nil, errUnknownCurve
+	}
+
+	if cs.ctx = p11.New(options.Library); cs.ctx == nil {
+		return nil, errors.Errorf(`failed to load PKCS#11 library %s`, options.Library)
+	}
+	if err = cs.ctx.Initialize(); err != nil {
+		return nil, errors.Wrap(err, `failed to initialize PKCS#11 library`)
+	}
+
+	slot, err := findSlot(cs.ctx, options.Label, options.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	if cs.session, err = cs.ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION|p11.CKF_RW_SESSION); err != nil {
+		return nil, errors.Wrap(err, `failed to open PKCS#11 session`)
+	}
+
+	if err = cs.ctx.Login(cs.session, p11.CKU_USER, options.Pin); err != nil {
+		if p11Err, ok := err.(p11.Error); !ok || p11Err != p11.CKR_USER_ALREADY_LOGGED_IN {
+			return nil, errors.Wrap(err, `failed to login to token`)
+		}
+	}
+
+	if options.KeyLabel != `` {
+		if cs.defaultKey, err = cs.findKey(options.KeyLabel); err != nil {
+			return nil, errors.Wrapf(err, `failed to find key %s`, options.KeyLabel)
+		}
+	}
+
+	return cs, nil
+}
+
+// FindKey returns private key with presented label from token of suite initialized with pkcs11 module
+func FindKey(cs api.CryptoSuite, label string) (*PrivateKey, error) {
+	suite, ok := cs.(*pkcs11Suite)
+	if !ok {
+		return nil, errors.New(`crypto suite is not PKCS#11`)
+	}
+	return suite.findKey(label)
+}
+
+func (c *pkcs11Suite) findKey(label string) (*PrivateKey, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	privateHandle, err := c.findObject(p11.CKO_PRIVATE_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+
+	publicHandle, err := c.findObject(p11.CKO_PUBLIC_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := c.publicKey(publicHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{Label: label, handle: privateHandle, publicKey: publicKey, suite: c}, nil
+}
+
+func (c *pkcs11Suite) findObject(class uint, label string) (p11.ObjectHandle, error) {
+	if err := c.ctx.FindObjectsInit(c.session, []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, class),
+		p11.NewAttribute(p11.CKA_LABEL, label),
+	}); err != nil {
+		return 0, errors.Wrap(err, `failed to init objects search`)
+	}
+
+	handles, _, err := c.ctx.FindObjects(c.session, 1)
+	if finalErr := c.ctx.FindObjectsFinal(c.session); err == nil && finalErr != nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, `failed to find objects`)
+	}
+
+	if len(handles) == 0 {
+		return 0, errKeyNotFound
+	}
+	return handles[0], nil
+}
+
+// publicKey reads EC public key from token object
+func (c *pkcs11Suite) publicKey(handle p11.ObjectHandle) (*ecdsa.PublicKey, error) {
+	attrs, err := c.ctx.GetAttributeValue(c.session, handle, []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_EC_PARAMS, nil),
+		p11.NewAttribute(p11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get public key attributes`)
+	}
+
+	var curveOID asn1.ObjectIdentifier
+	if _, err = asn1.Unmarshal(attrs[0].Value, &curveOID); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal curve params`)
+	}
+	var curve elliptic.Curve
+	for oidCurve, oid := range curveOIDs {
+		if oid.Equal(curveOID) {
+			curve = oidCurve
+		}
+	}
+	if curve == nil {
+		return nil, errUnknownCurve
+	}
+
+	// EC point is DER encoded octet string with uncompressed point
+	var point []byte
+	if _, err = asn1.Unmarshal(attrs[1].Value, &point); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal EC point`)
+	}
+
+	x, y := elliptic.Unmarshal(curve, point)
+	if x == nil {
+		return nil, errors.New(`invalid EC point`)
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func (c *pkcs11Suite) signDigest(key *PrivateKey, digest []byte) ([]byte, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if err := c.ctx.SignInit(c.session, []*p11.Mechanism{p11.NewMechanism(p11.CKM_ECDSA, nil)}, key.handle); err != nil {
+		return nil, errors.Wrap(err, `failed to init signing`)
+	}
+
+	raw, err := c.ctx.Sign(c.session, digest)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to sign message`)
+	}
+
+	// PKCS#11 ECDSA signature is concatenation of R and S
+	R := new(big.Int).SetBytes(raw[:len(raw)/2])
+	S := new(big.Int).SetBytes(raw[len(raw)/2:])
+
+	// low S value is required by Fabric
+	if halfOrder := new(big.Int).Rsh(key.publicKey.Params().N, 1); S.Cmp(halfOrder) == 1 {
+		S.Sub(key.publicKey.Params().N, S)
+	}
+
+	signature, err := asn1.Marshal(ecdsaSignature{R, S})
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to format asn1 signature`)
+	}
+	return signature, nil
+}
+
+// findSlot returns slot of token with presented label or presented slot id if label is empty
+func findSlot(ctx *p11.Ctx, label string, slotID uint) (uint, error) {
+	if label == `` {
+		return slotID, nil
+	}
+
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, errors.Wrap(err, `failed to get slot list`)
+	}
+
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		if info.Label == label {
+			return slot, nil
+		}
+	}
+	return 0, errors.Errorf(`token with label %s not found`, label)
+}
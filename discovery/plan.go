@@ -0,0 +1,238 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+// Plan is snapshot of discovered channels with chaincodes and their endorsement policies
+type Plan struct {
+	Channels []api.DiscoveryChannel `json:"channels"`
+	// Members are addresses of pool peers by MSP id at the moment plan was discovered
+	Members map[string][]string `json:"members"`
+	// Endorsers are minimal sets of member organizations satisfying chaincode policies by channel and chaincode name
+	Endorsers    map[string]map[string][]string `json:"endorsers"`
+	DiscoveredAt time.Time                      `json:"discovered_at"`
+}
+
+// planEndorsers selects minimal set of member organizations satisfying policy of each chaincode,
+// chaincodes without policy or with policy unsatisfiable by members are skipped
+func planEndorsers(channels []api.DiscoveryChannel, members map[string][]string) map[string]map[string][]string {
+	orgs := make([]string, 0, len(members))
+	for mspID := range members {
+		orgs = append(orgs, mspID)
+	}
+	sort.Strings(orgs)
+
+	endorsers := make(map[string]map[string][]string)
+	for _, ch := range channels {
+		for _, cc := range ch.Chaincodes {
+			if cc.Policy == `` {
+				continue
+			}
+			selected, err := util.SelectEndorsers(cc.Policy, orgs)
+			if err != nil || selected == nil {
+				continue
+			}
+			if endorsers[ch.Name] == nil {
+				endorsers[ch.Name] = make(map[string][]string)
+			}
+			endorsers[ch.Name][cc.Name] = selected
+		}
+	}
+	return endorsers
+}
+
+// SavePlan writes plan to file as JSON
+func SavePlan(path string, plan *Plan) error {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return errors.Wrap(err, `failed to marshal discovery plan`)
+	}
+	if err = ioutil.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrap(err, `failed to write discovery plan`)
+	}
+	return nil
+}
+
+// LoadPlan reads plan written by SavePlan
+func LoadPlan(path string) (*Plan, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to read discovery plan`)
+	}
+	plan := new(Plan)
+	if err = json.Unmarshal(data, plan); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal discovery plan`)
+	}
+	return plan, nil
+}
+
+// poolMembers returns sorted addresses of pool peers by MSP id
+func poolMembers(pool api.PeerPool) map[string][]string {
+	members := make(map[string][]string)
+	for mspID, peers := range pool.Peers() {
+		for _, p := range peers {
+			members[mspID] = append(members[mspID], p.Uri())
+		}
+		sort.Strings(members[mspID])
+	}
+	return members
+}
+
+// CachedProvider serves channels, chaincodes and their endorsers from plan persisted to file, so discovery results are
+// available right after restart. Plan is refreshed from provider by Run and is dropped if pool membership changes,
+// requests without plan are passed to provider
+type CachedProvider struct {
+	provider api.DiscoveryProvider
+	pool     api.PeerPool
+	path     string
+	plan     *Plan
+	mx       sync.Mutex
+}
+
+// NewCachedProvider wraps provider and loads plan from file if it exists
+func NewCachedProvider(provider api.DiscoveryProvider, pool api.PeerPool, path string) (*CachedProvider, error) {
+	cached := &CachedProvider{provider: provider, pool: pool, path: path}
+
+	plan, err := LoadPlan(path)
+	if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return nil, err
+	}
+	cached.plan = plan
+
+	return cached, nil
+}
+
+// current returns plan if pool membership didn't change since it was discovered
+func (p *CachedProvider) current() *Plan {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.plan != nil && !reflect.DeepEqual(p.plan.Members, poolMembers(p.pool)) {
+		p.plan = nil
+	}
+	return p.plan
+}
+
+// Refresh discovers channels by provider and saves plan to file
+func (p *CachedProvider) Refresh() error {
+	members := poolMembers(p.pool)
+
+	channels, err := p.provider.Channels()
+	if err != nil {
+		return errors.Wrap(err, `failed to discover channels`)
+	}
+
+	plan := &Plan{
+		Channels:     channels,
+		Members:      members,
+		Endorsers:    planEndorsers(channels, members),
+		DiscoveredAt: time.Now(),
+	}
+
+	p.mx.Lock()
+	p.plan = plan
+	p.mx.Unlock()
+
+	return SavePlan(p.path, plan)
+}
+
+// Run refreshes plan immediately and then with presented interval until context is done.
+// Failed refresh is logged and leaves previous plan in use
+func (p *CachedProvider) Run(ctx context.Context, interval time.Duration, log *zap.Logger) {
+	refresh := func() {
+		if err := p.Refresh(); err != nil {
+			log.Warn(`Failed to refresh discovery plan`, zap.Error(err))
+		}
+	}
+
+	refresh()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// Endorsers returns organizations which endorsements satisfy chaincode policy according to plan
+func (p *CachedProvider) Endorsers(channelName, ccName string) ([]string, bool) {
+	if plan := p.current(); plan != nil {
+		endorsers, ok := plan.Endorsers[channelName][ccName]
+		return endorsers, ok
+	}
+	return nil, false
+}
+
+func (p *CachedProvider) Initialize(opts config.DiscoveryConfigOpts, pool api.PeerPool) (api.DiscoveryProvider, error) {
+	provider, err := p.provider.Initialize(opts, pool)
+	if err != nil {
+		return nil, err
+	}
+	return NewCachedProvider(provider, pool, p.path)
+}
+
+func (p *CachedProvider) Channels() ([]api.DiscoveryChannel, error) {
+	if plan := p.current(); plan != nil {
+		return plan.Channels, nil
+	}
+	return p.provider.Channels()
+}
+
+func (p *CachedProvider) Channel(channelName string) (*api.DiscoveryChannel, error) {
+	if plan := p.current(); plan != nil {
+		for _, ch := range plan.Channels {
+			if ch.Name == channelName {
+				return &ch, nil
+			}
+		}
+	}
+	return p.provider.Channel(channelName)
+}
+
+func (p *CachedProvider) Chaincode(channelName string, ccName string) (*api.DiscoveryChaincode, error) {
+	if plan := p.current(); plan != nil {
+		for _, ch := range plan.Channels {
+			if ch.Name != channelName {
+				continue
+			}
+			for _, cc := range ch.Chaincodes {
+				if cc.Name == ccName {
+					return &cc, nil
+				}
+			}
+		}
+	}
+	return p.provider.Chaincode(channelName, ccName)
+}
+
+func (p *CachedProvider) Chaincodes(channelName string) ([]api.DiscoveryChaincode, error) {
+	if plan := p.current(); plan != nil {
+		for _, ch := range plan.Channels {
+			if ch.Name == channelName {
+				return ch.Chaincodes, nil
+			}
+		}
+	}
+	return p.provider.Chaincodes(channelName)
+}
@@ -0,0 +1,191 @@
+package discovery_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/discovery"
+)
+
+const testPolicy = `OR(AND('Org1MSP.member', 'Org2MSP.member'), 'Org3MSP.member')`
+
+type testPeer struct {
+	api.Peer
+	uri string
+}
+
+func (p testPeer) Uri() string { return p.uri }
+
+// testPool returns peers which can be changed by test
+type testPool struct {
+	api.PeerPool
+	peers map[string][]api.Peer
+	mx    sync.Mutex
+}
+
+func newTestPool(members map[string]string) *testPool {
+	pool := &testPool{peers: make(map[string][]api.Peer)}
+	for mspID, uri := range members {
+		pool.add(mspID, uri)
+	}
+	return pool
+}
+
+func (p *testPool) add(mspID, uri string) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	p.peers[mspID] = append(p.peers[mspID], testPeer{uri: uri})
+}
+
+func (p *testPool) Peers() map[string][]api.Peer {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	peers := make(map[string][]api.Peer, len(p.peers))
+	for mspID, mspPeers := range p.peers {
+		peers[mspID] = append([]api.Peer(nil), mspPeers...)
+	}
+	return peers
+}
+
+// testProvider counts discoveries and fails while err is set
+type testProvider struct {
+	api.DiscoveryProvider
+	channels []api.DiscoveryChannel
+	calls    int32
+	err      error
+	mx       sync.Mutex
+}
+
+func (p *testProvider) setErr(err error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	p.err = err
+}
+
+func (p *testProvider) Channels() ([]api.DiscoveryChannel, error) {
+	atomic.AddInt32(&p.calls, 1)
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.channels, nil
+}
+
+func (p *testProvider) Chaincodes(string) ([]api.DiscoveryChaincode, error) {
+	atomic.AddInt32(&p.calls, 1)
+	return nil, nil
+}
+
+func testChannels() []api.DiscoveryChannel {
+	return []api.DiscoveryChannel{{
+		Name:       `channel`,
+		Chaincodes: []api.DiscoveryChaincode{{Name: `cc`, Policy: testPolicy}},
+	}}
+}
+
+func TestSaveLoadPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `plan.json`)
+	plan := &discovery.Plan{
+		Channels:     testChannels(),
+		Members:      map[string][]string{`Org3MSP`: {`peer0.org3:7051`}},
+		Endorsers:    map[string]map[string][]string{`channel`: {`cc`: {`Org3MSP`}}},
+		DiscoveredAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	require.NoError(t, discovery.SavePlan(path, plan))
+
+	loaded, err := discovery.LoadPlan(path)
+	require.NoError(t, err)
+	require.Equal(t, plan, loaded)
+}
+
+func TestCachedProvider(t *testing.T) {
+	t.Run(`plan is loaded from file`, func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), `plan.json`)
+		pool := newTestPool(map[string]string{`Org3MSP`: `peer0.org3:7051`})
+		provider := &testProvider{channels: testChannels()}
+
+		cached, err := discovery.NewCachedProvider(provider, pool, path)
+		require.NoError(t, err)
+		require.NoError(t, cached.Refresh())
+
+		// new instance serves plan without discovery
+		restarted, err := discovery.NewCachedProvider(&testProvider{}, pool, path)
+		require.NoError(t, err)
+		channels, err := restarted.Channels()
+		require.NoError(t, err)
+		require.Equal(t, testChannels(), channels)
+
+		endorsers, ok := restarted.Endorsers(`channel`, `cc`)
+		require.True(t, ok)
+		require.Equal(t, []string{`Org3MSP`}, endorsers)
+	})
+
+	t.Run(`plan is dropped on membership change`, func(t *testing.T) {
+		pool := newTestPool(map[string]string{`Org3MSP`: `peer0.org3:7051`})
+		provider := &testProvider{channels: testChannels()}
+
+		cached, err := discovery.NewCachedProvider(provider, pool, filepath.Join(t.TempDir(), `plan.json`))
+		require.NoError(t, err)
+		require.NoError(t, cached.Refresh())
+
+		_, err = cached.Chaincodes(`channel`)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), atomic.LoadInt32(&provider.calls))
+
+		pool.add(`Org1MSP`, `peer0.org1:7051`)
+
+		_, ok := cached.Endorsers(`channel`, `cc`)
+		require.False(t, ok)
+		// requests are passed to provider until plan is refreshed
+		_, err = cached.Chaincodes(`channel`)
+		require.NoError(t, err)
+		require.Equal(t, int32(2), atomic.LoadInt32(&provider.calls))
+	})
+
+	t.Run(`run refreshes plan until context is done`, func(t *testing.T) {
+		pool := newTestPool(map[string]string{`Org3MSP`: `peer0.org3:7051`})
+		provider := &testProvider{channels: testChannels()}
+		provider.setErr(errors.New(`discovery failed`))
+
+		cached, err := discovery.NewCachedProvider(provider, pool, filepath.Join(t.TempDir(), `plan.json`))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			cached.Run(ctx, 10*time.Millisecond, zap.NewNop())
+			close(done)
+		}()
+
+		// failed refresh leaves provider without plan
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&provider.calls) > 1 }, time.Second, 5*time.Millisecond)
+		_, ok := cached.Endorsers(`channel`, `cc`)
+		require.False(t, ok)
+
+		provider.setErr(nil)
+		require.Eventually(t, func() bool {
+			_, ok := cached.Endorsers(`channel`, `cc`)
+			return ok
+		}, time.Second, 5*time.Millisecond)
+
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal(`run is not stopped`)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package discovery
+
+import (
+	"sync"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+)
+
+// StaticProvider serves channels and chaincodes registered programmatically, e.g. from service registry.
+// Registrations can be updated concurrently with discovery requests
+type StaticProvider struct {
+	channels []api.DiscoveryChannel
+	mx       sync.RWMutex
+}
+
+func NewStaticProvider() *StaticProvider {
+	return &StaticProvider{}
+}
+
+// SetChannel registers channel with presented orderers, orderers of already registered channel are replaced
+// and its chaincodes are kept
+func (p *StaticProvider) SetChannel(channelName string, orderers ...config.ConnectionConfig) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	ch := p.channel(channelName)
+	ch.Orderers = append([]config.ConnectionConfig(nil), orderers...)
+}
+
+// RemoveChannel removes channel with its chaincodes
+func (p *StaticProvider) RemoveChannel(channelName string) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	for i, ch := range p.channels {
+		if ch.Name == channelName {
+			p.channels = append(p.channels[:i:i], p.channels[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetChaincode registers chaincode on channel, channel is registered without orderers if it doesn't exist.
+// Registered chaincode with the same name is replaced
+func (p *StaticProvider) SetChaincode(channelName string, cc api.DiscoveryChaincode) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	ch := p.channel(channelName)
+	chaincodes := make([]api.DiscoveryChaincode, 0, len(ch.Chaincodes)+1)
+	for _, existing := range ch.Chaincodes {
+		if existing.Name != cc.Name {
+			chaincodes = append(chaincodes, existing)
+		}
+	}
+	ch.Chaincodes = append(chaincodes, cc)
+}
+
+// RemoveChaincode removes chaincode from channel
+func (p *StaticProvider) RemoveChaincode(channelName, ccName string) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	for i := range p.channels {
+		if p.channels[i].Name != channelName {
+			continue
+		}
+		var chaincodes []api.DiscoveryChaincode
+		for _, cc := range p.channels[i].Chaincodes {
+			if cc.Name != ccName {
+				chaincodes = append(chaincodes, cc)
+			}
+		}
+		p.channels[i].Chaincodes = chaincodes
+	}
+}
+
+// channel returns registered channel, registering it if it doesn't exist. Must be called under lock
+func (p *StaticProvider) channel(channelName string) *api.DiscoveryChannel {
+	for i := range p.channels {
+		if p.channels[i].Name == channelName {
+			return &p.channels[i]
+		}
+	}
+	p.channels = append(p.channels, api.DiscoveryChannel{Name: channelName})
+	return &p.channels[len(p.channels)-1]
+}
+
+// Initialize returns provider itself, options are not used as channels are registered programmatically
+func (p *StaticProvider) Initialize(config.DiscoveryConfigOpts, api.PeerPool) (api.DiscoveryProvider, error) {
+	return p, nil
+}
+
+func (p *StaticProvider) Channels() ([]api.DiscoveryChannel, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	if len(p.channels) == 0 {
+		return nil, ErrNoChannels
+	}
+	return append([]api.DiscoveryChannel(nil), p.channels...), nil
+}
+
+func (p *StaticProvider) Channel(channelName string) (*api.DiscoveryChannel, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	for _, ch := range p.channels {
+		if ch.Name == channelName {
+			return &ch, nil
+		}
+	}
+	return nil, ErrChannelNotFound
+}
+
+func (p *StaticProvider) Chaincode(channelName string, ccName string) (*api.DiscoveryChaincode, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	for _, ch := range p.channels {
+		if ch.Name != channelName {
+			continue
+		}
+		for _, cc := range ch.Chaincodes {
+			if cc.Name == ccName {
+				return &cc, nil
+			}
+		}
+		return nil, ErrNoChaincodes
+	}
+	return nil, ErrChannelNotFound
+}
+
+func (p *StaticProvider) Chaincodes(channelName string) ([]api.DiscoveryChaincode, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	for _, ch := range p.channels {
+		if ch.Name == channelName {
+			return ch.Chaincodes, nil
+		}
+	}
+	return nil, ErrChannelNotFound
+}
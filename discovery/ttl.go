@@ -0,0 +1,112 @@
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+)
+
+// TTLProvider memoizes results of provider for ttl, errors are not memoized
+type TTLProvider struct {
+	provider api.DiscoveryProvider
+	ttl      time.Duration
+	entries  map[string]ttlEntry
+	mx       sync.Mutex
+}
+
+type ttlEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewTTLProvider wraps provider, so its results are cached for ttl
+func NewTTLProvider(provider api.DiscoveryProvider, ttl time.Duration) *TTLProvider {
+	return &TTLProvider{provider: provider, ttl: ttl, entries: make(map[string]ttlEntry)}
+}
+
+// Refresh drops all cached results, so next requests are passed to provider
+func (p *TTLProvider) Refresh() {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	p.entries = make(map[string]ttlEntry)
+}
+
+// Endorsers passes request to provider if it keeps endorsement plan, plan is kept by provider itself
+func (p *TTLProvider) Endorsers(channelName, ccName string) ([]string, bool) {
+	if planner, ok := p.provider.(api.EndorsersPlanner); ok {
+		return planner.Endorsers(channelName, ccName)
+	}
+	return nil, false
+}
+
+// get returns cached value by key or calls fetch and caches its result
+func (p *TTLProvider) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	p.mx.Lock()
+	entry, ok := p.entries[key]
+	p.mx.Unlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mx.Lock()
+	p.entries[key] = ttlEntry{value: value, expiresAt: time.Now().Add(p.ttl)}
+	p.mx.Unlock()
+
+	return value, nil
+}
+
+func (p *TTLProvider) Initialize(opts config.DiscoveryConfigOpts, pool api.PeerPool) (api.DiscoveryProvider, error) {
+	provider, err := p.provider.Initialize(opts, pool)
+	if err != nil {
+		return nil, err
+	}
+	return NewTTLProvider(provider, p.ttl), nil
+}
+
+func (p *TTLProvider) Channels() ([]api.DiscoveryChannel, error) {
+	value, err := p.get(`channels`, func() (interface{}, error) {
+		return p.provider.Channels()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]api.DiscoveryChannel), nil
+}
+
+func (p *TTLProvider) Channel(channelName string) (*api.DiscoveryChannel, error) {
+	value, err := p.get(`channel/`+channelName, func() (interface{}, error) {
+		return p.provider.Channel(channelName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*api.DiscoveryChannel), nil
+}
+
+func (p *TTLProvider) Chaincode(channelName string, ccName string) (*api.DiscoveryChaincode, error) {
+	value, err := p.get(`chaincode/`+channelName+`/`+ccName, func() (interface{}, error) {
+		return p.provider.Chaincode(channelName, ccName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*api.DiscoveryChaincode), nil
+}
+
+func (p *TTLProvider) Chaincodes(channelName string) ([]api.DiscoveryChaincode, error) {
+	value, err := p.get(`chaincodes/`+channelName, func() (interface{}, error) {
+		return p.provider.Chaincodes(channelName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]api.DiscoveryChaincode), nil
+}
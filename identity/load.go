@@ -0,0 +1,60 @@
+package identity
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+const (
+	signCertsDir = `signcerts`
+	keyStoreDir  = `keystore`
+)
+
+// FromMSPPath loads identity from Fabric MSP folder: certificate from signcerts and PKCS#8 private key from keystore.
+// Each directory must contain exactly one file, file names are not significant
+func FromMSPPath(mspDir string, mspID string) (api.Identity, error) {
+	certBytes, err := readSingleFile(filepath.Join(mspDir, signCertsDir))
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to read certificate`)
+	}
+
+	keyBytes, err := readSingleFile(filepath.Join(mspDir, keyStoreDir))
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to read private key`)
+	}
+
+	return FromBytes(certBytes, keyBytes, mspID)
+}
+
+// FromBytes creates identity from PEM encoded certificate and PKCS#8 private key
+func FromBytes(certPEM []byte, keyPEM []byte, mspID string) (api.Identity, error) {
+	return NewMSPIdentityBytes(mspID, certPEM, keyPEM)
+}
+
+// readSingleFile returns content of the only regular file in directory
+func readSingleFile(dir string) ([]byte, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, info := range infos {
+		if info.Mode().IsRegular() {
+			files = append(files, info.Name())
+		}
+	}
+
+	switch len(files) {
+	case 0:
+		return nil, errors.Errorf(`no files found in %s`, dir)
+	case 1:
+		return ioutil.ReadFile(filepath.Join(dir, files[0]))
+	default:
+		return nil, errors.Errorf(`%d files found in %s, expected exactly one: %v`, len(files), dir, files)
+	}
+}
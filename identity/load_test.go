@@ -0,0 +1,50 @@
+package identity_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/crypto"
+	"github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+	"github.com/s7techlab/hlf-sdk-go/identity"
+)
+
+const testMSPPath = `../client/chaincode/testdata/msp`
+
+func TestFromMSPPath(t *testing.T) {
+	id, err := identity.FromMSPPath(testMSPPath, `org1msp`)
+	require.NoError(t, err)
+
+	cryptoSuite, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	signer := id.GetSigningIdentity(cryptoSuite)
+	require.Equal(t, `org1msp`, signer.GetMSPIdentifier())
+
+	sig, err := signer.Sign([]byte(`msg`))
+	require.NoError(t, err)
+	require.NoError(t, signer.Verify([]byte(`msg`), sig))
+}
+
+func TestFromMSPPathMultipleKeys(t *testing.T) {
+	dir, err := ioutil.TempDir(``, `msp`)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certBytes, err := ioutil.ReadFile(filepath.Join(testMSPPath, `signcerts`, `cert.pem`))
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, `signcerts`), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, `keystore`), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, `signcerts`, `user.pem`), certBytes, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, `keystore`, `key1`), []byte(`key`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, `keystore`, `key2`), []byte(`key`), 0600))
+
+	_, err = identity.FromMSPPath(dir, `org1msp`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `expected exactly one`)
+}
@@ -29,10 +29,10 @@ type mspSigningIdentity struct {
 }
 
 func (s *mspIdentity) GetSigningIdentity(cs api.CryptoSuite) msp.SigningIdentity {
-	id := new(mspSigningIdentity)
-	id = s.signingIdentity
+	// copy identity, so signing identities with different crypto suites can be used simultaneously
+	id := *s.signingIdentity
 	id.cryptoSuite = cs
-	return id
+	return &id
 }
 
 func (s *mspSigningIdentity) Anonymous() bool {
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
+)
+
+// breakerCollector reads circuit breaker states of pool peers and watched orderers on scrape
+type breakerCollector struct {
+	desc     *prometheus.Desc
+	pool     api.PeerPool
+	orderers map[string]*breaker.Breaker
+	mx       sync.RWMutex
+}
+
+func (c *breakerCollector) setPool(pool api.PeerPool) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.pool = pool
+}
+
+func (c *breakerCollector) watchOrderer(address string, b *breaker.Breaker) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.orderers == nil {
+		c.orderers = make(map[string]*breaker.Breaker)
+	}
+	c.orderers[address] = b
+}
+
+func (c *breakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *breakerCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	for address, b := range c.orderers {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(b.State()), `orderer`, ``, address)
+	}
+
+	if c.pool == nil {
+		return
+	}
+	for mspID, peers := range c.pool.Peers() {
+		for _, p := range peers {
+			if bp, ok := p.(interface{ BreakerState() breaker.State }); ok {
+				ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(bp.BreakerState()), `peer`, mspID, p.Uri())
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Package metrics allows to collect Prometheus metrics of peer endorsements, orderer broadcasts and peer pool state
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
+)
+
+const (
+	Namespace = `hlf_sdk`
+
+	StatusSuccess = `success`
+	StatusFailure = `failure`
+)
+
+// Metrics holds collectors registered by New, peers and orderers wrapped by Metrics report to them
+type Metrics struct {
+	endorseDuration   *prometheus.HistogramVec
+	endorseTotal      *prometheus.CounterVec
+	broadcastDuration prometheus.Histogram
+	broadcastTotal    *prometheus.CounterVec
+	pool              *poolCollector
+	breakers          *breakerCollector
+}
+
+// New creates collectors and registers them with registerer
+func New(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		endorseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: `peer`,
+			Name:      `endorse_duration_seconds`,
+			Help:      `Duration of proposal endorsements by peer`,
+			Buckets:   prometheus.DefBuckets,
+		}, []string{`msp_id`, `peer`}),
+		endorseTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: `peer`,
+			Name:      `endorse_total`,
+			Help:      `Count of proposal endorsements by peer and status`,
+		}, []string{`msp_id`, `peer`, `status`}),
+		broadcastDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: `orderer`,
+			Name:      `broadcast_duration_seconds`,
+			Help:      `Duration of envelope broadcasts to orderer`,
+			Buckets:   prometheus.DefBuckets,
+		}),
+		broadcastTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: `orderer`,
+			Name:      `broadcast_total`,
+			Help:      `Count of envelope broadcasts to orderer by status`,
+		}, []string{`status`}),
+		pool: &poolCollector{
+			desc: prometheus.NewDesc(prometheus.BuildFQName(Namespace, `pool`, `ready_peers`),
+				`Count of ready pool peers by MSP`, []string{`msp_id`}, nil),
+		},
+		breakers: &breakerCollector{
+			desc: prometheus.NewDesc(prometheus.BuildFQName(Namespace, ``, `circuit_breaker_state`),
+				`State of circuit breaker of peer or orderer: 0 - closed, 1 - open, 2 - half-open`,
+				[]string{`target`, `msp_id`, `address`}, nil),
+		},
+	}
+
+	for _, collector := range []prometheus.Collector{
+		m.endorseDuration, m.endorseTotal, m.broadcastDuration, m.broadcastTotal, m.pool, m.breakers} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Peer wraps peer of presented MSP, so its endorsements are measured
+func (m *Metrics) Peer(mspID string, peer api.Peer) api.Peer {
+	return &metricsPeer{Peer: peer, metrics: m, mspID: mspID}
+}
+
+// Orderer wraps orderer, so its broadcasts are measured
+func (m *Metrics) Orderer(orderer api.Orderer) api.Orderer {
+	return &metricsOrderer{Orderer: orderer, metrics: m}
+}
+
+// WatchPool reports count of ready peers of pool and states of circuit breakers of its peers on each scrape
+func (m *Metrics) WatchPool(pool api.PeerPool) {
+	m.pool.setPool(pool)
+	m.breakers.setPool(pool)
+}
+
+// WatchOrdererBreaker reports state of circuit breaker of orderer endpoint on each scrape
+func (m *Metrics) WatchOrdererBreaker(address string, b *breaker.Breaker) {
+	m.breakers.watchOrderer(address, b)
+}
+
+func status(err error) string {
+	if err != nil {
+		return StatusFailure
+	}
+	return StatusSuccess
+}
+
+type metricsPeer struct {
+	api.Peer
+	metrics *Metrics
+	mspID   string
+}
+
+func (p *metricsPeer) Endorse(ctx context.Context, proposal *fabricPeer.SignedProposal, opts ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	started := time.Now()
+	resp, err := p.Peer.Endorse(ctx, proposal, opts...)
+
+	p.metrics.endorseDuration.WithLabelValues(p.mspID, p.Uri()).Observe(time.Since(started).Seconds())
+	p.metrics.endorseTotal.WithLabelValues(p.mspID, p.Uri(), status(err)).Inc()
+	return resp, err
+}
+
+type metricsOrderer struct {
+	api.Orderer
+	metrics *Metrics
+}
+
+func (o *metricsOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	started := time.Now()
+	resp, err := o.Orderer.Broadcast(ctx, envelope)
+
+	o.metrics.broadcastDuration.Observe(time.Since(started).Seconds())
+	broadcastStatus := status(err)
+	if err == nil && resp.GetStatus() != common.Status_SUCCESS {
+		broadcastStatus = StatusFailure
+	}
+	o.metrics.broadcastTotal.WithLabelValues(broadcastStatus).Inc()
+	return resp, err
+}
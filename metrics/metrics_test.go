@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
+)
+
+type testPeer struct {
+	api.Peer
+	err error
+}
+
+func (p *testPeer) Endorse(context.Context, *fabricPeer.SignedProposal, ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	return &fabricPeer.ProposalResponse{}, p.err
+}
+
+func (p *testPeer) Uri() string {
+	return `peer0:7051`
+}
+
+type testOrderer struct {
+	api.Orderer
+	status common.Status
+}
+
+func (o *testOrderer) Broadcast(context.Context, *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	return &fabricOrderer.BroadcastResponse{Status: o.status}, nil
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	m, err := New(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	_, err = m.Peer(`Org1MSP`, &testPeer{}).Endorse(ctx, &fabricPeer.SignedProposal{})
+	require.NoError(t, err)
+	_, err = m.Peer(`Org1MSP`, &testPeer{err: errors.New(`unavailable`)}).Endorse(ctx, &fabricPeer.SignedProposal{})
+	require.Error(t, err)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.endorseTotal.WithLabelValues(`Org1MSP`, `peer0:7051`, StatusSuccess)))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.endorseTotal.WithLabelValues(`Org1MSP`, `peer0:7051`, StatusFailure)))
+
+	_, err = m.Orderer(&testOrderer{status: common.Status_SERVICE_UNAVAILABLE}).Broadcast(ctx, &common.Envelope{})
+	require.NoError(t, err)
+	require.Equal(t, float64(1), testutil.ToFloat64(m.broadcastTotal.WithLabelValues(StatusFailure)))
+}
+
+func TestNewDuplicateRegistration(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	_, err := New(registry)
+	require.NoError(t, err)
+	_, err = New(registry)
+	require.Error(t, err)
+}
+
+func TestOrdererBreakerState(t *testing.T) {
+	m, err := New(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	b := breaker.New(breaker.Config{Cooldown: time.Hour})
+	m.WatchOrdererBreaker(`orderer0:7050`, b)
+	b.Failure()
+	require.NoError(t, testutil.CollectAndCompare(m.breakers, strings.NewReader(`
+# HELP hlf_sdk_circuit_breaker_state State of circuit breaker of peer or orderer: 0 - closed, 1 - open, 2 - half-open
+# TYPE hlf_sdk_circuit_breaker_state gauge
+hlf_sdk_circuit_breaker_state{address="orderer0:7050",msp_id="",target="orderer"} 1
+`)))
+}
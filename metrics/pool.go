@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// poolCollector reads state of pool peers on scrape, so pool isn't observed while metrics aren't collected
+type poolCollector struct {
+	desc *prometheus.Desc
+	pool api.PeerPool
+	mx   sync.RWMutex
+}
+
+func (c *poolCollector) setPool(pool api.PeerPool) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.pool = pool
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mx.RLock()
+	pool := c.pool
+	c.mx.RUnlock()
+
+	if pool == nil {
+		return
+	}
+
+	for mspID, peers := range pool.Status() {
+		ready := 0
+		for _, p := range peers {
+			if p.Ready {
+				ready++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(ready), mspID)
+	}
+}
@@ -0,0 +1,73 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"google.golang.org/grpc"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
+)
+
+type breakerOrderer struct {
+	api.Orderer
+	breaker *breaker.Breaker
+}
+
+// WithCircuitBreaker wraps orderer with circuit breaker, requests fail fast with api.ErrCircuitOpen while breaker is open.
+// Only connection errors and unavailable ordering service are counted as failures, envelope rejections like BAD_REQUEST
+// or FORBIDDEN and requests aborted by caller are not
+func WithCircuitBreaker(orderer api.Orderer, config breaker.Config) api.Orderer {
+	return WithBreaker(orderer, breaker.New(config))
+}
+
+// WithBreaker wraps orderer with presented circuit breaker, so breaker state is kept
+// when orderer of the same endpoint is dialed again
+func WithBreaker(orderer api.Orderer, b *breaker.Breaker) api.Orderer {
+	return &breakerOrderer{Orderer: orderer, breaker: b}
+}
+
+func (o *breakerOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	if !o.breaker.Allow() {
+		return nil, api.ErrCircuitOpen
+	}
+
+	resp, err := o.Orderer.Broadcast(ctx, envelope)
+	o.register(ctx, err)
+	return resp, err
+}
+
+func (o *breakerOrderer) Deliver(ctx context.Context, envelope *common.Envelope) (*common.Block, error) {
+	if !o.breaker.Allow() {
+		return nil, api.ErrCircuitOpen
+	}
+
+	block, err := o.Orderer.Deliver(ctx, envelope)
+	o.register(ctx, err)
+	return block, err
+}
+
+func (o *breakerOrderer) register(ctx context.Context, err error) {
+	// request aborted by caller or rejected by orderer doesn't indicate orderer failure
+	if err != nil && ctx.Err() == nil && (IsTransientStatus(err) || !errors.As(err, &api.OrdererError{})) {
+		o.breaker.Failure()
+	} else {
+		o.breaker.Success()
+	}
+}
+
+// Conn returns GRPC connection of wrapped orderer, nil if it doesn't expose connection
+func (o *breakerOrderer) Conn() *grpc.ClientConn {
+	if connOrderer, ok := o.Orderer.(interface{ Conn() *grpc.ClientConn }); ok {
+		return connOrderer.Conn()
+	}
+	return nil
+}
+
+// BreakerState returns current state of orderer circuit breaker
+func (o *breakerOrderer) BreakerState() breaker.State {
+	return o.breaker.State()
+}
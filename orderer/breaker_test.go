@@ -0,0 +1,69 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	config := breaker.Config{Threshold: 1, Cooldown: time.Hour}
+
+	t.Run(`rejected envelope`, func(t *testing.T) {
+		ord := WithCircuitBreaker(&testOrderer{err: &ErrUnexpectedStatus{status: common.Status_BAD_REQUEST}}, config)
+		_, err := ord.Broadcast(ctx, &common.Envelope{})
+		require.Error(t, err)
+		require.Equal(t, breaker.Closed, ord.(*breakerOrderer).BreakerState())
+	})
+
+	t.Run(`cancelled call`, func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		ord := WithCircuitBreaker(&testOrderer{err: context.Canceled}, config)
+		_, err := ord.Broadcast(cancelled, &common.Envelope{})
+		require.Error(t, err)
+		require.Equal(t, breaker.Closed, ord.(*breakerOrderer).BreakerState())
+	})
+
+	t.Run(`unavailable orderer`, func(t *testing.T) {
+		for _, unavailable := range []error{
+			status.Error(codes.Unavailable, `connection refused`),
+			&ErrUnexpectedStatus{status: common.Status_SERVICE_UNAVAILABLE},
+		} {
+			ord := WithCircuitBreaker(&testOrderer{err: unavailable}, config)
+			_, err := ord.Broadcast(ctx, &common.Envelope{})
+			require.Error(t, err)
+			require.Equal(t, breaker.Open, ord.(*breakerOrderer).BreakerState())
+
+			_, err = ord.Broadcast(ctx, &common.Envelope{})
+			require.True(t, errors.Is(err, api.ErrCircuitOpen))
+		}
+	})
+
+	t.Run(`multi skips open endpoint`, func(t *testing.T) {
+		failed := &testOrderer{err: status.Error(codes.Unavailable, `connection refused`)}
+		healthy := &testOrderer{}
+		failedBreaker := WithCircuitBreaker(failed, config)
+		// open circuit of failed endpoint
+		_, _ = failedBreaker.Broadcast(ctx, &common.Envelope{})
+
+		multi, err := NewMulti([]api.Orderer{failedBreaker, WithCircuitBreaker(healthy, config)}, 1, 0)
+		require.NoError(t, err)
+		for i := 0; i < 4; i++ {
+			_, err = multi.Broadcast(ctx, &common.Envelope{})
+			require.NoError(t, err)
+		}
+		require.Equal(t, 1, failed.calls)
+		require.Equal(t, 4, healthy.calls)
+	})
+}
@@ -0,0 +1,212 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"go.uber.org/zap"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// ChainLink is source of orderer in fallback chain, orderer is dialed on first use and is dialed again
+// after endpoint failure, so actual endpoints are picked up, e.g. after consenters change
+type ChainLink struct {
+	Source string
+	Dial   func(ctx context.Context) (api.Orderer, error)
+	// Shared orderer is owned by caller, so it isn't closed by chain
+	Shared bool
+}
+
+// dialedLink is orderer of link, done is closed when dial is completed
+type dialedLink struct {
+	ord  api.Orderer
+	err  error
+	done chan struct{}
+}
+
+type chainOrderer struct {
+	links  []ChainLink
+	dialed []*dialedLink
+	closed bool
+	mx     sync.Mutex
+	log    *zap.Logger
+}
+
+// NewChain returns orderer which sends each request to orderer of first link. If orderer can't be dialed
+// or request fails with endpoint failure (see IsEndpointFailure), request is sent to orderer of next link
+func NewChain(links []ChainLink, log *zap.Logger) (api.Orderer, error) {
+	if len(links) == 0 {
+		return nil, errors.New(`orderer chain is empty`)
+	}
+	return &chainOrderer{links: links, dialed: make([]*dialedLink, len(links)), log: log}, nil
+}
+
+func (o *chainOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (resp *fabricOrderer.BroadcastResponse, err error) {
+	err = o.do(ctx, func(ord api.Orderer) error {
+		resp, err = ord.Broadcast(ctx, envelope)
+		return err
+	})
+	return resp, err
+}
+
+func (o *chainOrderer) Deliver(ctx context.Context, envelope *common.Envelope) (block *common.Block, err error) {
+	err = o.do(ctx, func(ord api.Orderer) error {
+		block, err = ord.Deliver(ctx, envelope)
+		return err
+	})
+	return block, err
+}
+
+func (o *chainOrderer) do(ctx context.Context, request func(ord api.Orderer) error) error {
+	var lastErr error
+	for i, link := range o.links {
+		ord, err := o.orderer(ctx, i)
+		if errors.Is(err, api.ErrClientClosed) {
+			return err
+		}
+		if err == nil {
+			if err = request(ord); err == nil {
+				o.log.Debug(`Orderer is used`, zap.String(`source`, link.Source), zap.Int(`position`, i))
+				return nil
+			}
+			if !IsEndpointFailure(err) {
+				return err
+			}
+			o.drop(i, ord)
+		}
+
+		lastErr = fmt.Errorf(`orderer source %s: %w`, link.Source, err)
+		if ctx.Err() != nil {
+			return lastErr
+		}
+		if i < len(o.links)-1 {
+			o.log.Warn(`Orderer source failed, falling back to next one`,
+				zap.String(`source`, link.Source), zap.String(`next`, o.links[i+1].Source), zap.Error(err))
+		}
+	}
+	return lastErr
+}
+
+// Probe dials links in order until orderer of some link becomes ready, error of last link is returned if none is ready
+func (o *chainOrderer) Probe(ctx context.Context) error {
+	var lastErr error
+	for i, link := range o.links {
+		ord, err := o.orderer(ctx, i)
+		if err == nil {
+			if err = Probe(ctx, ord); err == nil {
+				return nil
+			}
+		}
+		if errors.Is(err, api.ErrClientClosed) {
+			return err
+		}
+
+		lastErr = fmt.Errorf(`orderer source %s: %w`, link.Source, err)
+		if ctx.Err() != nil {
+			return lastErr
+		}
+	}
+	return lastErr
+}
+
+// Close closes dialed orderers, links are not dialed after close
+func (o *chainOrderer) Close() error {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+
+	var owned []api.Orderer
+	for i, d := range o.dialed {
+		// orderers which are being dialed are closed by dialing request
+		if d != nil && d.ord != nil && !o.links[i].Shared {
+			owned = append(owned, d.ord)
+		}
+	}
+	return closeAll(owned...)
+}
+
+// orderer returns orderer of link. Link is dialed by single request, concurrent requests wait for dial result,
+// failed dial isn't cached
+func (o *chainOrderer) orderer(ctx context.Context, i int) (api.Orderer, error) {
+	o.mx.Lock()
+	if o.closed {
+		o.mx.Unlock()
+		return nil, api.ErrClientClosed
+	}
+	if d := o.dialed[i]; d != nil {
+		o.mx.Unlock()
+		select {
+		case <-d.done:
+			return d.ord, d.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	d := &dialedLink{done: make(chan struct{})}
+	o.dialed[i] = d
+	o.mx.Unlock()
+
+	ord, err := o.links[i].Dial(ctx)
+
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	defer close(d.done)
+
+	switch {
+	case err != nil:
+		d.err = fmt.Errorf(`dial: %w`, err)
+		o.dialed[i] = nil
+	case o.closed:
+		if !o.links[i].Shared {
+			_ = ord.Close()
+		}
+		d.err = api.ErrClientClosed
+	default:
+		d.ord = ord
+	}
+	return d.ord, d.err
+}
+
+// drop removes failed orderer of link, so link is dialed again by next request
+func (o *chainOrderer) drop(i int, failed api.Orderer) {
+	o.mx.Lock()
+	d := o.dialed[i]
+	if d == nil || d.ord != failed {
+		// orderer is already replaced by concurrent request
+		o.mx.Unlock()
+		return
+	}
+	o.dialed[i] = nil
+	o.mx.Unlock()
+
+	if !o.links[i].Shared {
+		if err := failed.Close(); err != nil {
+			o.log.Debug(`Failed to close dropped orderer`, zap.String(`source`, o.links[i].Source), zap.Error(err))
+		}
+	}
+}
+
+// IsEndpointFailure reports whether error is connection failure or status returned by orderer
+// which doesn't serve channel, e.g. after removal from raft cluster
+func IsEndpointFailure(err error) bool {
+	var statusErr *ErrUnexpectedStatus
+	if !errors.As(err, &statusErr) {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) &&
+			!errors.Is(err, api.ErrClientClosed)
+	}
+
+	switch statusErr.Status() {
+	case common.Status_NOT_FOUND, common.Status_FORBIDDEN, common.Status_SERVICE_UNAVAILABLE:
+		return true
+	}
+	return false
+}
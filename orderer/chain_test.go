@@ -0,0 +1,84 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+type testOrderer struct {
+	api.Orderer
+	err    error
+	calls  int
+	closed bool
+}
+
+func (o *testOrderer) Close() error {
+	o.closed = true
+	return nil
+}
+
+func (o *testOrderer) Broadcast(context.Context, *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	o.calls++
+	if o.err != nil {
+		return nil, o.err
+	}
+	return &fabricOrderer.BroadcastResponse{Status: common.Status_SUCCESS}, nil
+}
+
+func link(source string, ord api.Orderer, dialErr error) ChainLink {
+	return ChainLink{Source: source, Dial: func(context.Context) (api.Orderer, error) {
+		return ord, dialErr
+	}}
+}
+
+func TestChainFallback(t *testing.T) {
+	ctx := context.Background()
+	unreachable := &testOrderer{err: errors.New(`connection refused`)}
+	fallback := &testOrderer{}
+
+	var dials int
+	config := link(`config`, unreachable, nil)
+	dial := config.Dial
+	config.Dial = func(ctx context.Context) (api.Orderer, error) {
+		dials++
+		return dial(ctx)
+	}
+
+	chain, err := NewChain([]ChainLink{
+		link(`discovery`, nil, errors.New(`no endpoints`)),
+		config,
+		link(`channel_config`, fallback, nil),
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = chain.Broadcast(ctx, &common.Envelope{})
+	require.NoError(t, err)
+	require.Equal(t, 1, unreachable.calls)
+	require.Equal(t, 1, fallback.calls)
+	// failed orderer is closed and dialed again by next request
+	require.True(t, unreachable.closed)
+
+	_, err = chain.Broadcast(ctx, &common.Envelope{})
+	require.NoError(t, err)
+	require.Equal(t, 2, dials)
+	require.Equal(t, 2, fallback.calls)
+
+	// rejected request isn't repeated on next orderer
+	unreachable.err = &ErrUnexpectedStatus{status: common.Status_BAD_REQUEST}
+	_, err = chain.Broadcast(ctx, &common.Envelope{})
+	require.Error(t, err)
+	require.Equal(t, 2, fallback.calls)
+
+	require.NoError(t, chain.Close())
+	require.True(t, fallback.closed)
+	_, err = chain.Broadcast(ctx, &common.Envelope{})
+	require.True(t, errors.Is(err, api.ErrClientClosed))
+}
@@ -0,0 +1,35 @@
+package orderer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+type hookedOrderer struct {
+	api.Orderer
+	hooks []api.PreBroadcastHook
+}
+
+func (o *hookedOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	var err error
+	for _, hook := range o.hooks {
+		if envelope, err = hook(envelope); err != nil {
+			return nil, fmt.Errorf(`pre broadcast hook: %w`, err)
+		}
+	}
+
+	return o.Orderer.Broadcast(ctx, envelope)
+}
+
+// WithPreBroadcastHooks wraps orderer, so each envelope passes through hooks in presented order before broadcast
+func WithPreBroadcastHooks(orderer api.Orderer, hooks ...api.PreBroadcastHook) api.Orderer {
+	if len(hooks) == 0 {
+		return orderer
+	}
+	return &hookedOrderer{Orderer: orderer, hooks: hooks}
+}
@@ -0,0 +1,95 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+type multiOrderer struct {
+	orderers    []api.Orderer
+	maxAttempts uint
+	backoff     time.Duration
+	next        uint32
+}
+
+// NewMulti returns orderer which sends requests to presented orderers in turn. Request failed with GRPC Unavailable
+// or SERVICE_UNAVAILABLE status is repeated on next orderer, up to maxAttempts attempts with backoff between them.
+// Other statuses like BAD_REQUEST are returned without retries, so rejected envelopes aren't submitted twice.
+// Orderer with open circuit breaker is skipped without attempt, unless circuits of all orderers are open
+func NewMulti(orderers []api.Orderer, maxAttempts uint, backoff time.Duration) (api.Orderer, error) {
+	if len(orderers) == 0 {
+		return nil, errors.New(`orderers are empty`)
+	}
+	if maxAttempts == 0 {
+		maxAttempts = 1
+	}
+	return &multiOrderer{orderers: orderers, maxAttempts: maxAttempts, backoff: backoff}, nil
+}
+
+func (o *multiOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (resp *fabricOrderer.BroadcastResponse, err error) {
+	err = o.do(ctx, func(ord api.Orderer) error {
+		resp, err = ord.Broadcast(ctx, envelope)
+		return err
+	})
+	return resp, err
+}
+
+func (o *multiOrderer) Deliver(ctx context.Context, envelope *common.Envelope) (block *common.Block, err error) {
+	err = o.do(ctx, func(ord api.Orderer) error {
+		block, err = ord.Deliver(ctx, envelope)
+		return err
+	})
+	return block, err
+}
+
+func (o *multiOrderer) Close() error {
+	return closeAll(o.orderers...)
+}
+
+// Conn returns GRPC connection of orderer which receives next request
+func (o *multiOrderer) Conn() *grpc.ClientConn {
+	ord := o.orderers[(atomic.LoadUint32(&o.next)+1)%uint32(len(o.orderers))]
+	if connOrderer, ok := ord.(interface{ Conn() *grpc.ClientConn }); ok {
+		return connOrderer.Conn()
+	}
+	return nil
+}
+
+func (o *multiOrderer) do(ctx context.Context, request func(ord api.Orderer) error) error {
+	skipped := 0
+	for attempt := uint(1); ; attempt++ {
+		ord := o.orderers[atomic.AddUint32(&o.next, 1)%uint32(len(o.orderers))]
+
+		err := request(ord)
+		if errors.Is(err, api.ErrCircuitOpen) && skipped < len(o.orderers)-1 {
+			skipped++
+			attempt--
+			continue
+		}
+		skipped = 0
+		if err == nil || attempt >= o.maxAttempts || !IsFailoverError(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(o.backoff):
+		}
+	}
+}
+
+// IsFailoverError reports whether request failed because of orderer connection or temporary unavailable
+// ordering service, so it can be repeated on another orderer
+func IsFailoverError(err error) bool {
+	return IsTransientStatus(err) || grpcCode(err) == codes.Unavailable
+}
@@ -2,9 +2,11 @@ package orderer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
@@ -18,12 +20,31 @@ import (
 
 type ErrUnexpectedStatus struct {
 	status common.Status
+	info   string
 }
 
 func (e *ErrUnexpectedStatus) Error() string {
+	if e.info != `` {
+		return fmt.Sprintf("unexpected status: %s: %s", e.status.String(), e.info)
+	}
 	return fmt.Sprintf("unexpected status: %s", e.status.String())
 }
 
+// Status returns status returned by orderer
+func (e *ErrUnexpectedStatus) Status() common.Status {
+	return e.status
+}
+
+// Info returns details of broadcast rejection returned by orderer, e.g. failed envelope validation
+func (e *ErrUnexpectedStatus) Info() string {
+	return e.info
+}
+
+// Unwrap allows to get api.OrdererError with errors.As
+func (e *ErrUnexpectedStatus) Unwrap() error {
+	return api.OrdererError{Status: e.status, Info: e.info}
+}
+
 type orderer struct {
 	uri             string
 	conn            *grpc.ClientConn
@@ -32,9 +53,27 @@ type orderer struct {
 	connMx          sync.Mutex
 	broadcastClient fabricOrderer.AtomicBroadcastClient
 	grpcOptions     []grpc.DialOption
+	// callTimeout limits broadcast and deliver if context has no deadline
+	callTimeout time.Duration
+	closed      bool
+}
+
+// withCallTimeout limits context by call timeout if it's set and context has no deadline
+func (o *orderer) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || o.callTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, o.callTimeout)
 }
 
 func (o *orderer) Broadcast(ctx context.Context, envelope *common.Envelope) (resp *fabricOrderer.BroadcastResponse, err error) {
+	if o.isClosed() {
+		return nil, api.ErrClientClosed
+	}
+
+	ctx, cancel := o.withCallTimeout(ctx)
+	defer cancel()
+
 	cli, err := o.broadcastClient.Broadcast(ctx)
 	if err != nil {
 		err = fmt.Errorf(`initialize broadcast client: %w`, err)
@@ -62,7 +101,7 @@ func (o *orderer) Broadcast(ctx context.Context, envelope *common.Envelope) (res
 		return
 	} else {
 		if resp.Status != common.Status_SUCCESS {
-			err = &ErrUnexpectedStatus{status: resp.Status}
+			err = &ErrUnexpectedStatus{status: resp.Status, info: resp.Info}
 			return
 		}
 	}
@@ -71,6 +110,13 @@ func (o *orderer) Broadcast(ctx context.Context, envelope *common.Envelope) (res
 }
 
 func (o *orderer) Deliver(ctx context.Context, envelope *common.Envelope) (block *common.Block, err error) {
+	if o.isClosed() {
+		return nil, api.ErrClientClosed
+	}
+
+	ctx, cancel := o.withCallTimeout(ctx)
+	defer cancel()
+
 	cli, err := o.broadcastClient.Deliver(ctx)
 	if err != nil {
 		err = fmt.Errorf(`initialize deliver client: %w`, err)
@@ -117,6 +163,52 @@ func (o *orderer) Deliver(ctx context.Context, envelope *common.Envelope) (block
 	return
 }
 
+// Close closes GRPC connection of orderer
+func (o *orderer) Close() error {
+	o.connMx.Lock()
+	defer o.connMx.Unlock()
+
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+	if o.conn == nil {
+		return nil
+	}
+	if err := o.conn.Close(); err != nil {
+		return fmt.Errorf(`close grpc connection: %w`, err)
+	}
+	return nil
+}
+
+// closeAll closes presented orderers, skipping nil ones, and returns errors of all failed closes
+func closeAll(orderers ...api.Orderer) error {
+	var errs api.MultiError
+	for _, ord := range orderers {
+		if ord == nil {
+			continue
+		}
+		if err := ord.Close(); err != nil {
+			errs.Add(err)
+		}
+	}
+	if len(errs.Errors) > 0 {
+		return &errs
+	}
+	return nil
+}
+
+func (o *orderer) isClosed() bool {
+	o.connMx.Lock()
+	defer o.connMx.Unlock()
+	return o.closed
+}
+
+// Conn returns GRPC connection of orderer
+func (o *orderer) Conn() *grpc.ClientConn {
+	return o.conn
+}
+
 func (o *orderer) initBroadcastClient() error {
 	var err error
 	if o.conn == nil {
@@ -140,14 +232,55 @@ func New(c config.ConnectionConfig, log *zap.Logger) (api.Orderer, error) {
 		return nil, fmt.Errorf(`get GRPC options: %w`, err)
 	}
 
-	ctx, _ := context.WithTimeout(context.Background(), c.Timeout.Duration)
+	dialTimeout := c.Timeout.Duration
+	if c.DialTimeout.Duration > 0 {
+		dialTimeout = c.DialTimeout.Duration
+	}
+
+	ctx, _ := context.WithTimeout(context.Background(), dialTimeout)
 	conn, err := grpc.DialContext(ctx, c.Host, opts...)
 	if err != nil {
 		l.Error(`Failed to initialize GRPC connection`, zap.Error(err))
 		return nil, fmt.Errorf(`initialize GRPC connection: %w`, err)
 	}
 
-	return NewFromGRPC(ctx, conn, opts...)
+	ord, err := NewFromGRPC(ctx, conn, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ord.(*orderer).callTimeout = c.CallTimeout.Duration
+	return ord, nil
+}
+
+// NewBalanced returns orderer with single GRPC connection balanced between hosts of presented configs.
+// Other connection settings, including TLS and timeouts, are taken from first config
+func NewBalanced(configs []config.ConnectionConfig, log *zap.Logger) (api.Orderer, error) {
+	if len(configs) == 0 {
+		return nil, errors.New(`orderer configs are empty`)
+	}
+
+	ctx := context.Background()
+	dialTimeout := configs[0].Timeout.Duration
+	if configs[0].DialTimeout.Duration > 0 {
+		dialTimeout = configs[0].DialTimeout.Duration
+	}
+	if dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, dialTimeout)
+		defer cancel()
+	}
+
+	conn, err := util.NewGRPCConnectionFromConfigs(ctx, log, configs...)
+	if err != nil {
+		return nil, fmt.Errorf(`initialize GRPC connection: %w`, err)
+	}
+
+	ord, err := NewFromGRPC(context.Background(), conn)
+	if err != nil {
+		return nil, err
+	}
+	ord.(*orderer).callTimeout = configs[0].CallTimeout.Duration
+	return ord, nil
 }
 
 // NewFromGRPC allows to initialize orderer from existing GRPC connection
@@ -0,0 +1,101 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+)
+
+// rejectingServer rejects all broadcast envelopes with info
+type rejectingServer struct {
+	fabricOrderer.AtomicBroadcastServer
+}
+
+func (s *rejectingServer) Broadcast(stream fabricOrderer.AtomicBroadcast_BroadcastServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	return stream.Send(&fabricOrderer.BroadcastResponse{
+		Status: common.Status_BAD_REQUEST, Info: `envelope signature is invalid`})
+}
+
+func TestBroadcastInfo(t *testing.T) {
+	lis, err := net.Listen(`tcp`, `127.0.0.1:0`)
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	fabricOrderer.RegisterAtomicBroadcastServer(srv, &rejectingServer{})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ord, err := NewFromGRPC(context.Background(), conn)
+	require.NoError(t, err)
+
+	resp, err := ord.Broadcast(context.Background(), &common.Envelope{})
+	require.Equal(t, `envelope signature is invalid`, resp.GetInfo())
+
+	var statusErr *ErrUnexpectedStatus
+	require.True(t, errors.As(err, &statusErr))
+	require.Equal(t, common.Status_BAD_REQUEST, statusErr.Status())
+	require.Equal(t, `envelope signature is invalid`, statusErr.Info())
+	require.Contains(t, err.Error(), `envelope signature is invalid`)
+
+	var ordererErr api.OrdererError
+	require.True(t, errors.As(err, &ordererErr))
+	require.Equal(t, common.Status_BAD_REQUEST, ordererErr.Status)
+
+	require.NoError(t, ord.Close())
+	require.NoError(t, ord.Close())
+	_, err = ord.Broadcast(context.Background(), &common.Envelope{})
+	require.True(t, errors.Is(err, api.ErrClientClosed))
+}
+
+// hangingServer never responds to broadcast
+type hangingServer struct {
+	fabricOrderer.AtomicBroadcastServer
+}
+
+func (s *hangingServer) Broadcast(stream fabricOrderer.AtomicBroadcast_BroadcastServer) error {
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func TestNewBalancedCallTimeout(t *testing.T) {
+	var hosts []config.ConnectionConfig
+	for i := 0; i < 2; i++ {
+		lis, err := net.Listen(`tcp`, `127.0.0.1:0`)
+		require.NoError(t, err)
+
+		srv := grpc.NewServer()
+		fabricOrderer.RegisterAtomicBroadcastServer(srv, &hangingServer{})
+		go func() { _ = srv.Serve(lis) }()
+		defer srv.Stop()
+
+		hosts = append(hosts, config.ConnectionConfig{Host: lis.Addr().String(),
+			DialTimeout: config.Duration{Duration: time.Second}, CallTimeout: config.Duration{Duration: 100 * time.Millisecond}})
+	}
+
+	ord, err := NewBalanced(hosts, zap.NewNop())
+	require.NoError(t, err)
+	defer ord.Close()
+
+	started := time.Now()
+	_, err = ord.Broadcast(context.Background(), &common.Envelope{})
+	require.Error(t, err)
+	require.Less(t, int64(time.Since(started)), int64(5*time.Second))
+}
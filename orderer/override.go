@@ -0,0 +1,52 @@
+package orderer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+type endpointKey struct{}
+
+// WithOrderer returns context, broadcasts with which are sent to presented orderer endpoint
+// instead of default or channel orderer
+func WithOrderer(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+// EndpointFromContext returns orderer endpoint set by WithOrderer
+func EndpointFromContext(ctx context.Context) (string, bool) {
+	endpoint, ok := ctx.Value(endpointKey{}).(string)
+	return endpoint, ok && endpoint != ``
+}
+
+// DialFunc returns orderer connected to endpoint
+type DialFunc func(ctx context.Context, endpoint string) (api.Orderer, error)
+
+type overrideOrderer struct {
+	api.Orderer
+	dial DialFunc
+}
+
+// WithContextOverride wraps orderer, so broadcasts with context created by WithOrderer
+// are sent to orderer returned by dial for context endpoint
+func WithContextOverride(orderer api.Orderer, dial DialFunc) api.Orderer {
+	return &overrideOrderer{Orderer: orderer, dial: dial}
+}
+
+func (o *overrideOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	endpoint, ok := EndpointFromContext(ctx)
+	if !ok {
+		return o.Orderer.Broadcast(ctx, envelope)
+	}
+
+	ord, err := o.dial(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf(`orderer %s from context is unreachable: %w`, endpoint, err)
+	}
+	return ord.Broadcast(ctx, envelope)
+}
@@ -0,0 +1,31 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+// Probe waits until GRPC connection of orderer becomes ready or context is done.
+// Orderer must expose its connection, as orderers created by New and NewFromGRPC do, or probe itself as chain does
+func Probe(ctx context.Context, orderer api.Orderer) error {
+	if prober, ok := orderer.(interface{ Probe(context.Context) error }); ok {
+		return prober.Probe(ctx)
+	}
+
+	connOrderer, ok := orderer.(interface{ Conn() *grpc.ClientConn })
+	if !ok || connOrderer.Conn() == nil {
+		return errors.New(`orderer doesn't expose connection`)
+	}
+
+	conn := connOrderer.Conn()
+	if err := util.WaitForReady(ctx, conn); err != nil {
+		return fmt.Errorf(`orderer %s: %w`, conn.Target(), err)
+	}
+	return nil
+}
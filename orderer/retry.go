@@ -0,0 +1,97 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// RetryConfig describes retries of broadcast on transient ordering service statuses
+type RetryConfig struct {
+	// Attempts is max count of broadcast attempts including first one
+	Attempts uint
+	// Backoff is delay before second attempt, each next delay is doubled
+	Backoff time.Duration
+	// RetryConnectionErrors enables retries of broadcasts failed with GRPC Unavailable status,
+	// as it happens while raft leader is changing. Deadline errors aren't retried, as envelope could be
+	// already accepted by orderer and its retry submits transaction twice.
+	// If orderer connection is balanced between several orderers, next attempt is sent to next orderer
+	RetryConnectionErrors bool
+	// OnRecover is called if presented when broadcast succeeded after failed attempts
+	OnRecover func(attempts uint, lastErr error)
+}
+
+type retryOrderer struct {
+	api.Orderer
+	config RetryConfig
+}
+
+// WithBroadcastRetry wraps orderer, so broadcast is retried while ordering service responds with SERVICE_UNAVAILABLE,
+// e.g. during raft leader election. Other statuses like BAD_REQUEST are returned without retries.
+// Connection errors are retried only if it is enabled by config
+func WithBroadcastRetry(orderer api.Orderer, config RetryConfig) api.Orderer {
+	if config.Attempts <= 1 {
+		return orderer
+	}
+	return &retryOrderer{Orderer: orderer, config: config}
+}
+
+func (o *retryOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	backoff := o.config.Backoff
+
+	var lastErr error
+	for attempt := uint(1); ; attempt++ {
+		resp, err := o.Orderer.Broadcast(ctx, envelope)
+		if err == nil && lastErr != nil && o.config.OnRecover != nil {
+			o.config.OnRecover(attempt, lastErr)
+		}
+		if err == nil || attempt >= o.config.Attempts || !o.retryable(ctx, err) {
+			return resp, err
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (o *retryOrderer) retryable(ctx context.Context, err error) bool {
+	if IsTransientStatus(err) {
+		return true
+	}
+	// errors caused by done caller context are never retried
+	if !o.config.RetryConnectionErrors || ctx.Err() != nil {
+		return false
+	}
+	return grpcCode(err) == codes.Unavailable
+}
+
+// grpcCode returns code of GRPC status error wrapped by err, codes.Unknown if there is no status error
+func grpcCode(err error) codes.Code {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if s, ok := status.FromError(err); ok {
+			return s.Code()
+		}
+	}
+	return codes.Unknown
+}
+
+// IsTransientStatus reports whether error is unexpected ordering service status which could disappear on retry
+func IsTransientStatus(err error) bool {
+	var statusErr *ErrUnexpectedStatus
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.Status() == common.Status_SERVICE_UNAVAILABLE
+}
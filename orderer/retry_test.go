@@ -0,0 +1,109 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+var (
+	errUnavailable        = status.Error(codes.Unavailable, `connection refused`)
+	errServiceUnavailable = &ErrUnexpectedStatus{status: common.Status_SERVICE_UNAVAILABLE}
+	errBadRequest         = &ErrUnexpectedStatus{status: common.Status_BAD_REQUEST}
+)
+
+// flakyOrderer fails presented count of broadcasts
+type flakyOrderer struct {
+	testOrderer
+	failures int
+}
+
+func (o *flakyOrderer) Broadcast(context.Context, *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	o.calls++
+	if o.calls <= o.failures {
+		return nil, o.err
+	}
+	return &fabricOrderer.BroadcastResponse{Status: common.Status_SUCCESS}, nil
+}
+
+func TestBroadcastRetry(t *testing.T) {
+	ctx := context.Background()
+
+	for _, c := range []struct {
+		name                  string
+		err                   error
+		retryConnectionErrors bool
+		calls                 int
+	}{
+		{name: `service unavailable`, err: errServiceUnavailable, calls: 3},
+		{name: `bad request`, err: errBadRequest, calls: 1},
+		{name: `connection error`, err: errUnavailable, calls: 1},
+		{name: `connection error with retries`, err: errUnavailable, retryConnectionErrors: true, calls: 3},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			ord := &testOrderer{err: c.err}
+			_, err := WithBroadcastRetry(ord, RetryConfig{Attempts: 3, RetryConnectionErrors: c.retryConnectionErrors}).
+				Broadcast(ctx, &common.Envelope{})
+			require.True(t, errors.Is(err, c.err))
+			require.Equal(t, c.calls, ord.calls)
+		})
+	}
+
+	t.Run(`recovery`, func(t *testing.T) {
+		var recovered uint
+		ord := &flakyOrderer{testOrderer: testOrderer{err: errServiceUnavailable}, failures: 2}
+		_, err := WithBroadcastRetry(ord, RetryConfig{Attempts: 3, OnRecover: func(attempts uint, _ error) {
+			recovered = attempts
+		}}).Broadcast(ctx, &common.Envelope{})
+		require.NoError(t, err)
+		require.Equal(t, uint(3), recovered)
+	})
+}
+
+func TestMultiFailover(t *testing.T) {
+	ctx := context.Background()
+
+	for _, c := range []struct {
+		name         string
+		err          error
+		healthyCalls int
+	}{
+		{name: `connection error`, err: errUnavailable, healthyCalls: 1},
+		{name: `service unavailable`, err: errServiceUnavailable, healthyCalls: 1},
+		{name: `bad request`, err: errBadRequest, healthyCalls: 0},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			failed, healthy := &testOrderer{err: c.err}, &testOrderer{}
+			// first request is sent to second orderer, so failed one is placed there
+			multi, err := NewMulti([]api.Orderer{healthy, failed}, 2, 0)
+			require.NoError(t, err)
+
+			_, err = multi.Broadcast(ctx, &common.Envelope{})
+			require.Equal(t, 1, failed.calls)
+			require.Equal(t, c.healthyCalls, healthy.calls)
+			if c.healthyCalls == 0 {
+				require.True(t, errors.Is(err, c.err))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run(`attempts are limited`, func(t *testing.T) {
+		first, second := &testOrderer{err: errUnavailable}, &testOrderer{err: errServiceUnavailable}
+		multi, err := NewMulti([]api.Orderer{first, second}, 3, 0)
+		require.NoError(t, err)
+
+		_, err = multi.Broadcast(ctx, &common.Envelope{})
+		require.Error(t, err)
+		require.Equal(t, 3, first.calls+second.calls)
+	})
+}
@@ -0,0 +1,43 @@
+package peer
+
+import (
+	"context"
+	"errors"
+
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
+)
+
+type breakerPeer struct {
+	api.Peer
+	breaker *breaker.Breaker
+}
+
+// WithCircuitBreaker wraps peer with circuit breaker, endorsement fails fast with api.ErrCircuitOpen while breaker is open.
+// Only connection level errors are counted as failures, endorsement errors returned by chaincode are not
+func WithCircuitBreaker(peer api.Peer, config breaker.Config) api.Peer {
+	return &breakerPeer{Peer: peer, breaker: breaker.New(config)}
+}
+
+func (p *breakerPeer) Endorse(ctx context.Context, proposal *fabricPeer.SignedProposal, opts ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	if !p.breaker.Allow() {
+		return nil, api.ErrCircuitOpen
+	}
+
+	resp, err := p.Peer.Endorse(ctx, proposal, opts...)
+	// endorsement aborted by caller doesn't indicate peer failure
+	if err != nil && ctx.Err() == nil && !errors.As(err, &api.PeerEndorseError{}) {
+		p.breaker.Failure()
+	} else {
+		p.breaker.Success()
+	}
+
+	return resp, err
+}
+
+// BreakerState returns current state of peer circuit breaker
+func (p *breakerPeer) BreakerState() breaker.State {
+	return p.breaker.State()
+}
@@ -18,16 +18,48 @@ import (
 )
 
 // New
-func New(delivercli peer.DeliverClient, identity msp.SigningIdentity) *deliverImpl {
-	return &deliverImpl{
-		cli:      delivercli,
-		identity: identity,
+func New(delivercli peer.DeliverClient, identity msp.SigningIdentity, opts ...Opt) *deliverImpl {
+	d := &deliverImpl{
+		cli:          delivercli,
+		identity:     identity,
+		bufferPolicy: api.OverflowBlock,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Opt is deliver client option
+type Opt func(d *deliverImpl)
+
+// WithBlockBuffer sets buffer size and overflow policy of block subscriptions
+func WithBlockBuffer(size uint, policy api.BlockOverflowPolicy) Opt {
+	return func(d *deliverImpl) {
+		d.bufferSize = size
+		d.bufferPolicy = policy
+	}
+}
+
+// BlockVerifierProvider returns verifier of blocks delivered from channel starting from presented position
+type BlockVerifierProvider func(ctx context.Context, channelName string, start *orderer.SeekPosition) (*util.BlockVerifier, error)
+
+// WithBlockVerifier makes subscriptions verify data hash and orderer signatures of delivered blocks,
+// subscription reports first invalid block as api.InvalidBlockError and stops
+func WithBlockVerifier(provider BlockVerifierProvider) Opt {
+	return func(d *deliverImpl) {
+		d.verifiers = provider
 	}
 }
 
 type deliverImpl struct {
-	cli      peer.DeliverClient
-	identity msp.SigningIdentity
+	cli          peer.DeliverClient
+	identity     msp.SigningIdentity
+	bufferSize   uint
+	bufferPolicy api.BlockOverflowPolicy
+	verifiers    BlockVerifierProvider
 }
 
 var (
@@ -139,7 +171,10 @@ func (d *deliverImpl) SubscribeTx(ctx context.Context, channelName string, txId
 }
 
 func (d *deliverImpl) SubscribeBlock(ctx context.Context, channelName string, seekOpt ...api.EventCCSeekOption) (api.BlockSubscription, error) {
-	blocker := subs.NewBlockSubscription()
+	blocker, err := subs.NewBufferedBlockSubscription(d.bufferSize, d.bufferPolicy)
+	if err != nil {
+		return nil, err
+	}
 
 	sub, err := d.handleSubscription(ctx, channelName, blocker.Handler, seekOpt...)
 	if err != nil {
@@ -163,6 +198,13 @@ func (d *deliverImpl) handleSubscription(ctx context.Context, channel string, bl
 		return nil, errors.Wrap(err, `failed to get seek envelope`)
 	}
 
+	var verifier *util.BlockVerifier
+	if d.verifiers != nil {
+		if verifier, err = d.verifiers(ctx, channel, startPos); err != nil {
+			return nil, errors.Wrap(err, `failed to get block verifier`)
+		}
+	}
+
 	subCtx, stopSub := context.WithCancel(ctx)
 
 	stream, err := d.cli.Deliver(subCtx)
@@ -175,15 +217,16 @@ func (d *deliverImpl) handleSubscription(ctx context.Context, channel string, bl
 		return nil, errors.Wrap(err, `failed to send seek envelope to stream`)
 	}
 
-	return makeSubscription(subCtx, stopSub, stream, blockHandler), nil
+	return makeSubscription(subCtx, stopSub, stream, blockHandler, verifier), nil
 }
 
-func makeSubscription(ctx context.Context, stop context.CancelFunc, stream peer.Deliver_DeliverClient, blockHandler subs.BlockHandler) *subscriptionImpl {
+func makeSubscription(ctx context.Context, stop context.CancelFunc, stream peer.Deliver_DeliverClient, blockHandler subs.BlockHandler, verifier *util.BlockVerifier) *subscriptionImpl {
 	s := &subscriptionImpl{
 		ctx:          ctx,
 		stop:         stop,
 		stream:       stream,
 		blockHandler: blockHandler,
+		verifier:     verifier,
 		once:         new(sync.Once),
 		err:          make(chan error, 1),  // only one error
 		done:         make(chan *struct{}), // done will be closed after finished sub.handle
@@ -201,6 +244,7 @@ type subscriptionImpl struct {
 	ctx          context.Context
 	stop         context.CancelFunc
 	blockHandler subs.BlockHandler
+	verifier     *util.BlockVerifier
 	stream       peer.Deliver_DeliverClient
 	err          chan error
 	once         *sync.Once
@@ -236,6 +280,12 @@ func (s *subscriptionImpl) handle() {
 				s.err <- ctx.Err()
 				return
 			default:
+				if s.verifier != nil {
+					if err = s.verifier.Verify(event.Block); err != nil {
+						s.err <- err
+						return
+					}
+				}
 				if skip := s.blockHandler(event.Block); skip {
 					return
 				}
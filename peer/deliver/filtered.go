@@ -0,0 +1,169 @@
+package deliver
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+// FilteredReconnectDelay is delay before reconnection of filtered block subscription
+var FilteredReconnectDelay = time.Second
+
+// ConnProvider returns connection of peer which delivers blocks
+type ConnProvider func() (*grpc.ClientConn, error)
+
+// SubscribeFilteredBlocks subscribes on filtered blocks using DeliverFiltered service of peer returned by conns.
+// If stream drops, subscription reconnects and resumes from block next to last delivered one,
+// onReconnect is called before each reconnection if presented. Subscription is stopped with context
+func SubscribeFilteredBlocks(ctx context.Context, conns ConnProvider, channelName string, identity msp.SigningIdentity,
+	onReconnect api.ReconnectHandler, seekOpt ...api.EventCCSeekOption) (api.FilteredBlockSubscription, error) {
+
+	startPos, stopPos := api.SeekNewest()()
+	if len(seekOpt) > 0 {
+		startPos, stopPos = seekOpt[0]()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &filteredBlockSubscription{
+		channelName: channelName,
+		identity:    identity,
+		conns:       conns,
+		onReconnect: onReconnect,
+		startPos:    startPos,
+		stopPos:     stopPos,
+		cancel:      cancel,
+		blocks:      make(chan *peer.FilteredBlock),
+		errors:      make(chan error, 1),
+	}
+
+	stream, err := sub.open(ctx, startPos)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go sub.handle(ctx, stream)
+	return sub, nil
+}
+
+type filteredBlockSubscription struct {
+	channelName string
+	identity    msp.SigningIdentity
+	conns       ConnProvider
+	onReconnect api.ReconnectHandler
+	startPos    *orderer.SeekPosition
+	stopPos     *orderer.SeekPosition
+	cancel      context.CancelFunc
+	blocks      chan *peer.FilteredBlock
+	errors      chan error
+}
+
+func (s *filteredBlockSubscription) open(ctx context.Context, startPos *orderer.SeekPosition) (peer.Deliver_DeliverFilteredClient, error) {
+	seek, err := util.SeekEnvelope(s.channelName, startPos, s.stopPos, s.identity)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get seek envelope`)
+	}
+
+	conn, err := s.conns()
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get peer connection`)
+	}
+
+	stream, err := peer.NewDeliverClient(conn).DeliverFiltered(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to open deliver filtered stream`)
+	}
+
+	if err = stream.Send(seek); err != nil {
+		return nil, errors.Wrap(err, `failed to send seek envelope to stream`)
+	}
+	return stream, nil
+}
+
+func (s *filteredBlockSubscription) handle(ctx context.Context, stream peer.Deliver_DeliverFilteredClient) {
+	defer close(s.errors)
+	defer close(s.blocks)
+	defer s.cancel()
+
+	var next *uint64
+	for {
+		resp, err := stream.Recv()
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			switch r := resp.Type.(type) {
+			case *peer.DeliverResponse_FilteredBlock:
+				select {
+				case s.blocks <- r.FilteredBlock:
+				case <-ctx.Done():
+					return
+				}
+				number := r.FilteredBlock.Number + 1
+				next = &number
+				continue
+
+			case *peer.DeliverResponse_Status:
+				// success status finishes delivery of requested blocks
+				if r.Status != common.Status_SUCCESS {
+					s.errors <- errors.Errorf(`unexpected deliver status: %s`, r.Status)
+				}
+				return
+			}
+		}
+
+		if stream, err = s.reconnect(ctx, next, err); err != nil {
+			return
+		}
+	}
+}
+
+// reconnect opens stream starting from next block if any block was delivered, or from initial position otherwise
+func (s *filteredBlockSubscription) reconnect(ctx context.Context, next *uint64, streamErr error) (peer.Deliver_DeliverFilteredClient, error) {
+	for {
+		startPos := s.startPos
+		if next != nil {
+			startPos, _ = api.SeekSingle(*next)()
+		}
+
+		if s.onReconnect != nil {
+			s.onReconnect(next, api.GRPCStreamError{Code: status.Code(streamErr), Err: streamErr})
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(FilteredReconnectDelay):
+		}
+
+		stream, err := s.open(ctx, startPos)
+		if err == nil {
+			return stream, nil
+		}
+		streamErr = err
+	}
+}
+
+func (s *filteredBlockSubscription) Blocks() <-chan *peer.FilteredBlock {
+	return s.blocks
+}
+
+func (s *filteredBlockSubscription) Errors() <-chan error {
+	return s.errors
+}
+
+func (s *filteredBlockSubscription) Close() error {
+	s.cancel()
+	return nil
+}
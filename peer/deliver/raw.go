@@ -0,0 +1,82 @@
+package deliver
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// NewRawBlockSubscription returns subscription which delivers blocks of block subscription with their raw bytes.
+// Block contains only bytes and scalar fields, so its marshaling reproduces bytes received from deliver stream.
+// Subscription is stopped with context, block subscription is closed after stop
+func NewRawBlockSubscription(ctx context.Context, blockSub api.BlockSubscription) api.RawBlockSubscription {
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &rawBlockSubscription{
+		cancel: cancel,
+		blocks: make(chan *api.DeliveredBlock),
+		errors: make(chan error, 1),
+	}
+	go sub.handle(ctx, blockSub)
+
+	return sub
+}
+
+type rawBlockSubscription struct {
+	cancel context.CancelFunc
+	blocks chan *api.DeliveredBlock
+	errors chan error
+}
+
+func (s *rawBlockSubscription) handle(ctx context.Context, blockSub api.BlockSubscription) {
+	defer close(s.errors)
+	defer close(s.blocks)
+	defer func() { _ = blockSub.Close() }()
+
+	for {
+		var block *common.Block
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-blockSub.Errors():
+			if ok && err != nil && ctx.Err() == nil {
+				s.errors <- err
+			}
+			return
+		case b, ok := <-blockSub.Blocks():
+			// closed blocks channel finishes delivery of requested blocks
+			if !ok {
+				return
+			}
+			block = b
+		}
+
+		raw, err := proto.Marshal(block)
+		if err != nil {
+			s.errors <- errors.Wrap(err, `failed to marshal block`)
+			return
+		}
+
+		select {
+		case s.blocks <- &api.DeliveredBlock{Block: block, Raw: raw}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *rawBlockSubscription) Blocks() <-chan *api.DeliveredBlock {
+	return s.blocks
+}
+
+func (s *rawBlockSubscription) Errors() <-chan error {
+	return s.errors
+}
+
+func (s *rawBlockSubscription) Close() error {
+	s.cancel()
+	return nil
+}
@@ -0,0 +1,309 @@
+package deliver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// NewShared returns DeliverClient which shares single deliver stream per channel between all block subscriptions
+// which are started from newest block. Subscriptions with other seek options open own stream on the same GRPC connection.
+// Shared stream lives while it has at least one subscriber, each subscription can be closed independently.
+// Each subscriber has own queue of blocks, so slow subscriber doesn't delay others. Queue isn't limited
+// unless buffer with drop oldest policy is set by WithBlockBuffer
+func NewShared(ctx context.Context, delivercli peer.DeliverClient, identity msp.SigningIdentity, opts ...Opt) api.DeliverClient {
+	return &sharedDeliver{
+		deliverImpl: New(delivercli, identity, opts...),
+		ctx:         ctx,
+		streams:     make(map[string]*sharedBlockStream),
+	}
+}
+
+type sharedDeliver struct {
+	*deliverImpl
+	ctx       context.Context
+	streams   map[string]*sharedBlockStream
+	streamsMx sync.Mutex
+}
+
+var (
+	_ api.DeliverClient             = &sharedDeliver{}
+	_ api.BufferedBlockSubscription = &sharedBlockSubscription{}
+)
+
+func (d *sharedDeliver) SubscribeBlock(ctx context.Context, channelName string, seekOpt ...api.EventCCSeekOption) (api.BlockSubscription, error) {
+	if len(seekOpt) > 0 && !isSeekNewest(seekOpt[0]) {
+		return d.deliverImpl.SubscribeBlock(ctx, channelName, seekOpt...)
+	}
+
+	policy, err := api.ParseBlockOverflowPolicy(string(d.bufferPolicy))
+	if err != nil {
+		return nil, err
+	}
+
+	d.streamsMx.Lock()
+	defer d.streamsMx.Unlock()
+
+	stream, ok := d.streams[channelName]
+	if !ok {
+		stream = &sharedBlockStream{
+			subscribers: make(map[*sharedBlockSubscription]struct{}),
+		}
+
+		sub, err := d.handleSubscription(d.ctx, channelName, stream.handler)
+		if err != nil {
+			return nil, err
+		}
+		stream.sub = sub
+		d.streams[channelName] = stream
+
+		go d.serveStream(channelName, stream)
+		sub.readyForHandling()
+	}
+
+	queueSize := uint(0)
+	if policy == api.OverflowDropOldest {
+		queueSize = d.bufferSize
+		if queueSize == 0 {
+			queueSize = 1
+		}
+	}
+
+	return stream.subscribe(ctx, queueSize, func() {
+		d.unsubscribe(channelName, stream)
+	}), nil
+}
+
+// serveStream forwards stream errors to subscribers and forgets stream after it's finished
+func (d *sharedDeliver) serveStream(channelName string, stream *sharedBlockStream) {
+	for err := range stream.sub.Err() {
+		stream.broadcastErr(err)
+	}
+
+	d.streamsMx.Lock()
+	if d.streams[channelName] == stream {
+		delete(d.streams, channelName)
+	}
+	d.streamsMx.Unlock()
+
+	stream.finish()
+}
+
+// unsubscribe closes shared stream when it has no subscribers anymore
+func (d *sharedDeliver) unsubscribe(channelName string, stream *sharedBlockStream) {
+	d.streamsMx.Lock()
+	defer d.streamsMx.Unlock()
+
+	if stream.len() > 0 {
+		return
+	}
+
+	if d.streams[channelName] == stream {
+		delete(d.streams, channelName)
+	}
+	_ = stream.sub.Close()
+}
+
+func isSeekNewest(seekOpt api.EventCCSeekOption) bool {
+	start, stop := seekOpt()
+	if _, ok := start.GetType().(*orderer.SeekPosition_Newest); !ok {
+		return false
+	}
+	_, maxStop := api.SeekNewest()()
+	return stop.GetSpecified().GetNumber() == maxStop.GetSpecified().GetNumber()
+}
+
+type sharedBlockStream struct {
+	sub         *subscriptionImpl
+	subscribers map[*sharedBlockSubscription]struct{}
+	mx          sync.Mutex
+}
+
+func (s *sharedBlockStream) subscribe(ctx context.Context, queueSize uint, unsubscribe func()) *sharedBlockSubscription {
+	sb := &sharedBlockSubscription{
+		stream:      s,
+		unsubscribe: unsubscribe,
+		queueSize:   queueSize,
+		notify:      make(chan struct{}, 1),
+		blocks:      make(chan *common.Block),
+		err:         make(chan error, 1),
+		done:        make(chan struct{}),
+	}
+
+	s.mx.Lock()
+	s.subscribers[sb] = struct{}{}
+	s.mx.Unlock()
+
+	go sb.pump()
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = sb.Close()
+		case <-sb.done:
+		}
+	}()
+
+	return sb
+}
+
+// remove returns false if subscriber was already removed
+func (s *sharedBlockStream) remove(sb *sharedBlockSubscription) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if _, ok := s.subscribers[sb]; !ok {
+		return false
+	}
+	delete(s.subscribers, sb)
+	return true
+}
+
+func (s *sharedBlockStream) len() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return len(s.subscribers)
+}
+
+// handler only queues block for each subscriber, so stream is never blocked by subscribers
+func (s *sharedBlockStream) handler(block *common.Block) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for sb := range s.subscribers {
+		sb.push(block)
+	}
+
+	return false
+}
+
+func (s *sharedBlockStream) broadcastErr(err error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for sb := range s.subscribers {
+		select {
+		case sb.err <- err:
+		default:
+		}
+	}
+}
+
+// finish closes error channels of all remaining subscribers
+func (s *sharedBlockStream) finish() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for sb := range s.subscribers {
+		delete(s.subscribers, sb)
+		close(sb.err)
+	}
+}
+
+type sharedBlockSubscription struct {
+	stream      *sharedBlockStream
+	unsubscribe func()
+	queue       []*common.Block
+	queueSize   uint // queue isn't limited if size is zero, otherwise oldest blocks are dropped
+	queueMx     sync.Mutex
+	eof         bool
+	dropped     uint64
+	notify      chan struct{}
+	blocks      chan *common.Block
+	err         chan error
+	done        chan struct{}
+	once        sync.Once
+}
+
+// push queues block for subscriber, nil block means end of stream
+func (sb *sharedBlockSubscription) push(block *common.Block) {
+	sb.queueMx.Lock()
+	if block == nil {
+		sb.eof = true
+	} else {
+		if sb.queueSize > 0 && uint(len(sb.queue)) >= sb.queueSize {
+			sb.queue[0] = nil
+			sb.queue = sb.queue[1:]
+			atomic.AddUint64(&sb.dropped, 1)
+		}
+		sb.queue = append(sb.queue, block)
+	}
+	sb.queueMx.Unlock()
+
+	select {
+	case sb.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pump sends queued blocks to subscriber until subscription is closed
+func (sb *sharedBlockSubscription) pump() {
+	for {
+		sb.queueMx.Lock()
+		if len(sb.queue) == 0 {
+			eof := sb.eof
+			sb.queueMx.Unlock()
+
+			if eof {
+				close(sb.blocks)
+				return
+			}
+
+			select {
+			case <-sb.notify:
+				continue
+			case <-sb.done:
+				return
+			}
+		}
+
+		block := sb.queue[0]
+		sb.queue[0] = nil
+		sb.queue = sb.queue[1:]
+		sb.queueMx.Unlock()
+
+		select {
+		case sb.blocks <- block:
+		case <-sb.done:
+			return
+		}
+	}
+}
+
+func (sb *sharedBlockSubscription) Blocks() <-chan *common.Block {
+	return sb.blocks
+}
+
+func (sb *sharedBlockSubscription) OverflowPolicy() api.BlockOverflowPolicy {
+	if sb.queueSize > 0 {
+		return api.OverflowDropOldest
+	}
+	return api.OverflowBlock
+}
+
+func (sb *sharedBlockSubscription) Dropped() uint64 {
+	return atomic.LoadUint64(&sb.dropped)
+}
+
+func (sb *sharedBlockSubscription) Errors() chan error {
+	return sb.err
+}
+
+func (sb *sharedBlockSubscription) Close() error {
+	sb.once.Do(func() {
+		close(sb.done)
+		// error channel is closed by stream if subscriber was removed on stream finish
+		if sb.stream.remove(sb) {
+			close(sb.err)
+		}
+		sb.unsubscribe()
+	})
+
+	return nil
+}
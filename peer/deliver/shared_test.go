@@ -0,0 +1,164 @@
+package deliver_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/crypto"
+	"github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+	"github.com/s7techlab/hlf-sdk-go/identity"
+	"github.com/s7techlab/hlf-sdk-go/peer/deliver"
+)
+
+// feedDeliverClient opens deliver streams which receive blocks sent to feed
+type feedDeliverClient struct {
+	peer.DeliverClient
+	feed    chan *common.Block
+	streams int32
+}
+
+func (c *feedDeliverClient) Deliver(ctx context.Context, _ ...grpc.CallOption) (peer.Deliver_DeliverClient, error) {
+	atomic.AddInt32(&c.streams, 1)
+	return &feedStream{ctx: ctx, feed: c.feed}, nil
+}
+
+type feedStream struct {
+	peer.Deliver_DeliverClient
+	ctx  context.Context
+	feed chan *common.Block
+}
+
+func (s *feedStream) Send(*common.Envelope) error { return nil }
+
+func (s *feedStream) CloseSend() error { return nil }
+
+func (s *feedStream) Context() context.Context { return s.ctx }
+
+func (s *feedStream) Recv() (*peer.DeliverResponse, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case block := <-s.feed:
+		return &peer.DeliverResponse{Type: &peer.DeliverResponse_Block{Block: block}}, nil
+	}
+}
+
+func testIdentity(t *testing.T) msp.SigningIdentity {
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `../../client/chaincode/testdata/msp`)
+	require.NoError(t, err)
+
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	return id.GetSigningIdentity(cs)
+}
+
+func block(number uint64) *common.Block {
+	return &common.Block{Header: &common.BlockHeader{Number: number}}
+}
+
+func receive(t *testing.T, sub api.BlockSubscription) *common.Block {
+	select {
+	case b := <-sub.Blocks():
+		return b
+	case <-time.After(5 * time.Second):
+		t.Fatal(`block was not received`)
+		return nil
+	}
+}
+
+func TestShared(t *testing.T) {
+	t.Run(`independent cancellation`, func(t *testing.T) {
+		cli := &feedDeliverClient{feed: make(chan *common.Block)}
+		dc := deliver.NewShared(context.Background(), cli, testIdentity(t))
+
+		sub1, err := dc.SubscribeBlock(context.Background(), `channel`)
+		require.NoError(t, err)
+		ctx2, cancel2 := context.WithCancel(context.Background())
+		sub2, err := dc.SubscribeBlock(ctx2, `channel`)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), atomic.LoadInt32(&cli.streams))
+
+		cli.feed <- block(1)
+		require.Equal(t, uint64(1), receive(t, sub1).Header.Number)
+		require.Equal(t, uint64(1), receive(t, sub2).Header.Number)
+
+		cancel2()
+		_, ok := <-sub2.Errors()
+		require.False(t, ok)
+
+		// stream is kept for remaining subscriber
+		cli.feed <- block(2)
+		require.Equal(t, uint64(2), receive(t, sub1).Header.Number)
+		require.Equal(t, int32(1), atomic.LoadInt32(&cli.streams))
+
+		require.NoError(t, sub1.Close())
+		// stream is closed without subscribers and reopened for new one
+		sub3, err := dc.SubscribeBlock(context.Background(), `channel`)
+		require.NoError(t, err)
+		defer sub3.Close()
+		require.Equal(t, int32(2), atomic.LoadInt32(&cli.streams))
+	})
+
+	t.Run(`slow subscriber`, func(t *testing.T) {
+		cli := &feedDeliverClient{feed: make(chan *common.Block)}
+		dc := deliver.NewShared(context.Background(), cli, testIdentity(t))
+
+		slow, err := dc.SubscribeBlock(context.Background(), `channel`)
+		require.NoError(t, err)
+		defer slow.Close()
+		fast, err := dc.SubscribeBlock(context.Background(), `channel`)
+		require.NoError(t, err)
+		defer fast.Close()
+
+		// slow subscriber doesn't read, fast one still receives all blocks
+		for i := uint64(1); i <= 10; i++ {
+			cli.feed <- block(i)
+			require.Equal(t, i, receive(t, fast).Header.Number)
+		}
+
+		// queued blocks of slow subscriber are kept
+		for i := uint64(1); i <= 10; i++ {
+			require.Equal(t, i, receive(t, slow).Header.Number)
+		}
+	})
+
+	t.Run(`slow subscriber with drop oldest buffer`, func(t *testing.T) {
+		cli := &feedDeliverClient{feed: make(chan *common.Block)}
+		dc := deliver.NewShared(context.Background(), cli, testIdentity(t),
+			deliver.WithBlockBuffer(2, api.OverflowDropOldest))
+
+		slow, err := dc.SubscribeBlock(context.Background(), `channel`)
+		require.NoError(t, err)
+		defer slow.Close()
+		fast, err := dc.SubscribeBlock(context.Background(), `channel`)
+		require.NoError(t, err)
+		defer fast.Close()
+
+		for i := uint64(1); i <= 10; i++ {
+			cli.feed <- block(i)
+			require.Equal(t, i, receive(t, fast).Header.Number)
+		}
+
+		buffered, ok := slow.(api.BufferedBlockSubscription)
+		require.True(t, ok)
+		require.Equal(t, api.OverflowDropOldest, buffered.OverflowPolicy())
+		// block taken from queue before overflow can be received in addition to two latest blocks
+		var received []uint64
+		for len(received) == 0 || received[len(received)-1] != 10 {
+			received = append(received, receive(t, slow).Header.Number)
+		}
+		require.LessOrEqual(t, len(received), 3)
+		require.Equal(t, []uint64{9, 10}, received[len(received)-2:])
+		require.Equal(t, uint64(10-len(received)), buffered.Dropped())
+	})
+}
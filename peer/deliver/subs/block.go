@@ -1,7 +1,11 @@
 package subs
 
 import (
+	"sync/atomic"
+
 	"github.com/hyperledger/fabric-protos-go/common"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
 )
 
 type (
@@ -19,12 +23,32 @@ type (
 
 func NewBlockSubscription() *BlockSubscription {
 	return &BlockSubscription{
-		blocks: make(chan *common.Block, 0),
+		blocks: make(chan *common.Block),
+		policy: api.OverflowBlock,
 	}
 }
 
+// NewBufferedBlockSubscription returns block subscription with buffer of presented size.
+// Buffer of drop oldest subscription contains at least one block, unknown policy is rejected
+func NewBufferedBlockSubscription(size uint, policy api.BlockOverflowPolicy) (*BlockSubscription, error) {
+	policy, err := api.ParseBlockOverflowPolicy(string(policy))
+	if err != nil {
+		return nil, err
+	}
+	if policy == api.OverflowDropOldest && size == 0 {
+		size = 1
+	}
+
+	return &BlockSubscription{
+		blocks: make(chan *common.Block, size),
+		policy: policy,
+	}, nil
+}
+
 type BlockSubscription struct {
-	blocks chan *common.Block
+	blocks  chan *common.Block
+	policy  api.BlockOverflowPolicy
+	dropped uint64
 	ErrorCloser
 }
 
@@ -32,18 +56,50 @@ func (b *BlockSubscription) Blocks() <-chan *common.Block {
 	return b.blocks
 }
 
+func (b *BlockSubscription) OverflowPolicy() api.BlockOverflowPolicy {
+	return b.policy
+}
+
+func (b *BlockSubscription) Dropped() uint64 {
+	return atomic.LoadUint64(&b.dropped)
+}
+
 func (b *BlockSubscription) Handler(block *common.Block) bool {
 	if block == nil {
 		close(b.blocks)
-	} else {
+		return false
+	}
+
+	if b.policy == api.OverflowDropOldest {
+		return b.sendDropOldest(block)
+	}
+
+	select {
+	case b.blocks <- block:
+	case <-b.ErrorCloser.Done():
+		return true
+	}
+
+	return false
+}
+
+// sendDropOldest frees space in full buffer by dropping oldest block, so delivery stream is never blocked
+func (b *BlockSubscription) sendDropOldest(block *common.Block) bool {
+	for {
 		select {
-		case b.blocks <- block:
 		case <-b.ErrorCloser.Done():
 			return true
+		case b.blocks <- block:
+			return false
+		default:
 		}
-	}
 
-	return false
+		select {
+		case <-b.blocks:
+			atomic.AddUint64(&b.dropped, 1)
+		default:
+		}
+	}
 }
 
 func (b *BlockSubscription) Serve(base ErrorCloser, readyForHandling ReadyForHandling) *BlockSubscription {
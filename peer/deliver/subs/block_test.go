@@ -0,0 +1,101 @@
+package subs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// testCloser is stream of subscription which is done when closed
+type testCloser struct {
+	done chan struct{}
+}
+
+func (c *testCloser) Done() <-chan struct{} { return c.done }
+
+func (c *testCloser) Err() <-chan error { return nil }
+
+func (c *testCloser) Errors() chan error { return nil }
+
+func (c *testCloser) Close() error {
+	close(c.done)
+	return nil
+}
+
+func serve(t *testing.T, size uint, policy api.BlockOverflowPolicy) (*BlockSubscription, *testCloser) {
+	sub, err := NewBufferedBlockSubscription(size, policy)
+	require.NoError(t, err)
+
+	closer := &testCloser{done: make(chan struct{})}
+	return sub.Serve(closer, func() {}), closer
+}
+
+func block(number uint64) *common.Block {
+	return &common.Block{Header: &common.BlockHeader{Number: number}}
+}
+
+func TestBufferedBlockSubscription(t *testing.T) {
+	t.Run(`drop oldest`, func(t *testing.T) {
+		sub, _ := serve(t, 2, api.OverflowDropOldest)
+		require.Equal(t, api.OverflowDropOldest, sub.OverflowPolicy())
+
+		// handler never blocks, oldest blocks are evicted from full buffer
+		for i := uint64(1); i <= 5; i++ {
+			require.False(t, sub.Handler(block(i)))
+		}
+		require.Equal(t, uint64(3), sub.Dropped())
+
+		require.Equal(t, uint64(4), (<-sub.Blocks()).Header.Number)
+		require.Equal(t, uint64(5), (<-sub.Blocks()).Header.Number)
+	})
+
+	t.Run(`drop oldest without size`, func(t *testing.T) {
+		sub, _ := serve(t, 0, api.OverflowDropOldest)
+
+		require.False(t, sub.Handler(block(1)))
+		require.False(t, sub.Handler(block(2)))
+		require.Equal(t, uint64(1), sub.Dropped())
+		require.Equal(t, uint64(2), (<-sub.Blocks()).Header.Number)
+	})
+
+	t.Run(`block`, func(t *testing.T) {
+		sub, closer := serve(t, 1, ``)
+		require.Equal(t, api.OverflowBlock, sub.OverflowPolicy())
+
+		require.False(t, sub.Handler(block(1)))
+
+		handled := make(chan bool)
+		go func() {
+			handled <- sub.Handler(block(2))
+		}()
+
+		// handler waits for space in full buffer
+		select {
+		case <-handled:
+			t.Fatal(`handler is not blocked by full buffer`)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		require.Equal(t, uint64(1), (<-sub.Blocks()).Header.Number)
+		require.False(t, <-handled)
+		require.Equal(t, uint64(2), (<-sub.Blocks()).Header.Number)
+		require.Equal(t, uint64(0), sub.Dropped())
+
+		// blocked handler is released when subscription is closed
+		require.False(t, sub.Handler(block(3)))
+		go func() {
+			handled <- sub.Handler(block(4))
+		}()
+		require.NoError(t, closer.Close())
+		require.True(t, <-handled)
+	})
+
+	t.Run(`unknown policy`, func(t *testing.T) {
+		_, err := NewBufferedBlockSubscription(1, `drop_newest`)
+		require.Error(t, err)
+	})
+}
@@ -21,11 +21,17 @@ func NewTxSubscription(txId api.ChaincodeTx) *TxSubscription {
 type result struct {
 	code peer.TxValidationCode
 	err  error
+	// block is number of block with transaction, it is set if found is true
+	block uint64
+	found bool
 }
 
 type TxSubscription struct {
 	txId   api.ChaincodeTx
 	result chan *result
+	// block is number of block with transaction taken by Result
+	block      uint64
+	blockFound bool
 	ErrorCloser
 }
 
@@ -38,19 +44,13 @@ func (ts *TxSubscription) Serve(sub ErrorCloser, readyForHandling ReadyForHandli
 func (ts *TxSubscription) Result() (peer.TxValidationCode, error) {
 	select {
 	case r, ok := <-ts.result:
-		if !ok {
-			return -1, errors.New(`code is closed`)
-		}
-		return r.code, r.err
+		return ts.take(r, ok)
 	case err, ok := <-ts.Err():
 		if !ok {
 			// NOTE: sometime error can be closed early thet result
 			select {
 			case r, ok := <-ts.result:
-				if !ok {
-					return -1, errors.New(`code is closed`)
-				}
-				return r.code, r.err
+				return ts.take(r, ok)
 			default:
 				return -1, errors.New(`err is closed`)
 			}
@@ -59,6 +59,19 @@ func (ts *TxSubscription) Result() (peer.TxValidationCode, error) {
 	}
 }
 
+func (ts *TxSubscription) take(r *result, ok bool) (peer.TxValidationCode, error) {
+	if !ok {
+		return -1, errors.New(`code is closed`)
+	}
+	ts.block, ts.blockFound = r.block, r.found
+	return r.code, r.err
+}
+
+// BlockNumber returns number of block with transaction, it is known after Result returned validation code of transaction
+func (ts *TxSubscription) BlockNumber() (uint64, bool) {
+	return ts.block, ts.blockFound
+}
+
 func (ts *TxSubscription) Handler(block *common.Block) bool {
 	if block == nil {
 		close(ts.result)
@@ -90,12 +103,13 @@ func (ts *TxSubscription) Handler(block *common.Block) bool {
 		//println("TXID", chHeader.TxId, txFilter.IsValid(i))
 		if api.ChaincodeTx(chHeader.TxId) == ts.txId {
 			//defer ts.ErrorCloser.Close()
+			number := block.GetHeader().GetNumber()
 			if txFilter.IsValid(i) {
-				ts.result <- &result{code: txFilter.Flag(i), err: nil}
+				ts.result <- &result{code: txFilter.Flag(i), err: nil, block: number, found: true}
 				return true
 			} else {
-				err = errors.Errorf("TxId validation code failed: %s", peer.TxValidationCode_name[int32(txFilter.Flag(i))])
-				ts.result <- &result{code: txFilter.Flag(i), err: err}
+				err = api.InvalidTxError{TxId: ts.txId, Code: txFilter.Flag(i)}
+				ts.result <- &result{code: txFilter.Flag(i), err: err, block: number, found: true}
 				return true
 			}
 		}
@@ -0,0 +1,47 @@
+package subs
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+func txBlock(number uint64, txID string, code peer.TxValidationCode) *common.Block {
+	chHeader := protoutil.MakeChannelHeader(common.HeaderType_ENDORSER_TRANSACTION, 0, `channel`, 0)
+	chHeader.TxId = txID
+	payload := &common.Payload{Header: protoutil.MakePayloadHeader(chHeader, &common.SignatureHeader{})}
+	env := &common.Envelope{Payload: protoutil.MarshalOrPanic(payload)}
+
+	return &common.Block{
+		Header: &common.BlockHeader{Number: number},
+		Data:   &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(env)}},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{
+			common.BlockMetadataIndex_SIGNATURES:          {},
+			common.BlockMetadataIndex_LAST_CONFIG:         {},
+			common.BlockMetadataIndex_TRANSACTIONS_FILTER: {uint8(code)},
+		}},
+	}
+}
+
+func TestTxSubscriptionBlockNumber(t *testing.T) {
+	sub := NewTxSubscription(`tx`).Serve(&testCloser{done: make(chan struct{})}, func() {})
+
+	_, found := sub.BlockNumber()
+	require.False(t, found)
+
+	require.False(t, sub.Handler(txBlock(4, `other`, peer.TxValidationCode_VALID)))
+	require.True(t, sub.Handler(txBlock(5, `tx`, peer.TxValidationCode_MVCC_READ_CONFLICT)))
+
+	code, err := sub.Result()
+	require.IsType(t, api.InvalidTxError{}, err)
+	require.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, code)
+
+	number, found := sub.BlockNumber()
+	require.True(t, found)
+	require.Equal(t, uint64(5), number)
+}
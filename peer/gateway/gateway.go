@@ -0,0 +1,152 @@
+// Package gateway implements client of peer Gateway service available since Fabric 2.4
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricGateway "github.com/hyperledger/fabric-protos-go/gateway"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+const defaultDialTimeout = 5 * time.Second
+
+type gateway struct {
+	conn   *grpc.ClientConn
+	client fabricGateway.GatewayClient
+}
+
+// New dials peer with Gateway service
+func New(c config.ConnectionConfig, log *zap.Logger) (api.Gateway, error) {
+	opts, err := util.NewGRPCOptionsFromConfig(c, log)
+	if err != nil {
+		return nil, fmt.Errorf(`grpc options from config: %w`, err)
+	}
+
+	timeout := c.Timeout.Duration
+	if timeout == 0 {
+		timeout = defaultDialTimeout
+	}
+
+	log.Debug(`dial to gateway peer`, zap.String(`host`, c.Host), zap.Duration(`timeout`, timeout))
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, c.Host, opts...)
+	if err != nil {
+		return nil, fmt.Errorf(`grpc dial to host=%s: %w`, c.Host, err)
+	}
+
+	return NewFromGRPC(conn), nil
+}
+
+// NewFromGRPC creates Gateway client from existing GRPC connection
+func NewFromGRPC(conn *grpc.ClientConn) api.Gateway {
+	return &gateway{conn: conn, client: fabricGateway.NewGatewayClient(conn)}
+}
+
+func (g *gateway) Evaluate(ctx context.Context, channelName string, tx api.ChaincodeTx, proposal *fabricPeer.SignedProposal, targetOrgs ...string) (*fabricPeer.Response, error) {
+	resp, err := g.client.Evaluate(ctx, &fabricGateway.EvaluateRequest{
+		TransactionId:       string(tx),
+		ChannelId:           channelName,
+		ProposedTransaction: proposal,
+		TargetOrganizations: targetOrgs,
+	})
+	if err != nil {
+		return nil, gatewayError(ctx, err)
+	}
+	return resp.Result, nil
+}
+
+func (g *gateway) Endorse(ctx context.Context, channelName string, tx api.ChaincodeTx, proposal *fabricPeer.SignedProposal, endorsingOrgs ...string) (*common.Envelope, error) {
+	resp, err := g.client.Endorse(ctx, &fabricGateway.EndorseRequest{
+		TransactionId:          string(tx),
+		ChannelId:              channelName,
+		ProposedTransaction:    proposal,
+		EndorsingOrganizations: endorsingOrgs,
+	})
+	if err != nil {
+		return nil, gatewayError(ctx, err)
+	}
+	return resp.PreparedTransaction, nil
+}
+
+func (g *gateway) Submit(ctx context.Context, channelName string, tx api.ChaincodeTx, envelope *common.Envelope) error {
+	_, err := g.client.Submit(ctx, &fabricGateway.SubmitRequest{
+		TransactionId:       string(tx),
+		ChannelId:           channelName,
+		PreparedTransaction: envelope,
+	})
+	if err != nil {
+		return gatewayError(ctx, err)
+	}
+	return nil
+}
+
+func (g *gateway) CommitStatus(ctx context.Context, channelName string, tx api.ChaincodeTx, identity msp.SigningIdentity) (fabricPeer.TxValidationCode, uint64, error) {
+	creator, err := identity.Serialize()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, `failed to serialize identity`)
+	}
+
+	req, err := proto.Marshal(&fabricGateway.CommitStatusRequest{
+		TransactionId: string(tx),
+		ChannelId:     channelName,
+		Identity:      creator,
+	})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, `failed to marshal commit status request`)
+	}
+
+	signature, err := identity.Sign(req)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, `failed to sign commit status request`)
+	}
+
+	resp, err := g.client.CommitStatus(ctx, &fabricGateway.SignedCommitStatusRequest{Request: req, Signature: signature})
+	if err != nil {
+		return 0, 0, gatewayError(ctx, err)
+	}
+	return resp.Result, resp.BlockNumber, nil
+}
+
+func (g *gateway) Close() error {
+	return g.conn.Close()
+}
+
+// gatewayError returns context error if call is aborted by context,
+// otherwise details of failed endorsers or orderers are added to error message
+func gatewayError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var details []string
+	for _, d := range s.Details() {
+		if detail, ok := d.(*fabricGateway.ErrorDetail); ok {
+			details = append(details, fmt.Sprintf(`%s (%s): %s`, detail.Address, detail.MspId, detail.Message))
+		}
+	}
+	if len(details) == 0 {
+		return err
+	}
+	return errors.Wrap(err, strings.Join(details, `; `))
+}
@@ -0,0 +1,55 @@
+package gateway_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	fabricGateway "github.com/hyperledger/fabric-protos-go/gateway"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/s7techlab/hlf-sdk-go/peer/gateway"
+)
+
+type gatewayServer struct {
+	fabricGateway.UnimplementedGatewayServer
+}
+
+func (s *gatewayServer) Evaluate(_ context.Context, req *fabricGateway.EvaluateRequest) (*fabricGateway.EvaluateResponse, error) {
+	return &fabricGateway.EvaluateResponse{
+		Result: &fabricPeer.Response{Status: 200, Payload: []byte(req.ChannelId + `/` + req.TransactionId)}}, nil
+}
+
+func (s *gatewayServer) Endorse(context.Context, *fabricGateway.EndorseRequest) (*fabricGateway.EndorseResponse, error) {
+	st, _ := status.New(codes.Aborted, `failed to endorse transaction`).WithDetails(
+		&fabricGateway.ErrorDetail{Address: `peer0.org2:7051`, MspId: `org2msp`, Message: `chaincode response 500`})
+	return nil, st.Err()
+}
+
+func TestGateway(t *testing.T) {
+	lis, err := net.Listen(`tcp`, `127.0.0.1:0`)
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	fabricGateway.RegisterGatewayServer(srv, &gatewayServer{})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+
+	gw := gateway.NewFromGRPC(conn)
+	defer gw.Close()
+
+	resp, err := gw.Evaluate(context.Background(), `channel`, `tx1`, &fabricPeer.SignedProposal{})
+	require.NoError(t, err)
+	require.Equal(t, `channel/tx1`, string(resp.Payload))
+
+	_, err = gw.Endorse(context.Background(), `channel`, `tx2`, &fabricPeer.SignedProposal{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `peer0.org2:7051 (org2msp): chaincode response 500`)
+}
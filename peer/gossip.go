@@ -0,0 +1,95 @@
+package peer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/msp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// GossipMembers queries discovery service of peer for alive channel members known by its gossip.
+// api.ErrGossipMembershipNotSupported is returned if peer discovery service is disabled or not implemented
+func GossipMembers(ctx context.Context, peer api.Peer, identity msp.SigningIdentity, channelName string) ([]api.GossipMember, error) {
+	clientIdentity, err := identity.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf(`serialize identity: %w`, err)
+	}
+
+	req, err := proto.Marshal(&discovery.Request{
+		Authentication: &discovery.AuthInfo{ClientIdentity: clientIdentity},
+		Queries: []*discovery.Query{{
+			Channel: channelName,
+			Query:   &discovery.Query_PeerQuery{PeerQuery: &discovery.PeerMembershipQuery{}},
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf(`marshal discovery request: %w`, err)
+	}
+
+	signature, err := identity.Sign(req)
+	if err != nil {
+		return nil, fmt.Errorf(`sign discovery request: %w`, err)
+	}
+
+	resp, err := discovery.NewDiscoveryClient(peer.Conn()).Discover(ctx, &discovery.SignedRequest{Payload: req, Signature: signature})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, fmt.Errorf(`peer %s: %w`, peer.Uri(), api.ErrGossipMembershipNotSupported)
+		}
+		return nil, fmt.Errorf(`peer %s: discover: %w`, peer.Uri(), err)
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, fmt.Errorf(`peer %s: %w`, peer.Uri(), api.ErrGossipMembershipNotSupported)
+	}
+
+	switch result := resp.Results[0].Result.(type) {
+	case *discovery.QueryResult_Members:
+		return gossipMembers(result.Members)
+	case *discovery.QueryResult_Error:
+		return nil, fmt.Errorf(`peer %s: discovery error: %s`, peer.Uri(), result.Error.Content)
+	default:
+		return nil, fmt.Errorf(`peer %s: unexpected discovery result %T`, peer.Uri(), result)
+	}
+}
+
+func gossipMembers(result *discovery.PeerMembershipResult) ([]api.GossipMember, error) {
+	var members []api.GossipMember
+	for mspID, peers := range result.PeersByOrg {
+		for _, p := range peers.Peers {
+			member := api.GossipMember{MspID: mspID}
+
+			alive, err := unmarshalGossipMessage(p.MembershipInfo)
+			if err != nil {
+				return nil, fmt.Errorf(`membership info of %s member: %w`, mspID, err)
+			}
+			member.Endpoint = alive.GetAliveMsg().GetMembership().GetEndpoint()
+
+			if p.StateInfo != nil {
+				state, err := unmarshalGossipMessage(p.StateInfo)
+				if err != nil {
+					return nil, fmt.Errorf(`state info of %s member: %w`, mspID, err)
+				}
+				member.LedgerHeight = state.GetStateInfo().GetProperties().GetLedgerHeight()
+			}
+
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func unmarshalGossipMessage(env *gossip.Envelope) (*gossip.GossipMessage, error) {
+	msg := new(gossip.GossipMessage)
+	if err := proto.Unmarshal(env.GetPayload(), msg); err != nil {
+		return nil, fmt.Errorf(`unmarshal gossip message: %w`, err)
+	}
+	return msg, nil
+}
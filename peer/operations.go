@@ -0,0 +1,94 @@
+package peer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// MaxChaincodeLogsSize limits size of chaincode logs read from operations endpoint, rest of logs is dropped
+var MaxChaincodeLogsSize int64 = 10 << 20
+
+// OperationsClient calls HTTP operations endpoint of peer
+type OperationsClient struct {
+	url      string
+	client   *http.Client
+	logsPath string
+}
+
+// OperationsOpt is option of operations client
+type OperationsOpt func(c *OperationsClient)
+
+// WithHTTPClient sets HTTP client used for calls, e.g. configured with TLS of operations endpoint
+func WithHTTPClient(client *http.Client) OperationsOpt {
+	return func(c *OperationsClient) {
+		c.client = client
+	}
+}
+
+// WithChaincodeLogsPath sets path of chaincode logs, %s in path is replaced with chaincode name.
+// Peer doesn't serve chaincode logs itself, so path is defined by extension or proxy and is required for ChaincodeLogs
+func WithChaincodeLogsPath(path string) OperationsOpt {
+	return func(c *OperationsClient) {
+		c.logsPath = path
+	}
+}
+
+// NewOperationsClient returns client of peer operations endpoint, e.g. `https://peer0:9443`
+func NewOperationsClient(operationsURL string, opts ...OperationsOpt) *OperationsClient {
+	c := &OperationsClient{
+		url:    strings.TrimRight(operationsURL, `/`),
+		client: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ChaincodeLogs returns last lines of chaincode container logs, all available lines are returned if tail is zero.
+// Peer doesn't expose chaincode logs by default, they are served by operations endpoint extensions or proxies,
+// so api.ErrChaincodeLogsNotExposed is returned if logs path isn't set or endpoint doesn't serve it.
+// Logs are read up to MaxChaincodeLogsSize
+func (c *OperationsClient) ChaincodeLogs(ctx context.Context, ccName string, tail int) ([]byte, error) {
+	if c.logsPath == `` {
+		return nil, fmt.Errorf(`chaincode logs path is not set: %w`, api.ErrChaincodeLogsNotExposed)
+	}
+
+	logsURL := c.url + fmt.Sprintf(c.logsPath, url.PathEscape(ccName))
+	if tail > 0 {
+		logsURL += `?tail=` + strconv.Itoa(tail)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, logsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf(`create http request: %w`, err)
+	}
+
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf(`process http request: %w`, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxChaincodeLogsSize))
+	if err != nil {
+		return nil, fmt.Errorf(`read response body: %w`, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, fmt.Errorf(`%s: %w`, c.url, api.ErrChaincodeLogsNotExposed)
+	default:
+		return nil, api.ErrUnexpectedHTTPStatus{Status: resp.StatusCode, Body: body}
+	}
+}
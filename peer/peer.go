@@ -25,6 +25,14 @@ type peer struct {
 	connMx    sync.Mutex
 	timeout   time.Duration
 	client    fabricPeer.EndorserClient
+	// deliverOpts are applied to each deliver client of peer
+	deliverOpts []deliver.Opt
+	// sharedDeliver enables deliver clients, which share block streams, one client per identity
+	sharedDeliver  bool
+	delivers       map[string]api.DeliverClient
+	deliversMx     sync.Mutex
+	deliversCtx    context.Context
+	deliversCancel context.CancelFunc
 }
 
 var (
@@ -43,17 +51,59 @@ func (p *peer) Endorse(ctx context.Context, proposal *fabricPeer.SignedProposal,
 	}
 
 	if resp, err := p.client.ProcessProposal(ctx, proposal); err != nil {
+		// call is aborted by cancelled context or expired deadline, context error is returned instead of GRPC status
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, err
 	} else {
 		if resp.Response.Status != shim.OK {
-			return nil, api.PeerEndorseError{Status: resp.Response.Status, Message: resp.Response.Message}
+			return nil, api.PeerEndorseError{Status: resp.Response.Status, Message: resp.Response.Message,
+				Payload: resp.Response.Payload, Peer: p.Uri(), Response: resp}
 		}
 		return resp, nil
 	}
 }
 
 func (p *peer) DeliverClient(identity msp.SigningIdentity) (api.DeliverClient, error) {
-	return deliver.New(fabricPeer.NewDeliverClient(p.conn), identity), nil
+	return p.deliverClient(identity, nil)
+}
+
+// deliverClient returns deliver client of identity with block verification if verifiers are presented.
+// If shared deliver is enabled, client is created once, so its subscriptions share streams
+func (p *peer) deliverClient(identity msp.SigningIdentity, verifiers deliver.BlockVerifierProvider) (api.DeliverClient, error) {
+	opts := p.deliverOpts
+	if verifiers != nil {
+		opts = append(append([]deliver.Opt{}, opts...), deliver.WithBlockVerifier(verifiers))
+	}
+
+	if !p.sharedDeliver {
+		return deliver.New(fabricPeer.NewDeliverClient(p.conn), identity, opts...), nil
+	}
+
+	creator, err := identity.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to serialize identity`)
+	}
+	key := string(creator)
+	if verifiers != nil {
+		key = `verified:` + key
+	}
+
+	p.deliversMx.Lock()
+	defer p.deliversMx.Unlock()
+
+	if dc, ok := p.delivers[key]; ok {
+		return dc, nil
+	}
+	if p.delivers == nil {
+		p.delivers = make(map[string]api.DeliverClient)
+		p.deliversCtx, p.deliversCancel = context.WithCancel(context.Background())
+	}
+
+	dc := deliver.NewShared(p.deliversCtx, fabricPeer.NewDeliverClient(p.conn), identity, opts...)
+	p.delivers[key] = dc
+	return dc, nil
 }
 
 func (p *peer) Conn() *grpc.ClientConn {
@@ -65,6 +115,12 @@ func (p *peer) Uri() string {
 }
 
 func (p *peer) Close() error {
+	p.deliversMx.Lock()
+	if p.deliversCancel != nil {
+		p.deliversCancel()
+	}
+	p.deliversMx.Unlock()
+
 	return p.conn.Close()
 }
 
@@ -82,6 +138,11 @@ func (p *peer) initEndorserClient() error {
 
 // New returns new peer instance based on peer config
 func New(c config.ConnectionConfig, log *zap.Logger) (api.Peer, error) {
+	bufferPolicy, err := api.ParseBlockOverflowPolicy(c.BlockBuffer.Policy)
+	if err != nil {
+		return nil, fmt.Errorf(`block buffer of peer %s: %w`, c.Host, err)
+	}
+
 	opts, err := util.NewGRPCOptionsFromConfig(c, log)
 	if err != nil {
 		return nil, fmt.Errorf(`grpc options from config: %w`, err)
@@ -91,16 +152,36 @@ func New(c config.ConnectionConfig, log *zap.Logger) (api.Peer, error) {
 	if timeout == 0 {
 		timeout = defaultTimeout
 	}
+	dialTimeout, callTimeout := timeout, timeout
+	if c.DialTimeout.Duration > 0 {
+		dialTimeout = c.DialTimeout.Duration
+	}
+	if c.CallTimeout.Duration > 0 {
+		callTimeout = c.CallTimeout.Duration
+	}
 
-	//ctx, _ := context.WithTimeout(context.Background(), c.Timeout.Duration)
-	log.Debug(`dial to peer`, zap.String(`host`, c.Host), zap.Duration(`timeout`, timeout))
-	ctx, _ := context.WithTimeout(context.Background(), timeout)
+	log.Debug(`dial to peer`, zap.String(`host`, c.Host),
+		zap.Duration(`dial timeout`, dialTimeout), zap.Duration(`call timeout`, callTimeout))
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
 	conn, err := grpc.DialContext(ctx, c.Host, opts...)
 	if err != nil {
 		return nil, fmt.Errorf(`grpc dial to host=%s: %w`, c.Host, err)
 	}
 
-	return NewFromGRPC(conn, log, timeout)
+	p, err := NewFromGRPC(conn, log, callTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.BlockBuffer.Size > 0 || c.BlockBuffer.Policy != `` {
+		pp := p.(*peer)
+		pp.deliverOpts = append(pp.deliverOpts,
+			deliver.WithBlockBuffer(c.BlockBuffer.Size, bufferPolicy))
+	}
+	p.(*peer).sharedDeliver = c.SharedDeliver
+
+	return p, nil
 }
 
 // NewFromGRPC allows to initialize peer from existing GRPC connection
@@ -0,0 +1,56 @@
+package pool
+
+import (
+	"context"
+	"time"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+const (
+	DefaultHealthCheckInterval         = 10 * time.Second
+	DefaultHealthCheckFailureThreshold = 3
+	DefaultPingTimeout                 = 5 * time.Second
+)
+
+// StrategyHealthCheck periodically waits for peer GRPC connection to become ready, so connection is dialed if it is idle.
+// Peer is reported as dead after failureThreshold consecutive failed checks and as alive again after first successful check
+func StrategyHealthCheck(interval time.Duration, failureThreshold int) api.PeerPoolCheckStrategy {
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultHealthCheckFailureThreshold
+	}
+
+	return func(ctx context.Context, peer api.Peer, alive chan bool) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		failures := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			err := util.WaitForReady(checkCtx, peer.Conn())
+			cancel()
+
+			if err == nil {
+				failures = 0
+			} else {
+				failures++
+			}
+
+			select {
+			case alive <- failures < failureThreshold:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
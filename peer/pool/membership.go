@@ -0,0 +1,63 @@
+package pool
+
+import (
+	"context"
+	"sync"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// Opt is peer pool option
+type Opt func(p *peerPool)
+
+// WithMembershipHandler sets handler notified when peers are added to or removed from pool.
+// Handler is called in separate goroutine, so slow handler doesn't delay pool changes, and is stopped on pool Close
+func WithMembershipHandler(handler api.PoolMembershipHandler) Opt {
+	return func(p *peerPool) {
+		p.membership = &membershipNotifier{handler: handler, signal: make(chan struct{}, 1)}
+	}
+}
+
+// membershipNotifier queues membership events and passes them to handler in order
+type membershipNotifier struct {
+	handler api.PoolMembershipHandler
+	events  []api.PoolMembershipEvent
+	mx      sync.Mutex
+	signal  chan struct{}
+}
+
+// notify queues event without blocking
+func (n *membershipNotifier) notify(event api.PoolMembershipEvent) {
+	if n == nil {
+		return
+	}
+
+	n.mx.Lock()
+	n.events = append(n.events, event)
+	n.mx.Unlock()
+
+	select {
+	case n.signal <- struct{}{}:
+	default:
+	}
+}
+
+// run passes queued events to handler until context is done
+func (n *membershipNotifier) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-n.signal:
+		}
+
+		n.mx.Lock()
+		events := n.events
+		n.events = nil
+		n.mx.Unlock()
+
+		for _, event := range events {
+			n.handler(event)
+		}
+	}
+}
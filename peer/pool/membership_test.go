@@ -0,0 +1,132 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+)
+
+type uriPeer struct {
+	api.Peer
+	uri    string
+	closed bool
+}
+
+func (p *uriPeer) Uri() string { return p.uri }
+
+func (p *uriPeer) Close() error {
+	p.closed = true
+	return nil
+}
+
+func noCheck(ctx context.Context, _ api.Peer, _ chan bool) {
+	<-ctx.Done()
+}
+
+func TestMembershipHandler(t *testing.T) {
+	events := make(chan api.PoolMembershipEvent, 10)
+	peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{},
+		pool.WithMembershipHandler(func(event api.PoolMembershipEvent) {
+			events <- event
+		}))
+	defer peerPool.Close()
+
+	p := &uriPeer{uri: `peer0:7051`}
+	require.NoError(t, peerPool.Add(`org1msp`, p, noCheck))
+	require.NoError(t, peerPool.Remove(`org1msp`, `peer0:7051`))
+	require.True(t, p.closed)
+	require.Error(t, peerPool.Remove(`org1msp`, `peer0:7051`))
+
+	for _, expected := range []api.PoolMembershipEventType{api.PoolMembershipPeerAdded, api.PoolMembershipPeerRemoved} {
+		select {
+		case event := <-events:
+			require.Equal(t, expected, event.Type)
+			require.Equal(t, `org1msp`, event.MspID)
+			require.Equal(t, `peer0:7051`, event.Address)
+		case <-time.After(time.Second):
+			t.Fatalf(`%s event not received`, expected)
+		}
+	}
+	require.Empty(t, peerPool.Peers()[`org1msp`])
+}
+
+type blockingPeer struct {
+	uriPeer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingPeer) Endorse(context.Context, *peer.SignedProposal, ...api.PeerEndorseOpt) (*peer.ProposalResponse, error) {
+	close(p.started)
+	<-p.release
+	return &peer.ProposalResponse{}, nil
+}
+
+func TestRemoveWaitsForEndorsement(t *testing.T) {
+	peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{})
+	defer peerPool.Close()
+
+	p := &blockingPeer{uriPeer: uriPeer{uri: `peer0:7051`}, started: make(chan struct{}), release: make(chan struct{})}
+	require.NoError(t, peerPool.Add(`org1msp`, p, noCheck))
+
+	endorsed := make(chan error)
+	go func() {
+		_, err := peerPool.Process(context.Background(), `org1msp`, &peer.SignedProposal{})
+		endorsed <- err
+	}()
+	<-p.started
+
+	removed := make(chan error)
+	go func() {
+		removed <- peerPool.Remove(`org1msp`, `peer0:7051`)
+	}()
+
+	select {
+	case <-removed:
+		t.Fatal(`peer removed before endorsement completed`)
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.False(t, p.closed)
+
+	close(p.release)
+	require.NoError(t, <-endorsed)
+	require.NoError(t, <-removed)
+	require.True(t, p.closed)
+}
+
+func TestClose(t *testing.T) {
+	peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{})
+
+	p := &blockingPeer{uriPeer: uriPeer{uri: `peer0:7051`}, started: make(chan struct{}), release: make(chan struct{})}
+	require.NoError(t, peerPool.Add(`org1msp`, p, noCheck))
+
+	endorsed := make(chan error)
+	go func() {
+		_, err := peerPool.Process(context.Background(), `org1msp`, &peer.SignedProposal{})
+		endorsed <- err
+	}()
+	<-p.started
+
+	closed := make(chan error)
+	go func() {
+		closed <- peerPool.Close()
+	}()
+
+	close(p.release)
+	require.NoError(t, <-endorsed)
+	require.NoError(t, <-closed)
+	require.True(t, p.closed)
+
+	_, err := peerPool.Process(context.Background(), `org1msp`, &peer.SignedProposal{})
+	require.ErrorIs(t, err, api.ErrClientClosed)
+	require.ErrorIs(t, peerPool.Add(`org1msp`, &uriPeer{uri: `peer1:7051`}, noCheck), api.ErrClientClosed)
+	require.NoError(t, peerPool.Close())
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/pkg/errors"
 	"github.com/s7techlab/hlf-sdk-go/api"
 	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/util"
+	"github.com/s7techlab/hlf-sdk-go/util/breaker"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -24,11 +28,21 @@ type peerPool struct {
 
 	store   map[string][]*peerPoolPeer
 	storeMx sync.RWMutex
+	closed  bool
+
+	membership *membershipNotifier
+	strategy   Strategy
 }
 
 type peerPoolPeer struct {
 	peer  api.Peer
 	ready bool
+	since time.Time
+	// cancel stops peer checks
+	cancel context.CancelFunc
+	// inflight tracks endorsements in progress, so removed peer is closed after they're completed
+	inflight sync.WaitGroup
+	removed  bool
 }
 
 func (p *peerPool) Add(mspId string, peer api.Peer, peerChecker api.PeerPoolCheckStrategy) error {
@@ -37,6 +51,10 @@ func (p *peerPool) Add(mspId string, peer api.Peer, peerChecker api.PeerPoolChec
 	p.storeMx.Lock()
 	defer p.storeMx.Unlock()
 
+	if p.closed {
+		return api.ErrClientClosed
+	}
+
 	if peers, ok := p.store[mspId]; !ok {
 		p.store[mspId] = p.addPeer(peer, make([]*peerPoolPeer, 0), peerChecker)
 	} else {
@@ -46,14 +64,66 @@ func (p *peerPool) Add(mspId string, peer api.Peer, peerChecker api.PeerPoolChec
 			p.store[mspId] = p.addPeer(peer, peers, peerChecker)
 		}
 	}
+
+	p.membership.notify(api.PoolMembershipEvent{
+		Type: api.PoolMembershipPeerAdded, MspID: mspId, Address: peer.Uri(), At: time.Now()})
+	return nil
+}
+
+func (p *peerPool) Remove(mspId string, address string) error {
+	p.storeMx.Lock()
+	peers, ok := p.store[mspId]
+	if !ok {
+		p.storeMx.Unlock()
+		return api.ErrMSPNotFound
+	}
+
+	var removed *peerPoolPeer
+	for i, pp := range peers {
+		if pp.peer.Uri() == address {
+			removed = pp
+			removed.removed = true
+			p.store[mspId] = append(peers[:i:i], peers[i+1:]...)
+			break
+		}
+	}
+	p.storeMx.Unlock()
+
+	if removed == nil {
+		return fmt.Errorf(`peer %s: %w`, address, api.ErrPeerNotFound)
+	}
+
+	removed.cancel()
+	p.membership.notify(api.PoolMembershipEvent{
+		Type: api.PoolMembershipPeerRemoved, MspID: mspId, Address: address, At: time.Now()})
+
+	// peer is not used by new endorsements after removal, so wait is bounded by endorsements in progress
+	removed.inflight.Wait()
+
+	if err := removed.peer.Close(); err != nil {
+		return errors.Wrapf(err, `failed to close peer %s`, address)
+	}
 	return nil
 }
 
+// acquire marks endorsement on peer in progress, false is returned if peer is already removed from pool
+func (p *peerPool) acquire(pp *peerPoolPeer) bool {
+	p.storeMx.Lock()
+	defer p.storeMx.Unlock()
+
+	if pp.removed {
+		return false
+	}
+	pp.inflight.Add(1)
+	return true
+}
+
 func (p *peerPool) addPeer(peer api.Peer, peerSet []*peerPoolPeer, peerChecker api.PeerPoolCheckStrategy) []*peerPoolPeer {
-	pp := &peerPoolPeer{peer: peer, ready: true}
+	ctx, cancel := context.WithCancel(p.ctx)
+	pp := &peerPoolPeer{peer: peer, ready: true, cancel: cancel}
 	aliveChan := make(chan bool)
-	go peerChecker(p.ctx, peer, aliveChan)
-	go p.poolChecker(p.ctx, aliveChan, pp)
+	go peerChecker(ctx, peer, aliveChan)
+	go p.poolChecker(ctx, aliveChan, pp)
 	return append(peerSet, pp)
 }
 
@@ -87,6 +157,9 @@ func (p *peerPool) poolChecker(ctx context.Context, aliveChan chan bool, peer *p
 			}
 
 			p.storeMx.Lock()
+			if peer.ready != alive {
+				peer.since = time.Now()
+			}
 			peer.ready = alive
 			p.storeMx.Unlock()
 		}
@@ -98,8 +171,13 @@ func (p *peerPool) Process(ctx context.Context, mspId string, proposal *peer.Sig
 	p.storeMx.RLock()
 	//check MspId exists
 	peers, ok := p.store[mspId]
+	closed := p.closed
 	p.storeMx.RUnlock()
 
+	if closed {
+		return nil, api.ErrClientClosed
+	}
+
 	if !ok {
 		log.Error(api.ErrMSPNotFound.Error(), zap.String(`mspId`, mspId))
 		return nil, api.ErrMSPNotFound
@@ -114,20 +192,44 @@ func (p *peerPool) Process(ctx context.Context, mspId string, proposal *peer.Sig
 
 	var lastError error
 
-	for pos, poolPeer := range peers {
+	for pos, poolPeer := range p.order(peers) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
 		if !poolPeer.ready {
 			log.Debug(api.ErrPeerNotReady.Error(), zap.String(`uri`, poolPeer.peer.Uri()))
 			continue
 		}
 
+		if !p.acquire(poolPeer) {
+			continue
+		}
+
 		log.Debug(`Endorse sent on peer`, zap.Int(`peerPos`, pos), zap.String(`mspId`, mspId), zap.String(`uri`, poolPeer.peer.Uri()))
 
-		if propResp, err := poolPeer.peer.Endorse(ctx, proposal); err != nil {
+		propResp, err := poolPeer.peer.Endorse(ctx, proposal)
+		poolPeer.inflight.Done()
+
+		if err != nil {
+			// don't try next peers if endorsement is cancelled by caller
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			if err == api.ErrCircuitOpen {
+				log.Debug(`Peer circuit breaker is open`, zap.String(`mspId`, mspId), zap.String(`peer_uri`, poolPeer.peer.Uri()))
+				lastError = err
+				continue
+			}
+
 			// GRPC error
 			if s, ok := status.FromError(err); ok {
 				if s.Code() == codes.Unavailable {
 					log.Debug(`Peer GRPC unavailable`, zap.String(`mspId`, mspId), zap.String(`peer_uri`, poolPeer.peer.Uri()))
 					//poolPeer.ready = false
+					lastError = api.PeerUnavailableError{Peer: poolPeer.peer.Uri(), Err: err}
+					continue
 				} else {
 					log.Debug(`Unexpected GRPC error code from peer`,
 						zap.String(`peer_uri`, poolPeer.peer.Uri()), zap.Uint32(`code`, uint32(s.Code())),
@@ -170,8 +272,13 @@ func (p *peerPool) getFirstReadyPeer(mspId string) (api.Peer, error) {
 	//check MspId exists
 	log.Debug(`Searching peers for MspId`, zap.String(`mspId`, mspId))
 	peers, ok := p.store[mspId]
+	closed := p.closed
 	p.storeMx.RUnlock()
 
+	if closed {
+		return nil, api.ErrClientClosed
+	}
+
 	if !ok {
 		log.Error(api.ErrMSPNotFound.Error(), zap.String(`mspId`, mspId))
 		return nil, api.ErrMSPNotFound
@@ -184,7 +291,7 @@ func (p *peerPool) getFirstReadyPeer(mspId string) (api.Peer, error) {
 
 	log.Debug(`Peers pool`, zap.String(`mspId`, mspId), zap.Int(`peerNum`, len(peers)))
 
-	for _, poolPeer := range peers {
+	for _, poolPeer := range p.order(peers) {
 		if poolPeer.ready == true {
 			return poolPeer.peer, nil
 		}
@@ -193,11 +300,121 @@ func (p *peerPool) getFirstReadyPeer(mspId string) (api.Peer, error) {
 	return nil, api.ErrNoReadyPeers{MspId: mspId}
 }
 
+func (p *peerPool) Conn(mspId string, address string) (*grpc.ClientConn, error) {
+	p.storeMx.RLock()
+	defer p.storeMx.RUnlock()
+
+	peers, ok := p.store[mspId]
+	if !ok {
+		return nil, api.ErrMSPNotFound
+	}
+
+	for _, poolPeer := range peers {
+		if poolPeer.peer.Uri() == address {
+			return poolPeer.peer.Conn(), nil
+		}
+	}
+
+	return nil, fmt.Errorf(`peer %s: %w`, address, api.ErrPeerNotFound)
+}
+
+func (p *peerPool) Peers() map[string][]api.Peer {
+	p.storeMx.RLock()
+	defer p.storeMx.RUnlock()
+
+	peers := make(map[string][]api.Peer, len(p.store))
+	for mspId, poolPeers := range p.store {
+		for _, poolPeer := range poolPeers {
+			peers[mspId] = append(peers[mspId], poolPeer.peer)
+		}
+	}
+	return peers
+}
+
+func (p *peerPool) Status() map[string][]api.PeerStatus {
+	p.storeMx.RLock()
+	defer p.storeMx.RUnlock()
+
+	statuses := make(map[string][]api.PeerStatus, len(p.store))
+	for mspId, poolPeers := range p.store {
+		for _, poolPeer := range poolPeers {
+			status := api.PeerStatus{
+				Address: poolPeer.peer.Uri(),
+				Ready:   poolPeer.ready,
+				Since:   poolPeer.since,
+			}
+			if bp, ok := poolPeer.peer.(interface{ BreakerState() breaker.State }); ok {
+				status.Circuit = bp.BreakerState().String()
+			}
+			statuses[mspId] = append(statuses[mspId], status)
+		}
+	}
+	return statuses
+}
+
+func (p *peerPool) Ping(ctx context.Context, mspId string, address string) (time.Duration, error) {
+	conn, err := p.Conn(mspId, address)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultPingTimeout)
+		defer cancel()
+	}
+
+	latency, err := util.Ping(ctx, conn)
+	if err != nil {
+		return latency, fmt.Errorf(`peer %s: %w`, address, err)
+	}
+	return latency, nil
+}
+
+// Close stops peer checks and membership handler and closes peers after endorsements in progress complete.
+// Subsequent calls of pool return ErrClientClosed
 func (p *peerPool) Close() error {
+	p.storeMx.Lock()
+	if p.closed {
+		p.storeMx.Unlock()
+		return nil
+	}
+	p.closed = true
+	store := p.store
+	p.store = make(map[string][]*peerPoolPeer)
+	for _, peers := range store {
+		for _, pp := range peers {
+			pp.removed = true
+		}
+	}
+	p.storeMx.Unlock()
+
+	p.cancel()
+
+	var errs api.MultiError
+	for _, peers := range store {
+		for _, pp := range peers {
+			pp.inflight.Wait()
+			if err := pp.peer.Close(); err != nil {
+				errs.Add(errors.Wrapf(err, `failed to close peer %s`, pp.peer.Uri()))
+			}
+		}
+	}
+	if len(errs.Errors) > 0 {
+		return &errs
+	}
 	return nil
 }
 
-func New(ctx context.Context, log *zap.Logger, config config.PoolConfig) api.PeerPool {
+func New(ctx context.Context, log *zap.Logger, config config.PoolConfig, opts ...Opt) api.PeerPool {
 	ctx, cancel := context.WithCancel(ctx)
-	return &peerPool{store: make(map[string][]*peerPoolPeer), log: log.Named(`PeerPool`), ctx: ctx, cancel: cancel, config: config}
+	p := &peerPool{store: make(map[string][]*peerPoolPeer), log: log.Named(`PeerPool`), ctx: ctx, cancel: cancel, config: config}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.membership != nil {
+		go p.membership.run(ctx)
+	}
+	return p
 }
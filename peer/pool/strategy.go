@@ -0,0 +1,89 @@
+package pool
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// Strategy selects peer of MSP to send proposal to. Select is called with ready peers of MSP in order of adding to pool,
+// other peers are tried in that order if selected peer fails. Nil result leaves order of peers unchanged
+type Strategy interface {
+	Select(peers []api.Peer) api.Peer
+}
+
+// StrategyFunc allows to use function as Strategy
+type StrategyFunc func(peers []api.Peer) api.Peer
+
+func (f StrategyFunc) Select(peers []api.Peer) api.Peer {
+	return f(peers)
+}
+
+// WithStrategy sets strategy of peer selection. By default, first ready peer of MSP is selected
+func WithStrategy(strategy Strategy) Opt {
+	return func(p *peerPool) {
+		p.strategy = strategy
+	}
+}
+
+// StrategyRoundRobin selects ready peers in turn
+func StrategyRoundRobin() Strategy {
+	var counter uint64
+	return StrategyFunc(func(peers []api.Peer) api.Peer {
+		if len(peers) == 0 {
+			return nil
+		}
+		return peers[(atomic.AddUint64(&counter, 1)-1)%uint64(len(peers))]
+	})
+}
+
+// StrategyRandom selects random ready peer
+func StrategyRandom() Strategy {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var mx sync.Mutex
+	return StrategyFunc(func(peers []api.Peer) api.Peer {
+		if len(peers) == 0 {
+			return nil
+		}
+		mx.Lock()
+		defer mx.Unlock()
+		return peers[rnd.Intn(len(peers))]
+	})
+}
+
+// order returns peers in order of attempts, peer selected by strategy goes first
+func (p *peerPool) order(peers []*peerPoolPeer) []*peerPoolPeer {
+	if p.strategy == nil {
+		return peers
+	}
+
+	var ready []api.Peer
+	p.storeMx.RLock()
+	for _, pp := range peers {
+		if pp.ready {
+			ready = append(ready, pp.peer)
+		}
+	}
+	p.storeMx.RUnlock()
+
+	selected := p.strategy.Select(ready)
+	if selected == nil {
+		return peers
+	}
+
+	ordered := make([]*peerPoolPeer, 0, len(peers))
+	for _, pp := range peers {
+		if pp.peer == selected {
+			ordered = append(ordered, pp)
+		}
+	}
+	for _, pp := range peers {
+		if pp.peer != selected {
+			ordered = append(ordered, pp)
+		}
+	}
+	return ordered
+}
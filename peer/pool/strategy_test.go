@@ -0,0 +1,40 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+	"github.com/s7techlab/hlf-sdk-go/peer/pool"
+)
+
+type endorsingPeer struct {
+	uriPeer
+}
+
+func (p *endorsingPeer) Endorse(context.Context, *fabricPeer.SignedProposal, ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	return &fabricPeer.ProposalResponse{Response: &fabricPeer.Response{Message: p.uri}}, nil
+}
+
+func TestStrategyRoundRobin(t *testing.T) {
+	peerPool := pool.New(context.Background(), logger.DefaultLogger, config.PoolConfig{},
+		pool.WithStrategy(pool.StrategyRoundRobin()))
+	defer peerPool.Close()
+
+	for _, uri := range []string{`peer0:7051`, `peer1:7051`} {
+		require.NoError(t, peerPool.Add(`org1msp`, &endorsingPeer{uriPeer{uri: uri}}, noCheck))
+	}
+
+	var endorsers []string
+	for i := 0; i < 3; i++ {
+		resp, err := peerPool.Process(context.Background(), `org1msp`, &fabricPeer.SignedProposal{})
+		require.NoError(t, err)
+		endorsers = append(endorsers, resp.Response.Message)
+	}
+	require.Equal(t, []string{`peer0:7051`, `peer1:7051`, `peer0:7051`}, endorsers)
+}
@@ -0,0 +1,17 @@
+package peer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+// Probe waits until GRPC connection of peer becomes ready or context is done
+func Probe(ctx context.Context, peer api.Peer) error {
+	if err := util.WaitForReady(ctx, peer.Conn()); err != nil {
+		return fmt.Errorf(`peer %s: %w`, peer.Uri(), err)
+	}
+	return nil
+}
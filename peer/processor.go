@@ -13,7 +13,20 @@ import (
 )
 
 type processor struct {
-	channelName string
+	channelName   string
+	txIDGenerator api.TxIDGenerator
+}
+
+// ProcessorOpt is option of peer processor
+type ProcessorOpt func(p *processor)
+
+// WithTxIDGenerator sets generator of transaction ids, standard Fabric scheme is used if generator is nil
+func WithTxIDGenerator(generator api.TxIDGenerator) ProcessorOpt {
+	return func(p *processor) {
+		if generator != nil {
+			p.txIDGenerator = generator
+		}
+	}
 }
 
 type endorseChannelResponse struct {
@@ -22,6 +35,25 @@ type endorseChannelResponse struct {
 }
 
 func (p *processor) CreateProposal(cc *api.DiscoveryChaincode, identity msp.SigningIdentity, fn string, args [][]byte, transArgs api.TransArgs) (*fabricPeer.SignedProposal, api.ChaincodeTx, error) {
+	creator, err := identity.Serialize()
+	if err != nil {
+		return nil, ``, errors.Wrap(err, `failed to serialize identity`)
+	}
+
+	proposal, txId, err := p.CreateUnsignedProposal(cc, creator, fn, args, transArgs)
+	if err != nil {
+		return nil, ``, err
+	}
+
+	signedBytes, err := identity.Sign(proposal)
+	if err != nil {
+		return nil, ``, errors.Wrap(err, `failed to sign proposal bytes`)
+	}
+
+	return &fabricPeer.SignedProposal{ProposalBytes: proposal, Signature: signedBytes}, txId, nil
+}
+
+func (p *processor) CreateUnsignedProposal(cc *api.DiscoveryChaincode, creator []byte, fn string, args [][]byte, transArgs api.TransArgs) ([]byte, api.ChaincodeTx, error) {
 	invSpec, err := p.invocationSpec(cc, fn, args)
 	if err != nil {
 		return nil, ``, errors.Wrap(err, `failed to get invocation spec`)
@@ -29,7 +61,7 @@ func (p *processor) CreateProposal(cc *api.DiscoveryChaincode, identity msp.Sign
 
 	extension := &fabricPeer.ChaincodeHeaderExtension{ChaincodeId: &fabricPeer.ChaincodeID{Name: cc.Name}}
 
-	txId, nonce, err := util.NewTxWithNonce(identity)
+	txId, nonce, err := util.NewTxWithNonceForCreator(creator, p.txIDGenerator)
 	if err != nil {
 		return nil, ``, errors.Wrap(err, `failed to get tx id`)
 	}
@@ -44,9 +76,9 @@ func (p *processor) CreateProposal(cc *api.DiscoveryChaincode, identity msp.Sign
 		return nil, ``, errors.Wrap(err, `failed to marshal proposal payload`)
 	}
 
-	sigHeader, err := util.NewSignatureHeader(identity, nonce)
+	sigHeader, err := proto.Marshal(&common.SignatureHeader{Creator: creator, Nonce: nonce})
 	if err != nil {
-		return nil, ``, errors.Wrap(err, `failed to get signatire header`)
+		return nil, ``, errors.Wrap(err, `failed to marshal signature header`)
 	}
 
 	header, err := proto.Marshal(&common.Header{
@@ -65,24 +97,21 @@ func (p *processor) CreateProposal(cc *api.DiscoveryChaincode, identity msp.Sign
 		return nil, ``, errors.Wrap(err, `failed to marshal proposal`)
 	}
 
-	signedBytes, err := identity.Sign(proposal)
-	if err != nil {
-		return nil, ``, errors.Wrap(err, `failed to sign proposal bytes`)
-	}
-
-	return &fabricPeer.SignedProposal{ProposalBytes: proposal, Signature: signedBytes}, api.ChaincodeTx(txId), nil
+	return proposal, api.ChaincodeTx(txId), nil
 }
 
 func (*processor) Send(ctx context.Context, proposal *fabricPeer.SignedProposal, cc *api.DiscoveryChaincode, pool api.PeerPool) ([]*fabricPeer.ProposalResponse, error) {
 
 	respList := make([]*fabricPeer.ProposalResponse, 0)
-	respChan := make(chan endorseChannelResponse)
 
 	mspIds, err := util.GetMSPFromPolicy(cc.Policy)
 	if err != nil {
 		return nil, errors.Wrap(err, `failed to get set of MSP`)
 	}
 
+	// buffered, so endorsing goroutines aren't blocked if responses aren't collected due to cancelled context
+	respChan := make(chan endorseChannelResponse, len(mspIds))
+
 	// send all proposals concurrently
 	for i := 0; i < len(mspIds); i++ {
 		go func(mspId string) {
@@ -97,7 +126,13 @@ func (*processor) Send(ctx context.Context, proposal *fabricPeer.SignedProposal,
 
 	// collecting peer responses
 	for i := 0; i < len(mspIds); i++ {
-		resp := <-respChan
+		var resp endorseChannelResponse
+		select {
+		case resp = <-respChan:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
 		if resp.Error != nil {
 			errOccurred = true
 			mErr.Add(resp.Error)
@@ -136,6 +171,10 @@ func (p *processor) prepareArgs(fn string, args [][]byte) [][]byte {
 	return byteArgs
 }
 
-func NewProcessor(channelName string) api.PeerProcessor {
-	return &processor{channelName: channelName}
+func NewProcessor(channelName string, opts ...ProcessorOpt) api.PeerProcessor {
+	p := &processor{channelName: channelName, txIDGenerator: util.StandardTxID{}}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
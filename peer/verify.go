@@ -0,0 +1,29 @@
+package peer
+
+import (
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/peer/deliver"
+)
+
+type verifyingPeer struct {
+	api.Peer
+	verifiers deliver.BlockVerifierProvider
+}
+
+// WithBlockVerification wraps peer, so its deliver subscriptions verify data hash and orderer signatures
+// of delivered blocks using verifier of subscription channel
+func WithBlockVerification(peer api.Peer, verifiers deliver.BlockVerifierProvider) api.Peer {
+	return &verifyingPeer{Peer: peer, verifiers: verifiers}
+}
+
+func (p *verifyingPeer) DeliverClient(identity msp.SigningIdentity) (api.DeliverClient, error) {
+	// keep deliver options and shared streams of peer created from config
+	if base, ok := p.Peer.(*peer); ok {
+		return base.deliverClient(identity, p.verifiers)
+	}
+
+	return deliver.New(fabricPeer.NewDeliverClient(p.Conn()), identity, deliver.WithBlockVerifier(p.verifiers)), nil
+}
@@ -0,0 +1,89 @@
+package peer_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/crypto"
+	"github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+	"github.com/s7techlab/hlf-sdk-go/identity"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+	"github.com/s7techlab/hlf-sdk-go/peer"
+	"github.com/s7techlab/hlf-sdk-go/recorder"
+	"github.com/s7techlab/hlf-sdk-go/util"
+)
+
+// blockDeliverer sends single block to each deliver stream
+type blockDeliverer struct {
+	fabricPeer.UnimplementedDeliverServer
+}
+
+func (d *blockDeliverer) Deliver(stream fabricPeer.Deliver_DeliverServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	if err := stream.Send(&fabricPeer.DeliverResponse{Type: &fabricPeer.DeliverResponse_Block{
+		Block: &common.Block{Header: &common.BlockHeader{Number: 1}},
+	}}); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func TestBufferedSubscriptionOfDecoratedPeer(t *testing.T) {
+	lis, err := net.Listen(`tcp`, `127.0.0.1:0`)
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	fabricPeer.RegisterDeliverServer(srv, &blockDeliverer{})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	p, err := peer.New(config.ConnectionConfig{
+		Host:        lis.Addr().String(),
+		BlockBuffer: config.BlockBufferConfig{Size: 2, Policy: string(api.OverflowDropOldest)},
+	}, logger.DefaultLogger)
+	require.NoError(t, err)
+	defer p.Close()
+
+	noVerifier := func(context.Context, string, *orderer.SeekPosition) (*util.BlockVerifier, error) {
+		return nil, nil
+	}
+	decorated := recorder.NewRecorder(ioutil.Discard).Peer(peer.WithBlockVerification(p, noVerifier))
+
+	id, err := identity.NewMSPIdentityFromPath(`org1msp`, `../client/chaincode/testdata/msp`)
+	require.NoError(t, err)
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	require.NoError(t, err)
+
+	dc, err := decorated.DeliverClient(id.GetSigningIdentity(cs))
+	require.NoError(t, err)
+
+	sub, err := dc.SubscribeBlock(context.Background(), `channel`)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	buffered, ok := sub.(api.BufferedBlockSubscription)
+	require.True(t, ok)
+	require.Equal(t, api.OverflowDropOldest, buffered.OverflowPolicy())
+	require.Equal(t, uint64(1), (<-sub.Blocks()).Header.Number)
+}
+
+func TestNewWithUnknownBufferPolicy(t *testing.T) {
+	_, err := peer.New(config.ConnectionConfig{
+		Host:        `127.0.0.1:7051`,
+		BlockBuffer: config.BlockBufferConfig{Policy: `drop_newest`},
+	}, logger.DefaultLogger)
+	require.Error(t, err)
+}
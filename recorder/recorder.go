@@ -0,0 +1,190 @@
+// Package recorder allows to capture peer and orderer interactions to file and to replay them without network
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+const (
+	KindEndorse   = `endorse`
+	KindBroadcast = `broadcast`
+	KindDeliver   = `deliver`
+	KindTx        = `tx`
+)
+
+// Record is single captured interaction, request and response are marshaled protobuf messages
+type Record struct {
+	Kind     string `json:"kind"`
+	Target   string `json:"target"`
+	Request  []byte `json:"request,omitempty"`
+	Response []byte `json:"response,omitempty"`
+	Error    *Error `json:"error,omitempty"`
+}
+
+// Error is captured error, EndorseStatus is set if error was api.PeerEndorseError
+type Error struct {
+	Message        string `json:"message"`
+	EndorseStatus  int32  `json:"endorse_status,omitempty"`
+	EndorsePayload []byte `json:"endorse_payload,omitempty"`
+}
+
+func newError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var endorseErr api.PeerEndorseError
+	if errors.As(err, &endorseErr) {
+		return &Error{Message: endorseErr.Message, EndorseStatus: endorseErr.Status, EndorsePayload: endorseErr.Payload}
+	}
+	return &Error{Message: err.Error()}
+}
+
+func (e *Error) err() error {
+	if e == nil {
+		return nil
+	}
+	if e.EndorseStatus != 0 {
+		return api.PeerEndorseError{Status: e.EndorseStatus, Message: e.Message, Payload: e.EndorsePayload}
+	}
+	return errors.New(e.Message)
+}
+
+// Recorder writes peer endorsements, orderer broadcasts and delivers and tx validation results
+// to writer as JSON lines
+type Recorder struct {
+	w      io.Writer
+	enc    *json.Encoder
+	closed bool
+	mx     sync.Mutex
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, enc: json.NewEncoder(w)}
+}
+
+// Close stops recording and flushes writer if it is buffered, e.g. bufio.Writer.
+// Writer is owned by caller, so it isn't closed
+func (r *Recorder) Close() error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	if flusher, ok := r.w.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+func (r *Recorder) write(kind, target string, req, resp proto.Message, err error) {
+	record := Record{Kind: kind, Target: target, Error: newError(err)}
+	if req != nil {
+		record.Request, _ = proto.Marshal(req)
+	}
+	if resp != nil {
+		record.Response, _ = proto.Marshal(resp)
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if r.closed {
+		return
+	}
+	// recording must not affect network interactions, so encoding errors are ignored
+	_ = r.enc.Encode(record)
+}
+
+// Peer wraps peer, so its endorsements and tx subscriptions results are recorded
+func (r *Recorder) Peer(peer api.Peer) api.Peer {
+	return &recordPeer{Peer: peer, recorder: r}
+}
+
+// Orderer wraps orderer, so its broadcasts and delivers are recorded
+func (r *Recorder) Orderer(orderer api.Orderer) api.Orderer {
+	return &recordOrderer{Orderer: orderer, recorder: r}
+}
+
+type recordPeer struct {
+	api.Peer
+	recorder *Recorder
+}
+
+func (p *recordPeer) Endorse(ctx context.Context, proposal *fabricPeer.SignedProposal, opts ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	resp, err := p.Peer.Endorse(ctx, proposal, opts...)
+	p.recorder.write(KindEndorse, p.Uri(), proposal, resp, err)
+	return resp, err
+}
+
+func (p *recordPeer) DeliverClient(identity msp.SigningIdentity) (api.DeliverClient, error) {
+	deliver, err := p.Peer.DeliverClient(identity)
+	if err != nil {
+		return nil, err
+	}
+	return &recordDeliver{DeliverClient: deliver, recorder: p.recorder, target: p.Uri()}, nil
+}
+
+type recordDeliver struct {
+	api.DeliverClient
+	recorder *Recorder
+	target   string
+}
+
+func (d *recordDeliver) SubscribeTx(ctx context.Context, channelName string, tx api.ChaincodeTx, seekOpt ...api.EventCCSeekOption) (api.TxSubscription, error) {
+	sub, err := d.DeliverClient.SubscribeTx(ctx, channelName, tx, seekOpt...)
+	if err != nil {
+		return nil, err
+	}
+	return &recordTxSubscription{TxSubscription: sub, recorder: d.recorder, target: d.target}, nil
+}
+
+type recordTxSubscription struct {
+	api.TxSubscription
+	recorder *Recorder
+	target   string
+}
+
+// BlockNumber returns number of block with transaction if recorded subscription reports it
+func (s *recordTxSubscription) BlockNumber() (uint64, bool) {
+	if blockSub, ok := s.TxSubscription.(api.TxBlockSubscription); ok {
+		return blockSub.BlockNumber()
+	}
+	return 0, false
+}
+
+func (s *recordTxSubscription) Result() (fabricPeer.TxValidationCode, error) {
+	code, err := s.TxSubscription.Result()
+	s.recorder.write(KindTx, s.target, nil, &fabricPeer.ProcessedTransaction{ValidationCode: int32(code)}, err)
+	return code, err
+}
+
+type recordOrderer struct {
+	api.Orderer
+	recorder *Recorder
+}
+
+func (o *recordOrderer) Broadcast(ctx context.Context, envelope *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	resp, err := o.Orderer.Broadcast(ctx, envelope)
+	o.recorder.write(KindBroadcast, ``, envelope, resp, err)
+	return resp, err
+}
+
+func (o *recordOrderer) Deliver(ctx context.Context, envelope *common.Envelope) (*common.Block, error) {
+	block, err := o.Orderer.Deliver(ctx, envelope)
+	o.recorder.write(KindDeliver, ``, envelope, block, err)
+	return block, err
+}
@@ -0,0 +1,58 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+type testPeer struct {
+	api.Peer
+	responses []*fabricPeer.ProposalResponse
+	errs      []error
+}
+
+func (p *testPeer) Endorse(context.Context, *fabricPeer.SignedProposal, ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	resp, err := p.responses[0], p.errs[0]
+	p.responses, p.errs = p.responses[1:], p.errs[1:]
+	return resp, err
+}
+
+func (p *testPeer) Uri() string {
+	return `peer0:7051`
+}
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	buf := bytes.NewBuffer(nil)
+
+	recorded := NewRecorder(buf).Peer(&testPeer{
+		responses: []*fabricPeer.ProposalResponse{{Response: &fabricPeer.Response{Status: 200, Payload: []byte(`ok`)}}, nil},
+		errs:      []error{nil, api.PeerEndorseError{Status: 500, Message: `failed`}},
+	})
+
+	_, err := recorded.Endorse(ctx, &fabricPeer.SignedProposal{})
+	require.NoError(t, err)
+	_, err = recorded.Endorse(ctx, &fabricPeer.SignedProposal{})
+	require.Error(t, err)
+
+	replayer, err := NewReplayer(buf)
+	require.NoError(t, err)
+	replayed := replayer.Peer(&testPeer{})
+
+	resp, err := replayed.Endorse(ctx, &fabricPeer.SignedProposal{})
+	require.NoError(t, err)
+	require.Equal(t, []byte(`ok`), resp.Response.Payload)
+
+	_, err = replayed.Endorse(ctx, &fabricPeer.SignedProposal{})
+	require.True(t, errors.As(err, &api.PeerEndorseError{}))
+
+	_, err = replayed.Endorse(ctx, &fabricPeer.SignedProposal{})
+	require.True(t, errors.Is(err, ErrNoRecord))
+}
@@ -0,0 +1,154 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+const (
+	ErrNoRecord = api.Error(`no recorded interaction`)
+)
+
+type recordKey struct {
+	kind   string
+	target string
+}
+
+// Replayer serves recorded responses instead of network interactions.
+// Records of same kind and target are served in order of recording, requests are not compared with recorded ones
+type Replayer struct {
+	records map[recordKey][]Record
+	mx      sync.Mutex
+}
+
+// NewReplayer reads records written by Recorder
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	replayer := &Replayer{records: make(map[recordKey][]Record)}
+
+	dec := json.NewDecoder(r)
+	for {
+		var record Record
+		if err := dec.Decode(&record); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf(`decode record: %w`, err)
+		}
+
+		key := recordKey{kind: record.Kind, target: record.Target}
+		replayer.records[key] = append(replayer.records[key], record)
+	}
+
+	return replayer, nil
+}
+
+// next returns next record of presented kind and target and unmarshals its response
+func (r *Replayer) next(kind, target string, resp proto.Message) error {
+	r.mx.Lock()
+	key := recordKey{kind: kind, target: target}
+	records := r.records[key]
+	if len(records) == 0 {
+		r.mx.Unlock()
+		return fmt.Errorf(`%s %s: %w`, kind, target, ErrNoRecord)
+	}
+	record := records[0]
+	r.records[key] = records[1:]
+	r.mx.Unlock()
+
+	if record.Error != nil {
+		return record.Error.err()
+	}
+
+	if err := proto.Unmarshal(record.Response, resp); err != nil {
+		return fmt.Errorf(`unmarshal recorded response: %w`, err)
+	}
+	return nil
+}
+
+// Peer wraps peer, so endorsements and tx subscriptions results are served from records
+func (r *Replayer) Peer(peer api.Peer) api.Peer {
+	return &replayPeer{Peer: peer, replayer: r}
+}
+
+// Orderer wraps orderer, so broadcasts and delivers are served from records
+func (r *Replayer) Orderer(orderer api.Orderer) api.Orderer {
+	return &replayOrderer{Orderer: orderer, replayer: r}
+}
+
+type replayPeer struct {
+	api.Peer
+	replayer *Replayer
+}
+
+func (p *replayPeer) Endorse(_ context.Context, _ *fabricPeer.SignedProposal, _ ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	resp := new(fabricPeer.ProposalResponse)
+	if err := p.replayer.next(KindEndorse, p.Uri(), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (p *replayPeer) DeliverClient(identity msp.SigningIdentity) (api.DeliverClient, error) {
+	deliver, err := p.Peer.DeliverClient(identity)
+	if err != nil {
+		return nil, err
+	}
+	return &replayDeliver{DeliverClient: deliver, replayer: p.replayer, target: p.Uri()}, nil
+}
+
+// replayDeliver serves only tx subscriptions, chaincode and block subscriptions are passed to peer
+type replayDeliver struct {
+	api.DeliverClient
+	replayer *Replayer
+	target   string
+}
+
+func (d *replayDeliver) SubscribeTx(_ context.Context, _ string, _ api.ChaincodeTx, _ ...api.EventCCSeekOption) (api.TxSubscription, error) {
+	processed := new(fabricPeer.ProcessedTransaction)
+	err := d.replayer.next(KindTx, d.target, processed)
+	return &replayTxSubscription{code: fabricPeer.TxValidationCode(processed.ValidationCode), err: err}, nil
+}
+
+type replayTxSubscription struct {
+	code fabricPeer.TxValidationCode
+	err  error
+}
+
+func (s *replayTxSubscription) Result() (fabricPeer.TxValidationCode, error) {
+	return s.code, s.err
+}
+
+func (s *replayTxSubscription) Close() error {
+	return nil
+}
+
+type replayOrderer struct {
+	api.Orderer
+	replayer *Replayer
+}
+
+func (o *replayOrderer) Broadcast(_ context.Context, _ *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	resp := new(fabricOrderer.BroadcastResponse)
+	if err := o.replayer.next(KindBroadcast, ``, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (o *replayOrderer) Deliver(_ context.Context, _ *common.Envelope) (*common.Block, error) {
+	block := new(common.Block)
+	if err := o.replayer.next(KindDeliver, ``, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
@@ -0,0 +1,80 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	fabricOrderer "github.com/hyperledger/fabric-protos-go/orderer"
+	fabricPeer "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"google.golang.org/grpc"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// StubPeer returns peer of address which is never connected. It's wrapped by Replayer in place of network peer,
+// interactions which aren't served from records fail with ErrNoRecord
+func StubPeer(address string) api.Peer {
+	return &stubPeer{address: address}
+}
+
+// StubOrderer returns orderer which is never connected. It's wrapped by Replayer in place of network orderer
+func StubOrderer() api.Orderer {
+	return &stubOrderer{}
+}
+
+type stubPeer struct {
+	address string
+}
+
+func (p *stubPeer) Endorse(context.Context, *fabricPeer.SignedProposal, ...api.PeerEndorseOpt) (*fabricPeer.ProposalResponse, error) {
+	return nil, fmt.Errorf(`%s %s: %w`, KindEndorse, p.address, ErrNoRecord)
+}
+
+func (p *stubPeer) DeliverClient(msp.SigningIdentity) (api.DeliverClient, error) {
+	return &stubDeliver{address: p.address}, nil
+}
+
+func (p *stubPeer) Uri() string {
+	return p.address
+}
+
+// Conn returns nil, as stub peer has no connection
+func (p *stubPeer) Conn() *grpc.ClientConn {
+	return nil
+}
+
+func (p *stubPeer) Close() error {
+	return nil
+}
+
+type stubDeliver struct {
+	address string
+}
+
+func (d *stubDeliver) SubscribeCC(context.Context, string, string, ...api.EventCCSeekOption) (api.EventCCSubscription, error) {
+	return nil, fmt.Errorf(`chaincode events %s: %w`, d.address, ErrNoRecord)
+}
+
+func (d *stubDeliver) SubscribeTx(context.Context, string, api.ChaincodeTx, ...api.EventCCSeekOption) (api.TxSubscription, error) {
+	return nil, fmt.Errorf(`%s %s: %w`, KindTx, d.address, ErrNoRecord)
+}
+
+func (d *stubDeliver) SubscribeBlock(context.Context, string, ...api.EventCCSeekOption) (api.BlockSubscription, error) {
+	return nil, fmt.Errorf(`blocks %s: %w`, d.address, ErrNoRecord)
+}
+
+type stubOrderer struct{}
+
+func (o *stubOrderer) Broadcast(context.Context, *common.Envelope) (*fabricOrderer.BroadcastResponse, error) {
+	return nil, fmt.Errorf(`%s: %w`, KindBroadcast, ErrNoRecord)
+}
+
+func (o *stubOrderer) Deliver(context.Context, *common.Envelope) (*common.Block, error) {
+	return nil, fmt.Errorf(`%s: %w`, KindDeliver, ErrNoRecord)
+}
+
+func (o *stubOrderer) Close() error {
+	return nil
+}
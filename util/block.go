@@ -0,0 +1,200 @@
+package util
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util/txflags"
+)
+
+// maxBlocksPrealloc limits preallocation of result of GetBlocksTimestamps, so huge ranges don't allocate memory in advance
+const maxBlocksPrealloc = 1024
+
+// BlockTimestamps contains timestamps of transactions from block
+type BlockTimestamps struct {
+	Number uint64
+	// Timestamp is the latest transaction timestamp in block, zero if block has no timestamped transactions
+	Timestamp    time.Time
+	TxTimestamps []time.Time
+}
+
+// GetBlocksTimestamps fetches blocks from start to end (inclusive) and returns transaction timestamps of each block,
+// config blocks are included with timestamp of config transaction
+func GetBlocksTimestamps(ctx context.Context, deliver api.DeliverClient, channelName string, start, end uint64) ([]BlockTimestamps, error) {
+	if start > end {
+		return nil, errors.Errorf("invalid block range: %d > %d", start, end)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sub, err := deliver.SubscribeBlock(ctx, channelName, api.SeekRange(start, end))
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to subscribe on blocks`)
+	}
+	defer sub.Close()
+
+	// end-start+1 overflows for whole range of uint64
+	prealloc := end - start
+	if prealloc >= maxBlocksPrealloc {
+		prealloc = maxBlocksPrealloc - 1
+	}
+	timestamps := make([]BlockTimestamps, 0, prealloc+1)
+
+	for {
+		select {
+		case block, ok := <-sub.Blocks():
+			if !ok {
+				return timestamps, errors.New(`block stream closed`)
+			}
+			timestamps = append(timestamps, GetBlockTimestamps(block))
+			if block.GetHeader().GetNumber() >= end {
+				return timestamps, nil
+			}
+		case err, ok := <-sub.Errors():
+			if ok {
+				return timestamps, errors.Wrap(err, `failed to get block`)
+			}
+			return timestamps, errors.New(`block stream closed`)
+		case <-ctx.Done():
+			return timestamps, ctx.Err()
+		}
+	}
+}
+
+// GetBlockTimestamps returns timestamps from channel headers of block transactions,
+// envelopes which can't be decoded or have no timestamp are skipped
+func GetBlockTimestamps(block *common.Block) BlockTimestamps {
+	bt := BlockTimestamps{
+		Number: block.GetHeader().GetNumber(),
+	}
+
+	for _, envBytes := range block.GetData().GetData() {
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			continue
+		}
+
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil || payload.Header == nil {
+			continue
+		}
+
+		chHeader, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil || chHeader.Timestamp == nil {
+			continue
+		}
+
+		ts, err := ptypes.Timestamp(chHeader.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		bt.TxTimestamps = append(bt.TxTimestamps, ts)
+		if ts.After(bt.Timestamp) {
+			bt.Timestamp = ts
+		}
+	}
+
+	return bt
+}
+
+// BlockMetadata contains decoded block metadata
+type BlockMetadata struct {
+	// Signatures of orderers
+	Signatures []BlockSignature
+	// LastConfig is number of last config block
+	LastConfig uint64
+	// TxFilter contains validation codes of block transactions
+	TxFilter txflags.ValidationFlags
+}
+
+// BlockSignature describes orderer signature of block
+type BlockSignature struct {
+	Creator   *msp.SerializedIdentity
+	Nonce     []byte
+	Signature []byte
+}
+
+// GetBlockMetadata decodes block metadata: orderer signatures, last config block number and transaction validation flags
+func GetBlockMetadata(block *common.Block) (*BlockMetadata, error) {
+	sigMetadata, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get signatures metadata`)
+	}
+
+	md := &BlockMetadata{
+		Signatures: make([]BlockSignature, 0, len(sigMetadata.Signatures)),
+	}
+
+	for _, metadataSig := range sigMetadata.Signatures {
+		sigHeader, err := protoutil.UnmarshalSignatureHeader(metadataSig.SignatureHeader)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to unmarshal signature header`)
+		}
+
+		creator := new(msp.SerializedIdentity)
+		if err = proto.Unmarshal(sigHeader.Creator, creator); err != nil {
+			return nil, errors.Wrap(err, `failed to unmarshal signature creator`)
+		}
+
+		md.Signatures = append(md.Signatures, BlockSignature{
+			Creator:   creator,
+			Nonce:     sigHeader.Nonce,
+			Signature: metadataSig.Signature,
+		})
+	}
+
+	if md.LastConfig, err = protoutil.GetLastConfigIndexFromBlock(block); err != nil {
+		return nil, errors.Wrap(err, `failed to get last config index`)
+	}
+
+	if len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		md.TxFilter = txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	return md, nil
+}
+
+// GetTxValidationCode returns validation code of transaction from block transactions filter
+func GetTxValidationCode(block *common.Block, txID string) (peer.TxValidationCode, error) {
+	var flags txflags.ValidationFlags
+	if len(block.GetMetadata().GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+
+	for i, envBytes := range block.GetData().GetData() {
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return 0, errors.Wrap(err, `failed to get envelope`)
+		}
+
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return 0, errors.Wrap(err, `failed to get payload`)
+		}
+
+		chHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+		if err != nil {
+			return 0, errors.Wrap(err, `failed to unmarshal channel header`)
+		}
+
+		if chHeader.TxId != txID {
+			continue
+		}
+		if i >= len(flags) {
+			return 0, errors.Errorf(`block %d has no validation flag of tx %s`, block.GetHeader().GetNumber(), txID)
+		}
+		return flags.Flag(i), nil
+	}
+	return 0, errors.Errorf(`tx %s not found in block %d`, txID, block.GetHeader().GetNumber())
+}
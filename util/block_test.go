@@ -0,0 +1,161 @@
+package util
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// blocksDeliver delivers blocks of requested range which it has and closes stream
+type blocksDeliver struct {
+	api.DeliverClient
+	blocks []*common.Block
+}
+
+type blocksSubscription struct {
+	blocks chan *common.Block
+	errors chan error
+}
+
+func (s *blocksSubscription) Blocks() <-chan *common.Block { return s.blocks }
+
+func (s *blocksSubscription) Errors() chan error { return s.errors }
+
+func (s *blocksSubscription) Close() error { return nil }
+
+func (d *blocksDeliver) SubscribeBlock(_ context.Context, _ string, seekOpt ...api.EventCCSeekOption) (api.BlockSubscription, error) {
+	start, stop := seekOpt[0]()
+	sub := &blocksSubscription{blocks: make(chan *common.Block, len(d.blocks)), errors: make(chan error)}
+	for _, block := range d.blocks {
+		number := block.Header.Number
+		if number >= start.GetSpecified().GetNumber() && number <= stop.GetSpecified().GetNumber() {
+			sub.blocks <- block
+		}
+	}
+	close(sub.blocks)
+	return sub, nil
+}
+
+func timestampedBlock(t *testing.T, number uint64, txType common.HeaderType, ts time.Time) *common.Block {
+	timestamp, err := ptypes.TimestampProto(ts)
+	require.NoError(t, err)
+
+	chHeader, err := proto.Marshal(&common.ChannelHeader{Type: int32(txType), Timestamp: timestamp})
+	require.NoError(t, err)
+
+	payload, err := proto.Marshal(&common.Payload{Header: &common.Header{ChannelHeader: chHeader}})
+	require.NoError(t, err)
+
+	env, err := proto.Marshal(&common.Envelope{Payload: payload})
+	require.NoError(t, err)
+
+	return &common.Block{
+		Header: &common.BlockHeader{Number: number},
+		Data:   &common.BlockData{Data: [][]byte{env}},
+	}
+}
+
+func TestGetBlocksTimestamps(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	deliver := &blocksDeliver{blocks: []*common.Block{
+		timestampedBlock(t, 0, common.HeaderType_CONFIG, now),
+		timestampedBlock(t, 1, common.HeaderType_ENDORSER_TRANSACTION, now.Add(time.Second)),
+		timestampedBlock(t, 2, common.HeaderType_ENDORSER_TRANSACTION, now.Add(2*time.Second)),
+	}}
+
+	t.Run(`range with config block`, func(t *testing.T) {
+		timestamps, err := GetBlocksTimestamps(context.Background(), deliver, `channel`, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, timestamps, 2)
+		assert.Equal(t, uint64(0), timestamps[0].Number)
+		assert.Equal(t, now, timestamps[0].Timestamp)
+		assert.Equal(t, uint64(1), timestamps[1].Number)
+		assert.Equal(t, []time.Time{now.Add(time.Second)}, timestamps[1].TxTimestamps)
+	})
+
+	t.Run(`whole range`, func(t *testing.T) {
+		// range size overflows uint64, blocks are received until stream is closed
+		timestamps, err := GetBlocksTimestamps(context.Background(), deliver, `channel`, 0, math.MaxUint64)
+		require.Error(t, err)
+		require.Len(t, timestamps, 3)
+		assert.Equal(t, uint64(2), timestamps[2].Number)
+	})
+
+	t.Run(`invalid range`, func(t *testing.T) {
+		_, err := GetBlocksTimestamps(context.Background(), deliver, `channel`, 2, 1)
+		require.Error(t, err)
+	})
+}
+
+func TestGetBlockMetadata(t *testing.T) {
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: `OrdererMSP`, IdBytes: []byte(`cert`)})
+	require.NoError(t, err)
+
+	sigHeader, err := proto.Marshal(&common.SignatureHeader{Creator: creator, Nonce: []byte(`nonce`)})
+	require.NoError(t, err)
+
+	obm, err := proto.Marshal(&common.OrdererBlockMetadata{LastConfig: &common.LastConfig{Index: 7}})
+	require.NoError(t, err)
+
+	sigMetadata, err := proto.Marshal(&common.Metadata{
+		Value:      obm,
+		Signatures: []*common.MetadataSignature{{SignatureHeader: sigHeader, Signature: []byte(`sig`)}},
+	})
+	require.NoError(t, err)
+
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 10},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{
+			common.BlockMetadataIndex_SIGNATURES:          sigMetadata,
+			common.BlockMetadataIndex_LAST_CONFIG:         {},
+			common.BlockMetadataIndex_TRANSACTIONS_FILTER: {uint8(peer.TxValidationCode_VALID), uint8(peer.TxValidationCode_MVCC_READ_CONFLICT)},
+		}},
+	}
+
+	md, err := GetBlockMetadata(block)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(7), md.LastConfig)
+	require.Len(t, md.Signatures, 1)
+	assert.Equal(t, `OrdererMSP`, md.Signatures[0].Creator.Mspid)
+	assert.Equal(t, []byte(`nonce`), md.Signatures[0].Nonce)
+	assert.Equal(t, []byte(`sig`), md.Signatures[0].Signature)
+	require.Len(t, md.TxFilter, 2)
+	assert.True(t, md.TxFilter.IsValid(0))
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, md.TxFilter.Flag(1))
+}
+
+func TestGetTxValidationCode(t *testing.T) {
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 7},
+		Data: &common.BlockData{Data: [][]byte{
+			lifecycleCommitTx(t, `tx1`, &lb.CommitChaincodeDefinitionArgs{}),
+			lifecycleCommitTx(t, `tx2`, &lb.CommitChaincodeDefinitionArgs{}),
+		}},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{
+			common.BlockMetadataIndex_SIGNATURES:  {},
+			common.BlockMetadataIndex_LAST_CONFIG: {},
+			common.BlockMetadataIndex_TRANSACTIONS_FILTER: {
+				uint8(peer.TxValidationCode_VALID), uint8(peer.TxValidationCode_MVCC_READ_CONFLICT)},
+		}},
+	}
+
+	code, err := GetTxValidationCode(block, `tx2`)
+	require.NoError(t, err)
+	require.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, code)
+
+	_, err = GetTxValidationCode(block, `tx3`)
+	require.Error(t, err)
+}
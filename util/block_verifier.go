@@ -0,0 +1,124 @@
+package util
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// BlockVerifier checks data hash of delivered blocks and orderer signatures of block metadata
+// against block validation policy of channel. Policy is updated from config blocks passed verification,
+// so verifier must be created with config block in effect at height which delivery starts from
+type BlockVerifier struct {
+	channelName string
+	policy      policies.Policy
+	// trustedNumber and trustedHash identify config block verifier is created with, e.g. genesis block
+	// which has no orderer signatures, so this block is accepted without signatures check
+	trustedNumber uint64
+	trustedHash   []byte
+	mx            sync.RWMutex
+}
+
+// NewBlockVerifier returns block verifier using block validation policy from channel config of config block
+func NewBlockVerifier(channelName string, configBlock *common.Block) (*BlockVerifier, error) {
+	conf, err := GetConfigFromBlock(configBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get channel config`)
+	}
+
+	policy, err := getBlockValidationPolicy(channelName, conf)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockVerifier{
+		channelName:   channelName,
+		policy:        policy,
+		trustedNumber: configBlock.GetHeader().GetNumber(),
+		trustedHash:   protoutil.BlockHeaderHash(configBlock.GetHeader()),
+	}, nil
+}
+
+// Verify returns api.InvalidBlockError if block data hash doesn't match header
+// or block signatures don't satisfy block validation policy
+func (v *BlockVerifier) Verify(block *common.Block) error {
+	number := block.GetHeader().GetNumber()
+
+	if !bytes.Equal(protoutil.BlockDataHash(block.GetData()), block.GetHeader().GetDataHash()) {
+		return api.InvalidBlockError{BlockNumber: number, Reason: `data hash mismatch`}
+	}
+
+	if number == v.trustedNumber && bytes.Equal(protoutil.BlockHeaderHash(block.GetHeader()), v.trustedHash) {
+		return nil
+	}
+
+	metadata, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return api.InvalidBlockError{BlockNumber: number, Reason: `failed to get signatures metadata: ` + err.Error()}
+	}
+
+	headerBytes := protoutil.BlockHeaderBytes(block.Header)
+	signedData := make([]*protoutil.SignedData, 0, len(metadata.Signatures))
+	for _, sig := range metadata.Signatures {
+		sigHeader, err := protoutil.UnmarshalSignatureHeader(sig.SignatureHeader)
+		if err != nil {
+			return api.InvalidBlockError{BlockNumber: number, Reason: `failed to unmarshal signature header: ` + err.Error()}
+		}
+
+		signedData = append(signedData, &protoutil.SignedData{
+			Data:      bytes.Join([][]byte{metadata.Value, sig.SignatureHeader, headerBytes}, nil),
+			Identity:  sigHeader.Creator,
+			Signature: sig.Signature,
+		})
+	}
+
+	v.mx.RLock()
+	policy := v.policy
+	v.mx.RUnlock()
+
+	if err = policy.EvaluateSignedData(signedData); err != nil {
+		return api.InvalidBlockError{BlockNumber: number, Reason: `block validation policy is not satisfied: ` + err.Error()}
+	}
+
+	if protoutil.IsConfigBlock(block) {
+		conf, err := GetConfigFromBlock(block)
+		if err != nil {
+			return api.InvalidBlockError{BlockNumber: number, Reason: `failed to get channel config: ` + err.Error()}
+		}
+
+		if policy, err = getBlockValidationPolicy(v.channelName, conf); err != nil {
+			return api.InvalidBlockError{BlockNumber: number, Reason: err.Error()}
+		}
+
+		v.mx.Lock()
+		v.policy = policy
+		v.mx.Unlock()
+	}
+
+	return nil
+}
+
+func getBlockValidationPolicy(channelName string, conf *common.Config) (policies.Policy, error) {
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to initialize crypto provider`)
+	}
+
+	bundle, err := channelconfig.NewBundle(channelName, conf, cryptoProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to parse channel config`)
+	}
+
+	policy, ok := bundle.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return nil, errors.New(`block validation policy is not found in channel config`)
+	}
+	return policy, nil
+}
@@ -0,0 +1,103 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// State describes circuit breaker state
+type State int
+
+const (
+	// Closed - requests are passed to endpoint
+	Closed State = iota
+	// Open - requests fail fast without reaching endpoint
+	Open
+	// HalfOpen - cooldown is over, single trial request is passed to endpoint
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return `closed`
+	case Open:
+		return `open`
+	case HalfOpen:
+		return `half-open`
+	}
+	return `unknown`
+}
+
+// Config describes circuit breaker thresholds
+type Config struct {
+	// Threshold is count of consecutive failures after which breaker opens, zero opens breaker on first failure
+	Threshold uint
+	// Cooldown is period after which open breaker allows trial request
+	Cooldown time.Duration
+}
+
+// Breaker is circuit breaker for single endpoint
+type Breaker struct {
+	config   Config
+	state    State
+	failures uint
+	openedAt time.Time
+	mx       sync.Mutex
+}
+
+// New returns circuit breaker in closed state
+func New(config Config) *Breaker {
+	return &Breaker{config: config}
+}
+
+// Allow reports whether request to endpoint could be made
+func (b *Breaker) Allow() bool {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	switch b.state {
+	case Open:
+		if time.Since(b.openedAt) < b.config.Cooldown {
+			return false
+		}
+		b.state = HalfOpen
+		return true
+	case HalfOpen:
+		// trial request is already in progress
+		return false
+	}
+	return true
+}
+
+// Success resets failures counter and closes breaker
+func (b *Breaker) Success() {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	b.failures = 0
+	b.state = Closed
+}
+
+// Failure registers failed request, breaker opens after threshold or on failed trial request
+func (b *Breaker) Failure() {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.config.Threshold {
+		b.state = Open
+		b.openedAt = time.Now()
+	}
+}
+
+// State returns current breaker state
+func (b *Breaker) State() State {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	if b.state == Open && time.Since(b.openedAt) >= b.config.Cooldown {
+		return HalfOpen
+	}
+	return b.state
+}
@@ -0,0 +1,37 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	b := New(Config{Threshold: 2, Cooldown: 50 * time.Millisecond})
+	require.Equal(t, Closed, b.State())
+
+	require.True(t, b.Allow())
+	b.Failure()
+	require.Equal(t, Closed, b.State())
+
+	require.True(t, b.Allow())
+	b.Failure()
+	require.Equal(t, Open, b.State())
+	require.False(t, b.Allow())
+
+	time.Sleep(60 * time.Millisecond)
+	require.Equal(t, HalfOpen, b.State())
+	require.True(t, b.Allow())
+	// only one trial request
+	require.False(t, b.Allow())
+
+	b.Failure()
+	require.Equal(t, Open, b.State())
+
+	time.Sleep(60 * time.Millisecond)
+	require.True(t, b.Allow())
+	b.Success()
+	require.Equal(t, Closed, b.State())
+	require.True(t, b.Allow())
+}
@@ -3,11 +3,15 @@ package util
 import (
 	"bytes"
 	"context"
+	"sort"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/protolator"
 	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 
 	"github.com/s7techlab/hlf-sdk-go/api"
@@ -32,6 +36,153 @@ func GetOrdererHostFromChannelConfig(conf *common.Config) (string, error) {
 	return ordererAddresses.Addresses[0], nil
 }
 
+// GetOrdererAddressesFromChannelConfig returns addresses of orderers from organizations endpoints (Fabric V2)
+// and global orderer addresses without duplicates
+func GetOrdererAddressesFromChannelConfig(conf *common.Config) ([]string, error) {
+	var addresses []string
+	seen := make(map[string]struct{})
+
+	appendAddresses := func(value *common.ConfigValue) error {
+		ordererAddresses := common.OrdererAddresses{}
+		if err := proto.Unmarshal(value.Value, &ordererAddresses); err != nil {
+			return errors.Wrap(err, `failed to unmarshal orderer addresses`)
+		}
+		for _, address := range ordererAddresses.Addresses {
+			if _, ok := seen[address]; !ok {
+				seen[address] = struct{}{}
+				addresses = append(addresses, address)
+			}
+		}
+		return nil
+	}
+
+	if ordGroup, ok := conf.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey]; ok {
+		for _, orgGroup := range ordGroup.Groups {
+			if endpoints, ok := orgGroup.Values[channelconfig.EndpointsKey]; ok {
+				if err := appendAddresses(endpoints); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if ordValues, ok := conf.GetChannelGroup().GetValues()[channelconfig.OrdererAddressesKey]; ok {
+		if err := appendAddresses(ordValues); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addresses) == 0 {
+		return nil, ErrOrdererGroupNotFound
+	}
+	return addresses, nil
+}
+
+// GetApplicationMSPIDsFromChannelConfig returns sorted MSP ids of channel application organizations
+func GetApplicationMSPIDsFromChannelConfig(conf *common.Config) ([]string, error) {
+	return groupMSPIDs(conf.GetChannelGroup().GetGroups()[channelconfig.ApplicationGroupKey])
+}
+
+// GetOrdererMSPIDsFromChannelConfig returns sorted MSP ids of channel orderer organizations
+func GetOrdererMSPIDsFromChannelConfig(conf *common.Config) ([]string, error) {
+	return groupMSPIDs(conf.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey])
+}
+
+// GetOrdererOrgNameFromChannelConfig returns name of channel orderer organization group with presented MSP id
+func GetOrdererOrgNameFromChannelConfig(conf *common.Config, mspID string) (string, error) {
+	return groupOrgName(conf.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey], mspID)
+}
+
+// GetApplicationOrgNameFromChannelConfig returns name of channel application organization group with presented MSP id
+func GetApplicationOrgNameFromChannelConfig(conf *common.Config, mspID string) (string, error) {
+	return groupOrgName(conf.GetChannelGroup().GetGroups()[channelconfig.ApplicationGroupKey], mspID)
+}
+
+// groupOrgName returns name of organization of config group with presented MSP id
+func groupOrgName(group *common.ConfigGroup, mspID string) (string, error) {
+	orgs, err := groupOrgMSPIDs(group)
+	if err != nil {
+		return ``, err
+	}
+	for orgName, orgMSPID := range orgs {
+		if orgMSPID == mspID {
+			return orgName, nil
+		}
+	}
+	return ``, errors.Errorf(`organization with MSP id %s not found`, mspID)
+}
+
+// groupMSPIDs returns sorted MSP ids of organizations of config group, nil group has no organizations
+func groupMSPIDs(group *common.ConfigGroup) ([]string, error) {
+	orgs, err := groupOrgMSPIDs(group)
+	if err != nil {
+		return nil, err
+	}
+
+	var mspIDs []string
+	for _, mspID := range orgs {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+	return mspIDs, nil
+}
+
+// groupOrgMSPIDs returns MSP ids of organizations of config group by organization name
+func groupOrgMSPIDs(group *common.ConfigGroup) (map[string]string, error) {
+	orgs := make(map[string]string)
+	for orgName, orgGroup := range group.GetGroups() {
+		mspValue, ok := orgGroup.Values[channelconfig.MSPKey]
+		if !ok {
+			return nil, errors.Errorf(`MSP config not found for organization %s`, orgName)
+		}
+
+		mspConfig := new(mspproto.MSPConfig)
+		if err := proto.Unmarshal(mspValue.Value, mspConfig); err != nil {
+			return nil, errors.Wrapf(err, `failed to unmarshal MSP config of organization %s`, orgName)
+		}
+
+		fabricConfig := new(mspproto.FabricMSPConfig)
+		if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+			return nil, errors.Wrapf(err, `failed to unmarshal fabric MSP config of organization %s`, orgName)
+		}
+
+		orgs[orgName] = fabricConfig.Name
+	}
+	return orgs, nil
+}
+
+// ConfigToJSON returns channel config as JSON in format of configtxlator, with nested messages decoded
+func ConfigToJSON(conf *common.Config) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := protolator.DeepMarshalJSON(buf, conf); err != nil {
+		return nil, errors.Wrap(err, `failed to marshal config to JSON`)
+	}
+	return buf.Bytes(), nil
+}
+
+// GetConfigFromBlock returns channel config from config block
+func GetConfigFromBlock(block *common.Block) (*common.Config, error) {
+	env, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to extract envelope`)
+	}
+
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal payload`)
+	}
+
+	configEnv := new(common.ConfigEnvelope)
+	if err = proto.Unmarshal(payload.Data, configEnv); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal config envelope`)
+	}
+
+	if configEnv.Config == nil {
+		return nil, errors.New(`config is empty`)
+	}
+	return configEnv.Config, nil
+}
+
 func ProceedChannelUpdate(ctx context.Context, channelName string, update *common.ConfigUpdate, orderer api.Orderer, id msp.SigningIdentity) error {
 	confUpdBytes, err := proto.Marshal(update)
 	if err != nil {
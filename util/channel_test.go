@@ -0,0 +1,53 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func orgGroup(t *testing.T, mspID string) *common.ConfigGroup {
+	fabricConfig, err := proto.Marshal(&mspproto.FabricMSPConfig{Name: mspID})
+	require.NoError(t, err)
+	mspConfig, err := proto.Marshal(&mspproto.MSPConfig{Config: fabricConfig})
+	require.NoError(t, err)
+	return &common.ConfigGroup{Values: map[string]*common.ConfigValue{channelconfig.MSPKey: {Value: mspConfig}}}
+}
+
+func TestChannelConfigHelpers(t *testing.T) {
+	addresses, err := proto.Marshal(&common.OrdererAddresses{Addresses: []string{`orderer:7050`}})
+	require.NoError(t, err)
+
+	conf := &common.Config{ChannelGroup: &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{
+			channelconfig.ApplicationGroupKey: {Groups: map[string]*common.ConfigGroup{
+				`Org2`: orgGroup(t, `Org2MSP`),
+				`Org1`: orgGroup(t, `Org1MSP`),
+			}},
+			channelconfig.OrdererGroupKey: {Groups: map[string]*common.ConfigGroup{
+				`Orderer`: orgGroup(t, `OrdererMSP`),
+			}},
+		},
+		Values: map[string]*common.ConfigValue{channelconfig.OrdererAddressesKey: {Value: addresses}},
+	}}
+
+	mspIDs, err := GetApplicationMSPIDsFromChannelConfig(conf)
+	require.NoError(t, err)
+	require.Equal(t, []string{`Org1MSP`, `Org2MSP`}, mspIDs)
+
+	mspIDs, err = GetOrdererMSPIDsFromChannelConfig(conf)
+	require.NoError(t, err)
+	require.Equal(t, []string{`OrdererMSP`}, mspIDs)
+
+	orderers, err := GetOrdererAddressesFromChannelConfig(conf)
+	require.NoError(t, err)
+	require.Equal(t, []string{`orderer:7050`}, orderers)
+
+	confJSON, err := ConfigToJSON(conf)
+	require.NoError(t, err)
+	require.Contains(t, string(confJSON), `"Org1MSP"`)
+}
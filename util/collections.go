@@ -0,0 +1,193 @@
+package util
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// CollectionDefinition is private data collection definition in format of peer CLI --collections-config file
+type CollectionDefinition struct {
+	Name   string `json:"name" yaml:"name"`
+	Policy string `json:"policy" yaml:"policy"`
+	// MemberOrgs are MSP ids of collection members, used to create policy requiring signature of any member
+	// if Policy is empty
+	MemberOrgs        []string                     `json:"memberOrgs,omitempty" yaml:"memberOrgs,omitempty"`
+	RequiredPeerCount int32                        `json:"requiredPeerCount" yaml:"requiredPeerCount"`
+	MaxPeerCount      int32                        `json:"maxPeerCount" yaml:"maxPeerCount"`
+	BlockToLive       uint64                       `json:"blockToLive" yaml:"blockToLive"`
+	MemberOnlyRead    bool                         `json:"memberOnlyRead" yaml:"memberOnlyRead"`
+	MemberOnlyWrite   bool                         `json:"memberOnlyWrite" yaml:"memberOnlyWrite"`
+	EndorsementPolicy *CollectionEndorsementPolicy `json:"endorsementPolicy,omitempty" yaml:"endorsementPolicy,omitempty"`
+}
+
+// CollectionEndorsementPolicy is collection level endorsement policy, only one of policies can be set
+type CollectionEndorsementPolicy struct {
+	SignaturePolicy     string `json:"signaturePolicy,omitempty" yaml:"signaturePolicy,omitempty"`
+	ChannelConfigPolicy string `json:"channelConfigPolicy,omitempty" yaml:"channelConfigPolicy,omitempty"`
+}
+
+// LoadCollectionsConfig reads collections definition file, see ParseCollectionsConfig
+func LoadCollectionsConfig(path string) (*peer.CollectionConfigPackage, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to read collections config`)
+	}
+	return ParseCollectionsConfig(data)
+}
+
+// ParseCollectionsConfig parses collections definition in YAML or JSON format of peer CLI --collections-config file
+// to collection config package used in chaincode definition
+func ParseCollectionsConfig(data []byte) (*peer.CollectionConfigPackage, error) {
+	var definitions []CollectionDefinition
+	// JSON is parsed as YAML, so both formats are supported
+	if err := yaml.Unmarshal(data, &definitions); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal collections config`)
+	}
+	return NewCollectionConfigPackage(definitions...)
+}
+
+// NewCollectionConfigPackage converts collection definitions to collection config package of chaincode definition
+func NewCollectionConfigPackage(definitions ...CollectionDefinition) (*peer.CollectionConfigPackage, error) {
+	pkg := &peer.CollectionConfigPackage{}
+	names := make(map[string]struct{})
+
+	for _, def := range definitions {
+		if _, ok := names[def.Name]; ok {
+			return nil, errors.Errorf(`collection %s is defined more than once`, def.Name)
+		}
+		names[def.Name] = struct{}{}
+
+		config, err := NewCollectionConfig(def)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Config = append(pkg.Config, config)
+	}
+
+	return pkg, nil
+}
+
+// NewCollectionConfig validates collection definition and converts it to collection config
+func NewCollectionConfig(def CollectionDefinition) (*peer.CollectionConfig, error) {
+	if def.Name == `` {
+		return nil, errors.New(`collection name is empty`)
+	}
+
+	if def.RequiredPeerCount < 0 {
+		return nil, errors.Errorf(`collection %s: requiredPeerCount must not be negative`, def.Name)
+	}
+
+	if def.MaxPeerCount < def.RequiredPeerCount {
+		return nil, errors.Errorf(`collection %s: maxPeerCount %d is less than requiredPeerCount %d`,
+			def.Name, def.MaxPeerCount, def.RequiredPeerCount)
+	}
+
+	policy := def.Policy
+	if policy == `` {
+		if len(def.MemberOrgs) == 0 {
+			return nil, errors.Errorf(`collection %s: neither policy nor member orgs are presented`, def.Name)
+		}
+		principals := make([]string, len(def.MemberOrgs))
+		for i, mspID := range def.MemberOrgs {
+			if mspID == `` {
+				return nil, errors.Errorf(`collection %s: member org MSP id is empty`, def.Name)
+			}
+			principals[i] = fmt.Sprintf(`'%s.member'`, mspID)
+		}
+		policy = fmt.Sprintf(`OR(%s)`, strings.Join(principals, `,`))
+	}
+
+	memberPolicy, err := policydsl.FromString(policy)
+	if err != nil {
+		return nil, errors.Wrapf(err, `collection %s: failed to parse policy`, def.Name)
+	}
+
+	endorsementPolicy, err := newCollectionEndorsementPolicy(def.EndorsementPolicy)
+	if err != nil {
+		return nil, errors.Wrapf(err, `collection %s`, def.Name)
+	}
+
+	return &peer.CollectionConfig{
+		Payload: &peer.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &peer.StaticCollectionConfig{
+				Name: def.Name,
+				MemberOrgsPolicy: &peer.CollectionPolicyConfig{
+					Payload: &peer.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: memberPolicy},
+				},
+				RequiredPeerCount: def.RequiredPeerCount,
+				MaximumPeerCount:  def.MaxPeerCount,
+				BlockToLive:       def.BlockToLive,
+				MemberOnlyRead:    def.MemberOnlyRead,
+				MemberOnlyWrite:   def.MemberOnlyWrite,
+				EndorsementPolicy: endorsementPolicy,
+			},
+		},
+	}, nil
+}
+
+// ValidateCollectionsConfig checks that collections have names and member orgs policies
+// which principals have non-empty MSP ids
+func ValidateCollectionsConfig(pkg *peer.CollectionConfigPackage) error {
+	for _, config := range pkg.GetConfig() {
+		coll := config.GetStaticCollectionConfig()
+		if coll == nil {
+			return errors.New(`collection config is not static collection config`)
+		}
+		if coll.Name == `` {
+			return errors.New(`collection name is empty`)
+		}
+
+		policy := coll.GetMemberOrgsPolicy().GetSignaturePolicy()
+		if policy == nil {
+			return errors.Errorf(`collection %s: member orgs policy is not presented`, coll.Name)
+		}
+		for _, principal := range policy.Identities {
+			if principal.PrincipalClassification != msp.MSPPrincipal_ROLE {
+				continue
+			}
+			role := new(msp.MSPRole)
+			if err := proto.Unmarshal(principal.Principal, role); err != nil {
+				return errors.Wrapf(err, `collection %s: failed to unmarshal member principal`, coll.Name)
+			}
+			if role.MspIdentifier == `` {
+				return errors.Errorf(`collection %s: member org MSP id is empty`, coll.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func newCollectionEndorsementPolicy(policy *CollectionEndorsementPolicy) (*peer.ApplicationPolicy, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	switch {
+	case policy.SignaturePolicy != `` && policy.ChannelConfigPolicy != ``:
+		return nil, errors.New(`endorsement policy must contain either signature or channel config policy, not both`)
+
+	case policy.SignaturePolicy != ``:
+		signaturePolicy, err := policydsl.FromString(policy.SignaturePolicy)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to parse endorsement signature policy`)
+		}
+		return &peer.ApplicationPolicy{
+			Type: &peer.ApplicationPolicy_SignaturePolicy{SignaturePolicy: signaturePolicy},
+		}, nil
+
+	case policy.ChannelConfigPolicy != ``:
+		return &peer.ApplicationPolicy{
+			Type: &peer.ApplicationPolicy_ChannelConfigPolicyReference{ChannelConfigPolicyReference: policy.ChannelConfigPolicy},
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCollectionsConfig(t *testing.T) {
+	pkg, err := ParseCollectionsConfig([]byte(`[
+ {
+   "name": "collectionMarbles",
+   "policy": "OR('Org1MSP.member', 'Org2MSP.member')",
+   "requiredPeerCount": 0,
+   "maxPeerCount": 3,
+   "blockToLive": 1000000,
+   "memberOnlyRead": true,
+   "endorsementPolicy": {"signaturePolicy": "OR('Org1MSP.member')"}
+ }
+]`))
+	require.NoError(t, err)
+	require.Len(t, pkg.Config, 1)
+
+	coll := pkg.Config[0].GetStaticCollectionConfig()
+	require.Equal(t, `collectionMarbles`, coll.Name)
+	require.Equal(t, int32(3), coll.MaximumPeerCount)
+	require.Equal(t, uint64(1000000), coll.BlockToLive)
+	require.True(t, coll.MemberOnlyRead)
+	require.Len(t, coll.MemberOrgsPolicy.GetSignaturePolicy().Identities, 2)
+	require.NotNil(t, coll.EndorsementPolicy.GetSignaturePolicy())
+
+	_, err = ParseCollectionsConfig([]byte(`
+- name: coll
+  policy: OR('Org1MSP.member')
+  requiredPeerCount: 2
+  maxPeerCount: 1
+`))
+	require.Error(t, err)
+
+	_, err = ParseCollectionsConfig([]byte(`
+- name: coll
+  policy: OR('Org1MSP.member'
+`))
+	require.Error(t, err)
+}
+
+func TestNewCollectionConfigPackage_MemberOrgs(t *testing.T) {
+	pkg, err := NewCollectionConfigPackage(CollectionDefinition{
+		Name:         `private`,
+		MemberOrgs:   []string{`Org1MSP`, `Org2MSP`},
+		MaxPeerCount: 1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ValidateCollectionsConfig(pkg))
+	require.Len(t, pkg.Config[0].GetStaticCollectionConfig().MemberOrgsPolicy.GetSignaturePolicy().Identities, 2)
+
+	_, err = NewCollectionConfigPackage(CollectionDefinition{Name: `private`, MemberOrgs: []string{`Org1MSP`, ``}})
+	require.Error(t, err)
+
+	// policy with empty MSP id is rejected before sending
+	pkg, err = NewCollectionConfigPackage(CollectionDefinition{Name: `private`, Policy: `OR('Org1MSP.member')`})
+	require.NoError(t, err)
+	pkg.Config[0].GetStaticCollectionConfig().MemberOrgsPolicy.GetSignaturePolicy().Identities[0].Principal = nil
+	require.Error(t, ValidateCollectionsConfig(pkg))
+}
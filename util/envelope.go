@@ -132,3 +132,26 @@ func GetEventFromEnvelope(envelopeData []byte) (*peer.ChaincodeEvent, error) {
 		}
 	}
 }
+
+// GetEventFromProposalResponse returns chaincode event from endorsed proposal response, nil if no event was emitted
+func GetEventFromProposalResponse(resp *peer.ProposalResponse) (*peer.ChaincodeEvent, error) {
+	propRespPayload, err := protoutil.UnmarshalProposalResponsePayload(resp.GetPayload())
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get proposal response payload`)
+	}
+
+	ccAction, err := protoutil.UnmarshalChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get chaincode action`)
+	}
+
+	event, err := protoutil.UnmarshalChaincodeEvents(ccAction.Events)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get events`)
+	}
+
+	if event.GetEventName() == `` {
+		return nil, nil
+	}
+	return event, nil
+}
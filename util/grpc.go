@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"time"
@@ -17,10 +18,15 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -38,6 +44,11 @@ var (
 const (
 	maxRecvMsgSize = 100 * 1024 * 1024
 	maxSendMsgSize = 100 * 1024 * 1024
+
+	// DefaultClientID is used as client id if it's not presented in connection config
+	DefaultClientID = `hlf-sdk-go`
+	// ClientIDHeader is metadata key of client id sent with each call
+	ClientIDHeader = `x-client-id`
 )
 
 func NewGRPCOptionsFromConfig(c config.ConnectionConfig, log *zap.Logger) ([]grpc.DialOption, error) {
@@ -56,11 +67,18 @@ func NewGRPCOptionsFromConfig(c config.ConnectionConfig, log *zap.Logger) ([]grp
 		var err error
 		var tlsCfg tls.Config
 		tlsCfg.InsecureSkipVerify = c.Tls.SkipVerify
+		if c.Tls.HostOverride != `` {
+			// server certificate is verified against overridden name, which is also used as authority
+			tlsCfg.ServerName = c.Tls.HostOverride
+			grpcOptions = append(grpcOptions, grpc.WithAuthority(c.Tls.HostOverride))
+		}
 		// if custom CA certificate is presented, use it
-		if c.Tls.CACertPath != `` {
-			caCert, err := ioutil.ReadFile(c.Tls.CACertPath)
-			if err != nil {
-				return nil, errors.Wrap(err, `failed to read CA certificate`)
+		if c.Tls.CACertPEM != `` || c.Tls.CACertPath != `` {
+			caCert := []byte(c.Tls.CACertPEM)
+			if len(caCert) == 0 {
+				if caCert, err = ioutil.ReadFile(c.Tls.CACertPath); err != nil {
+					return nil, errors.Wrap(err, `failed to read CA certificate`)
+				}
 			}
 			certPool := x509.NewCertPool()
 			if ok := certPool.AppendCertsFromPEM(caCert); !ok {
@@ -73,7 +91,13 @@ func NewGRPCOptionsFromConfig(c config.ConnectionConfig, log *zap.Logger) ([]grp
 				return nil, errors.Wrap(err, `failed to get system cert pool`)
 			}
 		}
-		if c.Tls.CertPath != `` {
+		if c.Tls.CertPEM != `` && c.Tls.KeyPEM != `` {
+			cert, err := tls.X509KeyPair([]byte(c.Tls.CertPEM), []byte(c.Tls.KeyPEM))
+			if err != nil {
+				return nil, errors.Wrap(err, `failed to parse client certificate`)
+			}
+			tlsCfg.Certificates = append(tlsCfg.Certificates, cert)
+		} else if c.Tls.CertPath != `` {
 			// use mutual tls if certificate and pk is presented
 			if c.Tls.KeyPath != `` {
 				cert, err := tls.LoadX509KeyPair(c.Tls.CertPath, c.Tls.KeyPath)
@@ -120,19 +144,53 @@ func NewGRPCOptionsFromConfig(c config.ConnectionConfig, log *zap.Logger) ([]grp
 		),
 	)
 
+	clientID := c.GRPC.ClientID
+	if clientID == `` {
+		clientID = DefaultClientID
+	}
+	grpcOptions = append(grpcOptions,
+		grpc.WithUserAgent(clientID),
+		grpc.WithChainUnaryInterceptor(clientIDUnaryInterceptor(clientID)),
+		grpc.WithChainStreamInterceptor(clientIDStreamInterceptor(clientID)),
+	)
+
+	recvMsgSize, sendMsgSize := c.GRPC.MaxRecvMsgSize, c.GRPC.MaxSendMsgSize
+	if recvMsgSize <= 0 {
+		recvMsgSize = maxRecvMsgSize
+	}
+	if sendMsgSize <= 0 {
+		sendMsgSize = maxSendMsgSize
+	}
 	grpcOptions = append(grpcOptions, grpc.WithDefaultCallOptions(
-		grpc.MaxCallRecvMsgSize(maxRecvMsgSize),
-		grpc.MaxCallSendMsgSize(maxSendMsgSize),
+		grpc.MaxCallRecvMsgSize(recvMsgSize),
+		grpc.MaxCallSendMsgSize(sendMsgSize),
 	))
 
+	if c.GRPC.RetryPolicy != nil {
+		serviceConfig, err := RetryServiceConfig(*c.GRPC.RetryPolicy)
+		if err != nil {
+			return nil, err
+		}
+		grpcOptions = append(grpcOptions, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+
 	fields := []zap.Field{
 		zap.String(`host`, c.Host),
 		zap.Bool(`tls`, c.Tls.Enabled),
 		zap.Reflect(`keep alive`, c.GRPC.KeepAlive),
 		zap.Reflect(`retry`, retryConfig),
+		zap.Reflect(`retry policy`, c.GRPC.RetryPolicy),
+		zap.Int(`max recv msg size`, recvMsgSize),
+		zap.Int(`max send msg size`, sendMsgSize),
+		zap.String(`client id`, clientID),
 	}
 	if c.Tls.Enabled {
-		fields = append(fields, zap.Reflect(`retry`, c.Tls))
+		tlsConfig := c.Tls
+		// private key must not be logged
+		if tlsConfig.KeyPEM != `` {
+			tlsConfig.KeyPEM = `***`
+		}
+		fields = append(fields, zap.Reflect(`retry`, tlsConfig))
 	}
 
 	log.Debug(`grpc options for host`, fields...)
@@ -141,6 +199,62 @@ func NewGRPCOptionsFromConfig(c config.ConnectionConfig, log *zap.Logger) ([]grp
 	return grpcOptions, nil
 }
 
+// RetryServices are Fabric GRPC services which calls are retried according to retry policy of service config
+var RetryServices = []string{`protos.Endorser`, `protos.Deliver`, `discovery.Discovery`, `gateway.Gateway`}
+
+// RetryServiceConfig returns GRPC service config JSON with retry policy for RetryServices
+func RetryServiceConfig(policy config.GRPCRetryPolicyConfig) (string, error) {
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.InitialBackoff.Duration == 0 {
+		policy.InitialBackoff.Duration = 100 * time.Millisecond
+	}
+	if policy.MaxBackoff.Duration == 0 {
+		policy.MaxBackoff.Duration = time.Second
+	}
+	if policy.BackoffMultiplier == 0 {
+		policy.BackoffMultiplier = 2
+	}
+	if len(policy.RetryableStatusCodes) == 0 {
+		policy.RetryableStatusCodes = []string{`UNAVAILABLE`}
+	}
+
+	names := make([]map[string]string, len(RetryServices))
+	for i, service := range RetryServices {
+		names[i] = map[string]string{`service`: service}
+	}
+
+	serviceConfig, err := json.Marshal(map[string]interface{}{
+		`methodConfig`: []map[string]interface{}{{
+			`name`: names,
+			`retryPolicy`: map[string]interface{}{
+				`maxAttempts`:          policy.MaxAttempts,
+				`initialBackoff`:       fmt.Sprintf(`%gs`, policy.InitialBackoff.Seconds()),
+				`maxBackoff`:           fmt.Sprintf(`%gs`, policy.MaxBackoff.Seconds()),
+				`backoffMultiplier`:    policy.BackoffMultiplier,
+				`retryableStatusCodes`: policy.RetryableStatusCodes,
+			},
+		}},
+	})
+	if err != nil {
+		return ``, errors.Wrap(err, `failed to marshal service config`)
+	}
+	return string(serviceConfig), nil
+}
+
+func clientIDUnaryInterceptor(clientID string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, ClientIDHeader, clientID), method, req, reply, cc, opts...)
+	}
+}
+
+func clientIDStreamInterceptor(clientID string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(metadata.AppendToOutgoingContext(ctx, ClientIDHeader, clientID), desc, cc, method, opts...)
+	}
+}
+
 func NewGRPCConnectionFromConfigs(ctx context.Context, log *zap.Logger, conf ...config.ConnectionConfig) (*grpc.ClientConn, error) {
 	// use options from first config
 	opts, err := NewGRPCOptionsFromConfig(conf[0], log)
@@ -170,3 +284,43 @@ func NewGRPCConnectionFromConfigs(ctx context.Context, log *zap.Logger, conf ...
 
 	return conn, nil
 }
+
+// WaitForReady waits until GRPC connection becomes ready or context is done
+func WaitForReady(ctx context.Context, conn *grpc.ClientConn) error {
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if state == connectivity.Shutdown {
+			return errors.New(`connection is shut down`)
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf(`connection state %s: %w`, state, ctx.Err())
+		}
+	}
+}
+
+// Ping makes round trip to server of connection using standard GRPC health service and returns its latency.
+// If server doesn't implement health service, it is considered alive when it responds and connection is ready
+func Ping(ctx context.Context, conn *grpc.ClientConn) (time.Duration, error) {
+	started := time.Now()
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	latency := time.Since(started)
+
+	switch {
+	case err == nil:
+		if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+			return latency, errors.Errorf(`health status %s`, resp.Status)
+		}
+		return latency, nil
+
+	case status.Code(err) == codes.Unimplemented:
+		if state := conn.GetState(); state != connectivity.Ready {
+			return latency, errors.Errorf(`connection state %s`, state)
+		}
+		return latency, nil
+	}
+
+	return latency, errors.Wrap(err, `failed to check health`)
+}
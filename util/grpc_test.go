@@ -2,9 +2,14 @@ package util
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"io/ioutil"
+	"math/big"
 	"net"
 	"path"
 	"path/filepath"
@@ -18,6 +23,8 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	testpb "google.golang.org/grpc/test/grpc_testing"
 )
 
@@ -111,6 +118,45 @@ func TestNewGRPCOptionsFromConfig(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPing(t *testing.T) {
+	serve := func(register func(srv *grpc.Server)) *grpc.ClientConn {
+		lis, err := net.Listen(`tcp4`, `:`)
+		assert.NoError(t, err)
+		srv := grpc.NewServer()
+		register(srv)
+		go func() {
+			_ = srv.Serve(lis)
+		}()
+		t.Cleanup(srv.Stop)
+
+		conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+		assert.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		return conn
+	}
+
+	pingCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	healthSrv := health.NewServer()
+	withHealth := serve(func(srv *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(srv, healthSrv)
+	})
+	_, err := Ping(pingCtx, withHealth)
+	assert.NoError(t, err)
+
+	healthSrv.SetServingStatus(``, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	_, err = Ping(pingCtx, withHealth)
+	assert.Error(t, err)
+
+	// server without health service is alive if it responds
+	withoutHealth := serve(func(srv *grpc.Server) {
+		testpb.RegisterTestServiceServer(srv, &testServer{})
+	})
+	_, err = Ping(pingCtx, withoutHealth)
+	assert.NoError(t, err)
+}
+
 func init() {
 	var err error
 
@@ -175,3 +221,105 @@ func getLocalAddress(lis net.Listener) string {
 	addr := lis.Addr().String()
 	return `localhost:` + strings.Split(addr, `:`)[1]
 }
+
+func TestRetryServiceConfig(t *testing.T) {
+	serviceConfig, err := RetryServiceConfig(config.GRPCRetryPolicyConfig{MaxAttempts: 4})
+	assert.NoError(t, err)
+	assert.Contains(t, serviceConfig, `"maxAttempts":4`)
+	assert.Contains(t, serviceConfig, `"initialBackoff":"0.1s"`)
+	assert.Contains(t, serviceConfig, `"retryableStatusCodes":["UNAVAILABLE"]`)
+
+	// service config is validated by grpc on dial
+	conn, err := grpc.Dial(getLocalAddress(nonTlsListener), grpc.WithInsecure(), grpc.WithDefaultServiceConfig(serviceConfig))
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+}
+
+func TestMutualTLSWithInlineCert(t *testing.T) {
+	newCert := func(tmpl *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, []byte, *x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		if parent == nil {
+			parent, parentKey = tmpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+		assert.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		assert.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		assert.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: `EC PRIVATE KEY`, Bytes: keyDER}), cert, key
+	}
+
+	validity := func(serial int64) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+	}
+
+	caTmpl := validity(1)
+	caTmpl.IsCA, caTmpl.BasicConstraintsValid = true, true
+	caTmpl.KeyUsage = x509.KeyUsageCertSign
+	caPEM, _, ca, caKey := newCert(caTmpl, nil, nil)
+
+	serverTmpl := validity(2)
+	serverTmpl.DNSNames = []string{`localhost`}
+	serverTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	serverCertPEM, serverKeyPEM, _, _ := newCert(serverTmpl, ca, caKey)
+
+	clientTmpl := validity(3)
+	clientTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	clientCertPEM, clientKeyPEM, _, _ := newCert(clientTmpl, ca, caKey)
+
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	assert.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	lis, err := net.Listen(`tcp4`, `:`)
+	assert.NoError(t, err)
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+	})))
+	testpb.RegisterTestServiceServer(srv, &testServer{})
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	defer srv.Stop()
+
+	call := func(host string, tlsConfig config.TlsConfig) error {
+		connConfig := config.ConnectionConfig{Host: host, Tls: tlsConfig}
+		opts, err := NewGRPCOptionsFromConfig(connConfig, log)
+		assert.NoError(t, err)
+
+		dialCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(dialCtx, connConfig.Host, opts...)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = testpb.NewTestServiceClient(conn).EmptyCall(dialCtx, &testpb.Empty{})
+		return err
+	}
+
+	mutualTLS := config.TlsConfig{
+		Enabled:   true,
+		CACertPEM: string(caPEM),
+		CertPEM:   string(clientCertPEM),
+		KeyPEM:    string(clientKeyPEM),
+	}
+
+	assert.Error(t, call(getLocalAddress(lis), config.TlsConfig{Enabled: true, CACertPEM: string(caPEM)}))
+	assert.NoError(t, call(getLocalAddress(lis), mutualTLS))
+
+	// server certificate has no IP SAN, so name from certificate must be presented to dial by IP
+	assert.Error(t, call(lis.Addr().String(), mutualTLS))
+	mutualTLS.HostOverride = `localhost`
+	assert.NoError(t, call(lis.Addr().String(), mutualTLS))
+}
@@ -0,0 +1,169 @@
+package util
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"github.com/s7techlab/hlf-sdk-go/util/txflags"
+)
+
+const (
+	lifecycleName           = `_lifecycle`
+	lifecycleCommitFuncName = `CommitChaincodeDefinition`
+)
+
+// ChaincodeDefinitionCommit is chaincode definition committed by valid _lifecycle transaction
+type ChaincodeDefinitionCommit struct {
+	BlockNumber uint64
+	TxID        string
+	Timestamp   time.Time
+	Definition  *lb.CommitChaincodeDefinitionArgs
+}
+
+// ScanChaincodeDefinitionHistory fetches blocks from start to end (inclusive) and calls fn for each chaincode
+// definition committed in them in order of sequence. Scan stops on first error returned by fn
+func ScanChaincodeDefinitionHistory(ctx context.Context, deliver api.DeliverClient, channelName, ccName string,
+	start, end uint64, fn func(ChaincodeDefinitionCommit) error) error {
+	if start > end {
+		return errors.Errorf("invalid block range: %d > %d", start, end)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sub, err := deliver.SubscribeBlock(ctx, channelName, api.SeekRange(start, end))
+	if err != nil {
+		return errors.Wrap(err, `failed to subscribe on blocks`)
+	}
+	defer sub.Close()
+
+	for scanned := uint64(0); scanned < end-start+1; scanned++ {
+		select {
+		case block, ok := <-sub.Blocks():
+			if !ok {
+				return errors.New(`block stream closed`)
+			}
+			commits, err := GetChaincodeDefinitionCommits(block, ccName)
+			if err != nil {
+				return errors.Wrapf(err, `failed to parse block %d`, block.GetHeader().GetNumber())
+			}
+			for _, commit := range commits {
+				if err = fn(commit); err != nil {
+					return err
+				}
+			}
+		case err, ok := <-sub.Errors():
+			if ok {
+				return errors.Wrap(err, `failed to get block`)
+			}
+			return errors.New(`block stream closed`)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// GetChaincodeDefinitionCommits returns definitions of chaincode committed by valid transactions of block
+func GetChaincodeDefinitionCommits(block *common.Block, ccName string) ([]ChaincodeDefinitionCommit, error) {
+	var flags txflags.ValidationFlags
+	if len(block.GetMetadata().GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+
+	var commits []ChaincodeDefinitionCommit
+	for i, envBytes := range block.GetData().GetData() {
+		if i < len(flags) && !flags.IsValid(i) {
+			continue
+		}
+
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to get envelope`)
+		}
+
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to get payload`)
+		}
+
+		chHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to unmarshal channel header`)
+		}
+
+		if common.HeaderType(chHeader.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+
+		definition, err := getCommittedDefinition(payload.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, `tx %s`, chHeader.TxId)
+		}
+		if definition == nil || definition.Name != ccName {
+			continue
+		}
+
+		commit := ChaincodeDefinitionCommit{
+			BlockNumber: block.GetHeader().GetNumber(),
+			TxID:        chHeader.TxId,
+			Definition:  definition,
+		}
+		if chHeader.Timestamp != nil {
+			commit.Timestamp, _ = ptypes.Timestamp(chHeader.Timestamp)
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
+// getCommittedDefinition returns args of _lifecycle CommitChaincodeDefinition invocation from transaction data,
+// nil is returned for other invocations
+func getCommittedDefinition(txData []byte) (*lb.CommitChaincodeDefinitionArgs, error) {
+	tx, err := protoutil.UnmarshalTransaction(txData)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal transaction`)
+	}
+
+	for _, action := range tx.Actions {
+		actionPayload, err := protoutil.UnmarshalChaincodeActionPayload(action.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to unmarshal chaincode action payload`)
+		}
+
+		proposalPayload, err := protoutil.UnmarshalChaincodeProposalPayload(actionPayload.ChaincodeProposalPayload)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to unmarshal chaincode proposal payload`)
+		}
+
+		spec := new(peer.ChaincodeInvocationSpec)
+		if err = proto.Unmarshal(proposalPayload.Input, spec); err != nil {
+			return nil, errors.Wrap(err, `failed to unmarshal chaincode invocation spec`)
+		}
+
+		args := spec.GetChaincodeSpec().GetInput().GetArgs()
+		if spec.GetChaincodeSpec().GetChaincodeId().GetName() != lifecycleName ||
+			len(args) < 2 || string(args[0]) != lifecycleCommitFuncName {
+			continue
+		}
+
+		definition := new(lb.CommitChaincodeDefinitionArgs)
+		if err = proto.Unmarshal(args[1], definition); err != nil {
+			return nil, errors.Wrap(err, `failed to unmarshal chaincode definition`)
+		}
+		return definition, nil
+	}
+
+	return nil, nil
+}
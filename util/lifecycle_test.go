@@ -0,0 +1,66 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/stretchr/testify/require"
+)
+
+func lifecycleCommitTx(t *testing.T, txID string, definition *lb.CommitChaincodeDefinitionArgs) []byte {
+	arg, err := proto.Marshal(definition)
+	require.NoError(t, err)
+
+	input, err := proto.Marshal(&peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{
+		ChaincodeId: &peer.ChaincodeID{Name: lifecycleName},
+		Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte(lifecycleCommitFuncName), arg}},
+	}})
+	require.NoError(t, err)
+
+	proposalPayload, err := proto.Marshal(&peer.ChaincodeProposalPayload{Input: input})
+	require.NoError(t, err)
+
+	actionPayload, err := proto.Marshal(&peer.ChaincodeActionPayload{ChaincodeProposalPayload: proposalPayload})
+	require.NoError(t, err)
+
+	tx, err := proto.Marshal(&peer.Transaction{Actions: []*peer.TransactionAction{{Payload: actionPayload}}})
+	require.NoError(t, err)
+
+	chHeader, err := proto.Marshal(&common.ChannelHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION), TxId: txID})
+	require.NoError(t, err)
+
+	payload, err := proto.Marshal(&common.Payload{Header: &common.Header{ChannelHeader: chHeader}, Data: tx})
+	require.NoError(t, err)
+
+	env, err := proto.Marshal(&common.Envelope{Payload: payload})
+	require.NoError(t, err)
+	return env
+}
+
+func TestGetChaincodeDefinitionCommits(t *testing.T) {
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 5},
+		Data: &common.BlockData{Data: [][]byte{
+			lifecycleCommitTx(t, `tx1`, &lb.CommitChaincodeDefinitionArgs{Name: `mycc`, Sequence: 2, Version: `1.1`}),
+			lifecycleCommitTx(t, `tx2`, &lb.CommitChaincodeDefinitionArgs{Name: `mycc`, Sequence: 3, Version: `1.2`}),
+			lifecycleCommitTx(t, `tx3`, &lb.CommitChaincodeDefinitionArgs{Name: `othercc`, Sequence: 1}),
+		}},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{
+			common.BlockMetadataIndex_SIGNATURES:  {},
+			common.BlockMetadataIndex_LAST_CONFIG: {},
+			common.BlockMetadataIndex_TRANSACTIONS_FILTER: {
+				uint8(peer.TxValidationCode_VALID), uint8(peer.TxValidationCode_MVCC_READ_CONFLICT), uint8(peer.TxValidationCode_VALID)},
+		}},
+	}
+
+	commits, err := GetChaincodeDefinitionCommits(block, `mycc`)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, uint64(5), commits[0].BlockNumber)
+	require.Equal(t, `tx1`, commits[0].TxID)
+	require.Equal(t, int64(2), commits[0].Definition.Sequence)
+	require.Equal(t, `1.1`, commits[0].Definition.Version)
+}
@@ -0,0 +1,158 @@
+package util
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+// MembershipChange describes changes of channel application organizations introduced by config block
+type MembershipChange struct {
+	BlockNumber    uint64
+	ConfigSequence uint64
+	// Added and Removed contain MSP ids of organizations
+	Added   []string
+	Removed []string
+	// Members contains MSP ids of all application organizations after change
+	Members []string
+}
+
+// MembershipHandler receives membership changes, returned error stops scanning
+type MembershipHandler func(change MembershipChange) error
+
+// ScanMembershipChanges walks channel config blocks from the latest one to genesis block following last config
+// metadata of blocks and passes changes of application organizations to handler, newest change first.
+// Config blocks which doesn't change membership are skipped.
+// At most maxConfigBlocks config blocks are fetched if maxConfigBlocks > 0,
+// oldest fetched config block is used only as a base for comparison in that case
+func ScanMembershipChanges(ctx context.Context, id msp.SigningIdentity, orderer api.Orderer, channelName string, maxConfigBlocks uint64, handler MembershipHandler) error {
+	lastBlock, err := fetchBlock(ctx, id, orderer, channelName, api.SeekNewest())
+	if err != nil {
+		return errors.Wrap(err, `failed to fetch last block`)
+	}
+
+	configNum, err := protoutil.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return errors.Wrap(err, `failed to get last config index`)
+	}
+
+	var (
+		newer   *channelMembers
+		fetched uint64
+	)
+
+	for {
+		configBlock, err := fetchBlock(ctx, id, orderer, channelName, api.SeekSingle(configNum))
+		if err != nil {
+			return errors.Wrapf(err, `failed to fetch config block %d`, configNum)
+		}
+		fetched++
+
+		members, err := getChannelMembers(configBlock)
+		if err != nil {
+			return errors.Wrapf(err, `failed to get members from config block %d`, configNum)
+		}
+
+		if newer != nil {
+			if change, ok := newer.diff(members); ok {
+				if err = handler(change); err != nil {
+					return err
+				}
+			}
+		}
+
+		if configNum == 0 {
+			if change, ok := members.diff(&channelMembers{}); ok {
+				return handler(change)
+			}
+			return nil
+		}
+
+		if maxConfigBlocks > 0 && fetched >= maxConfigBlocks {
+			return nil
+		}
+
+		// previous block contains index of previous config block
+		prevBlock, err := fetchBlock(ctx, id, orderer, channelName, api.SeekSingle(configNum-1))
+		if err != nil {
+			return errors.Wrapf(err, `failed to fetch block %d`, configNum-1)
+		}
+
+		if configNum, err = protoutil.GetLastConfigIndexFromBlock(prevBlock); err != nil {
+			return errors.Wrapf(err, `failed to get last config index from block %d`, prevBlock.Header.Number)
+		}
+		newer = members
+	}
+}
+
+type channelMembers struct {
+	blockNumber    uint64
+	configSequence uint64
+	mspIDs         map[string]struct{}
+}
+
+// diff returns membership change of c relative to older members
+func (c *channelMembers) diff(older *channelMembers) (MembershipChange, bool) {
+	change := MembershipChange{
+		BlockNumber:    c.blockNumber,
+		ConfigSequence: c.configSequence,
+	}
+
+	for mspID := range c.mspIDs {
+		change.Members = append(change.Members, mspID)
+		if _, ok := older.mspIDs[mspID]; !ok {
+			change.Added = append(change.Added, mspID)
+		}
+	}
+	for mspID := range older.mspIDs {
+		if _, ok := c.mspIDs[mspID]; !ok {
+			change.Removed = append(change.Removed, mspID)
+		}
+	}
+
+	sort.Strings(change.Members)
+	sort.Strings(change.Added)
+	sort.Strings(change.Removed)
+
+	return change, len(change.Added) > 0 || len(change.Removed) > 0
+}
+
+func getChannelMembers(block *common.Block) (*channelMembers, error) {
+	conf, err := GetConfigFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	members := &channelMembers{
+		blockNumber:    block.Header.Number,
+		configSequence: conf.Sequence,
+		mspIDs:         make(map[string]struct{}),
+	}
+
+	mspIDs, err := GetApplicationMSPIDsFromChannelConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	for _, mspID := range mspIDs {
+		members.mspIDs[mspID] = struct{}{}
+	}
+
+	return members, nil
+}
+
+func fetchBlock(ctx context.Context, id msp.SigningIdentity, orderer api.Orderer, channelName string, seekOpt api.EventCCSeekOption) (*common.Block, error) {
+	startPos, endPos := seekOpt()
+
+	seekEnvelope, err := SeekEnvelope(channelName, startPos, endPos, id)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to create seek envelope`)
+	}
+
+	return orderer.Deliver(ctx, seekEnvelope)
+}
@@ -12,34 +12,62 @@ import (
 
 // GetConfigBlockFromOrderer returns config block from orderer by channel name
 func GetConfigBlockFromOrderer(ctx context.Context, id msp.SigningIdentity, orderer api.Orderer, channelName string) (*common.Block, error) {
-	startPos, endPos := api.SeekNewest()()
+	blockId, err := GetLastConfigIndexFromOrderer(ctx, id, orderer, channelName)
+	if err != nil {
+		return nil, err
+	}
 
-	seekEnvelope, err := SeekEnvelope(channelName, startPos, endPos, id)
+	configBlock, err := GetBlockFromOrderer(ctx, id, orderer, channelName, blockId)
 	if err != nil {
-		return nil, errors.Wrap(err, `failed to create seek envelope`)
+		return nil, errors.Wrap(err, `failed to fetch block with config`)
 	}
+	return configBlock, nil
+}
 
-	lastBlock, err := orderer.Deliver(ctx, seekEnvelope)
+// GetLastConfigIndexFromOrderer returns number of last config block of channel
+func GetLastConfigIndexFromOrderer(ctx context.Context, id msp.SigningIdentity, orderer api.Orderer, channelName string) (uint64, error) {
+	lastBlock, err := getBlockFromOrderer(ctx, id, orderer, channelName, api.SeekNewest())
 	if err != nil {
-		return nil, errors.Wrap(err, `failed to fetch last block`)
+		return 0, errors.Wrap(err, `failed to fetch last block`)
 	}
 
 	blockId, err := protoutil.GetLastConfigIndexFromBlock(lastBlock)
 	if err != nil {
-		return nil, errors.Wrap(err, `failed to fetch block id with config`)
+		return 0, errors.Wrap(err, `failed to fetch block id with config`)
 	}
+	return blockId, nil
+}
 
-	startPos, endPos = api.SeekSingle(blockId)()
+// GetConfigIndexAtHeightFromOrderer returns number of config block which is in effect for block with presented number,
+// i.e. last config block preceding it. Genesis block is in effect for block 0
+func GetConfigIndexAtHeightFromOrderer(ctx context.Context, id msp.SigningIdentity, orderer api.Orderer, channelName string, number uint64) (uint64, error) {
+	if number == 0 {
+		return 0, nil
+	}
 
-	seekEnvelope, err = SeekEnvelope(channelName, startPos, endPos, id)
+	prevBlock, err := GetBlockFromOrderer(ctx, id, orderer, channelName, number-1)
 	if err != nil {
-		return nil, errors.Wrap(err, `failed to create seek envelope`)
+		return 0, errors.Wrapf(err, `failed to fetch block %d`, number-1)
 	}
 
-	configBlock, err := orderer.Deliver(ctx, seekEnvelope)
+	blockId, err := protoutil.GetLastConfigIndexFromBlock(prevBlock)
 	if err != nil {
-		return nil, errors.Wrap(err, `failed to fetch block with config`)
+		return 0, errors.Wrap(err, `failed to fetch block id with config`)
 	}
+	return blockId, nil
+}
 
-	return configBlock, nil
+// GetBlockFromOrderer returns block with presented number from orderer
+func GetBlockFromOrderer(ctx context.Context, id msp.SigningIdentity, orderer api.Orderer, channelName string, number uint64) (*common.Block, error) {
+	return getBlockFromOrderer(ctx, id, orderer, channelName, api.SeekSingle(number))
+}
+
+func getBlockFromOrderer(ctx context.Context, id msp.SigningIdentity, orderer api.Orderer, channelName string, seek api.EventCCSeekOption) (*common.Block, error) {
+	startPos, endPos := seek()
+
+	seekEnvelope, err := SeekEnvelope(channelName, startPos, endPos, id)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to create seek envelope`)
+	}
+	return orderer.Deliver(ctx, seekEnvelope)
 }
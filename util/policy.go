@@ -1,10 +1,17 @@
 package util
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/pkg/errors"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
 )
 
 func GetMSPFromPolicy(policy string) ([]string, error) {
@@ -26,3 +33,219 @@ func GetMSPFromPolicy(policy string) ([]string, error) {
 
 	return mspIds, nil
 }
+
+// GetCollectionsEndorsers returns organizations from chaincode endorsement policy which are members
+// of all presented private data collections
+func GetCollectionsEndorsers(cc *api.DiscoveryChaincode, collections []string) ([]string, error) {
+	endorsers, err := GetMSPFromPolicy(cc.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range collections {
+		var collection *api.DiscoveryCollection
+		for i := range cc.Collections {
+			if cc.Collections[i].Name == name {
+				collection = &cc.Collections[i]
+			}
+		}
+		if collection == nil {
+			return nil, errors.Errorf(`collection %s not found in chaincode %s definition`, name, cc.Name)
+		}
+
+		members, err := GetMSPFromPolicy(collection.Policy)
+		if err != nil {
+			return nil, errors.Wrapf(err, `failed to get members of collection %s`, name)
+		}
+
+		endorsers = intersect(endorsers, members)
+	}
+
+	if len(endorsers) == 0 {
+		return nil, errors.Errorf(`no endorsers are members of collections %s`, strings.Join(collections, `, `))
+	}
+	return endorsers, nil
+}
+
+// CheckEndorsementPolicy checks that endorsements of proposal responses satisfy endorsement policy.
+// Only organizations of endorsers are checked, roles and signatures are verified by committing peers.
+// api.EndorsementPolicyError is returned if policy is not satisfied
+func CheckEndorsementPolicy(policy string, responses []*peer.ProposalResponse) error {
+	policyEnvelope, principals, err := parsePolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	var endorsers []string
+	for _, resp := range responses {
+		if resp.GetEndorsement() == nil {
+			continue
+		}
+		endorser := new(msp.SerializedIdentity)
+		if err = proto.Unmarshal(resp.Endorsement.Endorser, endorser); err != nil {
+			return errors.Wrap(err, `failed to unmarshal endorser`)
+		}
+		endorsers = append(endorsers, endorser.Mspid)
+	}
+
+	rule := policyEnvelope.Rule
+	if evaluatePolicy(rule, principals, endorsers, make([]bool, len(endorsers))) {
+		return nil
+	}
+
+	policyErr := api.EndorsementPolicyError{Policy: policy, Need: 1}
+	// for top level n out of rule report how many of unsatisfied sub rules are still needed
+	if nOutOf := rule.GetNOutOf(); nOutOf != nil {
+		used := make([]bool, len(endorsers))
+		satisfied := 0
+		for _, subRule := range nOutOf.Rules {
+			if evaluatePolicy(subRule, principals, endorsers, used) {
+				satisfied++
+			} else {
+				policyErr.From = append(policyErr.From, policyOrgs(subRule, principals)...)
+			}
+		}
+		policyErr.Need = int(nOutOf.N) - satisfied
+	} else {
+		policyErr.From = policyOrgs(rule, principals)
+	}
+	policyErr.From = unique(policyErr.From)
+
+	return policyErr
+}
+
+// SelectEndorsers returns minimal set of presented organizations which endorsements satisfy endorsement policy,
+// nil is returned if policy can't be satisfied by presented organizations
+func SelectEndorsers(policy string, available []string) ([]string, error) {
+	policyEnvelope, principals, err := parsePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := intersect(unique(principals), available)
+	// sets of organizations are checked in order of size, so first satisfying set is minimal
+	for size := 1; size <= len(candidates); size++ {
+		if endorsers := selectCombination(policyEnvelope.Rule, principals, candidates, nil, size); endorsers != nil {
+			return endorsers, nil
+		}
+	}
+	return nil, nil
+}
+
+// selectCombination returns first combination of candidates of presented size
+// which is added to selected organizations and satisfies rule
+func selectCombination(rule *common.SignaturePolicy, principals, candidates, selected []string, size int) []string {
+	if size == 0 {
+		if evaluatePolicy(rule, principals, selected, make([]bool, len(selected))) {
+			return selected
+		}
+		return nil
+	}
+
+	for i := 0; i <= len(candidates)-size; i++ {
+		combination := append(append([]string{}, selected...), candidates[i])
+		if endorsers := selectCombination(rule, principals, candidates[i+1:], combination, size-1); endorsers != nil {
+			return endorsers
+		}
+	}
+	return nil
+}
+
+// parsePolicy parses policy and returns organizations of its principals
+func parsePolicy(policy string) (*common.SignaturePolicyEnvelope, []string, error) {
+	policyEnvelope, err := policydsl.FromString(policy)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, `failed to parse policy`)
+	}
+
+	principals := make([]string, 0, len(policyEnvelope.Identities))
+	for _, id := range policyEnvelope.Identities {
+		role := new(msp.MSPRole)
+		if err = proto.Unmarshal(id.Principal, role); err != nil {
+			return nil, nil, errors.Wrap(err, `failed to get MSP principal`)
+		}
+		principals = append(principals, role.MspIdentifier)
+	}
+	return policyEnvelope, principals, nil
+}
+
+// evaluatePolicy evaluates rule same way as committing peer, each endorsement is used once
+func evaluatePolicy(rule *common.SignaturePolicy, principals, endorsers []string, used []bool) bool {
+	switch r := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if int(r.SignedBy) >= len(principals) {
+			return false
+		}
+		for i, endorser := range endorsers {
+			if !used[i] && endorser == principals[r.SignedBy] {
+				used[i] = true
+				return true
+			}
+		}
+		return false
+
+	case *common.SignaturePolicy_NOutOf_:
+		verified := int32(0)
+		subUsed := make([]bool, len(used))
+		for _, subRule := range r.NOutOf.Rules {
+			copy(subUsed, used)
+			if evaluatePolicy(subRule, principals, endorsers, subUsed) {
+				verified++
+				copy(used, subUsed)
+			}
+		}
+		return verified >= r.NOutOf.N
+	}
+	return false
+}
+
+// policyOrgs returns organizations mentioned in rule
+func policyOrgs(rule *common.SignaturePolicy, principals []string) []string {
+	switch r := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if int(r.SignedBy) < len(principals) {
+			return []string{principals[r.SignedBy]}
+		}
+	case *common.SignaturePolicy_NOutOf_:
+		var orgs []string
+		for _, subRule := range r.NOutOf.Rules {
+			orgs = append(orgs, policyOrgs(subRule, principals)...)
+		}
+		return orgs
+	}
+	return nil
+}
+
+// NewMembersPolicy returns policy which requires signatures of members of all presented organizations
+func NewMembersPolicy(mspIds []string) string {
+	principals := make([]string, 0, len(mspIds))
+	for _, mspId := range mspIds {
+		principals = append(principals, fmt.Sprintf(`'%s.member'`, mspId))
+	}
+	return fmt.Sprintf(`AND(%s)`, strings.Join(principals, `,`))
+}
+
+func intersect(a, b []string) []string {
+	var result []string
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				result = append(result, x)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func unique(values []string) []string {
+	var result []string
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+	return result
+}
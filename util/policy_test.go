@@ -0,0 +1,50 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/s7techlab/hlf-sdk-go/api"
+)
+
+func endorsedBy(t *testing.T, mspIds ...string) []*peer.ProposalResponse {
+	var responses []*peer.ProposalResponse
+	for _, mspId := range mspIds {
+		endorser, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspId})
+		require.NoError(t, err)
+		responses = append(responses, &peer.ProposalResponse{Endorsement: &peer.Endorsement{Endorser: endorser}})
+	}
+	return responses
+}
+
+func TestCheckEndorsementPolicy(t *testing.T) {
+	policy := `OutOf(2, 'Org1MSP.member', 'Org2MSP.member', 'Org3MSP.member')`
+
+	require.NoError(t, CheckEndorsementPolicy(policy, endorsedBy(t, `Org1MSP`, `Org3MSP`)))
+
+	err := CheckEndorsementPolicy(policy, endorsedBy(t, `Org1MSP`, `Org1MSP`))
+	policyErr, ok := err.(api.EndorsementPolicyError)
+	require.True(t, ok)
+	require.Equal(t, 1, policyErr.Need)
+	require.Equal(t, []string{`Org2MSP`, `Org3MSP`}, policyErr.From)
+}
+
+func TestSelectEndorsers(t *testing.T) {
+	policy := `OR(AND('Org1MSP.member', 'Org2MSP.member'), 'Org3MSP.member')`
+
+	endorsers, err := SelectEndorsers(policy, []string{`Org1MSP`, `Org2MSP`, `Org3MSP`})
+	require.NoError(t, err)
+	require.Equal(t, []string{`Org3MSP`}, endorsers)
+
+	endorsers, err = SelectEndorsers(policy, []string{`Org1MSP`, `Org2MSP`})
+	require.NoError(t, err)
+	require.Equal(t, []string{`Org1MSP`, `Org2MSP`}, endorsers)
+
+	endorsers, err = SelectEndorsers(policy, []string{`Org1MSP`})
+	require.NoError(t, err)
+	require.Nil(t, endorsers)
+}
@@ -0,0 +1,136 @@
+package util
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// PvtWrite describes private data collection write of transaction.
+// Key and Value are presented only if private data of collection is delivered to peer,
+// otherwise write contains only hashes from block
+type PvtWrite struct {
+	Namespace  string
+	Collection string
+	Key        string
+	Value      []byte
+	KeyHash    []byte
+	ValueHash  []byte
+	IsDelete   bool
+	HashOnly   bool
+}
+
+// TxPvtWrites contains private data collection writes of block transaction
+type TxPvtWrites struct {
+	TxID    string
+	TxIndex uint64
+	Writes  []PvtWrite
+}
+
+// GetPvtWritesFromBlock returns private data collection writes of block transactions from block and private data
+// received with DeliverWithPrivateData. Writes of collections which private data is missing contain only hashes.
+// Transactions without collection writes are skipped
+func GetPvtWritesFromBlock(blockAndPvt *peer.BlockAndPrivateData) ([]TxPvtWrites, error) {
+	var txsWrites []TxPvtWrites
+
+	for i, envBytes := range blockAndPvt.GetBlock().GetData().GetData() {
+		txIndex := uint64(i)
+
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, `failed to get envelope of tx %d`, txIndex)
+		}
+
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, errors.Wrapf(err, `failed to get payload of tx %d`, txIndex)
+		}
+
+		chHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+		if err != nil {
+			return nil, errors.Wrapf(err, `failed to unmarshal channel header of tx %d`, txIndex)
+		}
+
+		if common.HeaderType(chHeader.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+
+		txRWSet, err := getTxRWSetFromEnvelope(env)
+		if err != nil {
+			return nil, errors.Wrapf(err, `failed to get read write set of tx %d`, txIndex)
+		}
+
+		writes, err := getPvtWrites(txRWSet, blockAndPvt.PrivateDataMap[txIndex])
+		if err != nil {
+			return nil, errors.Wrapf(err, `failed to get private writes of tx %d`, txIndex)
+		}
+
+		if len(writes) > 0 {
+			txsWrites = append(txsWrites, TxPvtWrites{TxID: chHeader.TxId, TxIndex: txIndex, Writes: writes})
+		}
+	}
+
+	return txsWrites, nil
+}
+
+func getPvtWrites(txRWSet *rwset.TxReadWriteSet, pvtRWSet *rwset.TxPvtReadWriteSet) ([]PvtWrite, error) {
+	var writes []PvtWrite
+
+	for _, nsRWSet := range txRWSet.NsRwset {
+		for _, collHashed := range nsRWSet.CollectionHashedRwset {
+			if collPvt := findCollectionPvtRWSet(pvtRWSet, nsRWSet.Namespace, collHashed.CollectionName); collPvt != nil {
+				kvRWSet := new(kvrwset.KVRWSet)
+				if err := proto.Unmarshal(collPvt.Rwset, kvRWSet); err != nil {
+					return nil, errors.Wrapf(err, `failed to unmarshal private read write set of collection %s`, collHashed.CollectionName)
+				}
+
+				for _, w := range kvRWSet.Writes {
+					writes = append(writes, PvtWrite{
+						Namespace:  nsRWSet.Namespace,
+						Collection: collHashed.CollectionName,
+						Key:        w.Key,
+						Value:      w.Value,
+						IsDelete:   w.IsDelete,
+					})
+				}
+				continue
+			}
+
+			hashedRWSet := new(kvrwset.HashedRWSet)
+			if err := proto.Unmarshal(collHashed.HashedRwset, hashedRWSet); err != nil {
+				return nil, errors.Wrapf(err, `failed to unmarshal hashed read write set of collection %s`, collHashed.CollectionName)
+			}
+
+			for _, w := range hashedRWSet.HashedWrites {
+				writes = append(writes, PvtWrite{
+					Namespace:  nsRWSet.Namespace,
+					Collection: collHashed.CollectionName,
+					KeyHash:    w.KeyHash,
+					ValueHash:  w.ValueHash,
+					IsDelete:   w.IsDelete,
+					HashOnly:   true,
+				})
+			}
+		}
+	}
+
+	return writes, nil
+}
+
+func findCollectionPvtRWSet(pvtRWSet *rwset.TxPvtReadWriteSet, namespace, collection string) *rwset.CollectionPvtReadWriteSet {
+	for _, nsPvtRWSet := range pvtRWSet.GetNsPvtRwset() {
+		if nsPvtRWSet.Namespace != namespace {
+			continue
+		}
+		for _, collPvt := range nsPvtRWSet.CollectionPvtRwset {
+			if collPvt.CollectionName == collection {
+				return collPvt
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Write describes public state write of transaction
+type Write struct {
+	// Namespace is name of chaincode which state is written
+	Namespace string
+	Key       string
+	Value     []byte
+	IsDelete  bool
+}
+
+// GetWritesFromProposalResponse returns public state writes from endorsed proposal response
+func GetWritesFromProposalResponse(resp *peer.ProposalResponse) ([]Write, error) {
+	return getWritesFromProposalResponsePayload(resp.GetPayload())
+}
+
+// GetWritesFromEnvelope returns public state writes from endorser transaction envelope
+func GetWritesFromEnvelope(env *common.Envelope) ([]Write, error) {
+	txRWSet, err := getTxRWSetFromEnvelope(env)
+	if err != nil {
+		return nil, err
+	}
+	return getWritesFromTxRWSet(txRWSet)
+}
+
+func getWritesFromProposalResponsePayload(payload []byte) ([]Write, error) {
+	txRWSet, err := getTxRWSetFromProposalResponsePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	return getWritesFromTxRWSet(txRWSet)
+}
+
+func getTxRWSetFromEnvelope(env *common.Envelope) (*rwset.TxReadWriteSet, error) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get payload from envelope`)
+	}
+
+	tx, err := protoutil.UnmarshalTransaction(payload.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get transaction`)
+	}
+
+	if len(tx.Actions) == 0 {
+		return nil, errors.New(`no actions in transaction`)
+	}
+
+	ccActionPayload, err := protoutil.UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get chaincode action payload`)
+	}
+
+	return getTxRWSetFromProposalResponsePayload(ccActionPayload.GetAction().GetProposalResponsePayload())
+}
+
+func getTxRWSetFromProposalResponsePayload(payload []byte) (*rwset.TxReadWriteSet, error) {
+	propRespPayload, err := protoutil.UnmarshalProposalResponsePayload(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get proposal response payload`)
+	}
+
+	ccAction, err := protoutil.UnmarshalChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get chaincode action`)
+	}
+
+	txRWSet := new(rwset.TxReadWriteSet)
+	if err = proto.Unmarshal(ccAction.Results, txRWSet); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal read write set`)
+	}
+
+	return txRWSet, nil
+}
+
+func getWritesFromTxRWSet(txRWSet *rwset.TxReadWriteSet) ([]Write, error) {
+	writes := make([]Write, 0)
+	for _, nsRWSet := range txRWSet.NsRwset {
+		kvRWSet := new(kvrwset.KVRWSet)
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal kv read write set of namespace %s", nsRWSet.Namespace)
+		}
+
+		for _, w := range kvRWSet.Writes {
+			writes = append(writes, Write{
+				Namespace: nsRWSet.Namespace,
+				Key:       w.Key,
+				Value:     w.Value,
+				IsDelete:  w.IsDelete,
+			})
+		}
+	}
+
+	return writes, nil
+}
+
+// GetTxEnvelopeFromBlock returns envelope of transaction with presented id from block
+func GetTxEnvelopeFromBlock(block *common.Block, txID string) (*common.Envelope, error) {
+	for _, envBytes := range block.GetData().GetData() {
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to get envelope`)
+		}
+
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to get payload`)
+		}
+
+		chHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to unmarshal channel header`)
+		}
+
+		if chHeader.TxId == txID {
+			return env, nil
+		}
+	}
+	return nil, errors.Errorf(`tx %s not found in block %d`, txID, block.GetHeader().GetNumber())
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// ServerCertificate performs TLS handshake with host and returns leaf certificate presented by server.
+// Certificate chain isn't verified, so certificate can be inspected even if it is expired or untrusted.
+// Server name is taken from host if presented server name is empty
+func ServerCertificate(ctx context.Context, host, serverName string) (*x509.Certificate, error) {
+	if serverName == `` {
+		serverName, _, _ = net.SplitHostPort(host)
+	}
+
+	dialer := new(net.Dialer)
+	rawConn, err := dialer.DialContext(ctx, `tcp`, host)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to dial`)
+	}
+	defer rawConn.Close()
+
+	// handshake is interrupted by closing connection when context is done
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = rawConn.Close()
+		case <-stop:
+		}
+	}()
+
+	conn := tls.Client(rawConn, &tls.Config{ServerName: serverName, InsecureSkipVerify: true})
+	if err = conn.Handshake(); err != nil {
+		return nil, errors.Wrap(err, `failed to perform TLS handshake`)
+	}
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, errors.New(`server presented no certificates`)
+	}
+	return certs[0], nil
+}
@@ -3,13 +3,22 @@ package util
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/hyperledger/fabric/msp"
 	"github.com/pkg/errors"
 
+	"github.com/s7techlab/hlf-sdk-go/api"
 	"github.com/s7techlab/hlf-sdk-go/crypto"
 )
 
+// StandardTxID is Fabric transaction id scheme
+type StandardTxID struct{}
+
+func (StandardTxID) TxID(nonce, creator []byte) (string, error) {
+	return generateTxId(nonce, creator), nil
+}
+
 // NewTxWithNonce generates new transaction id with crypto nonce
 func NewTxWithNonce(id msp.SigningIdentity) (string, []byte, error) {
 	if nonce, err := crypto.RandomBytes(24); err != nil {
@@ -23,6 +32,37 @@ func NewTxWithNonce(id msp.SigningIdentity) (string, []byte, error) {
 	}
 }
 
+// NewTxWithNonceFrom generates new transaction id with crypto nonce using presented generator,
+// generated id must be hex encoded SHA-256 sized value
+func NewTxWithNonceFrom(id msp.SigningIdentity, generator api.TxIDGenerator) (string, []byte, error) {
+	creator, err := id.Serialize()
+	if err != nil {
+		return ``, nil, errors.Wrap(err, `failed to get creator`)
+	}
+
+	return NewTxWithNonceForCreator(creator, generator)
+}
+
+// NewTxWithNonceForCreator generates new transaction id with crypto nonce for serialized creator identity,
+// so id can be generated without signing identity, e.g. for offline signing
+func NewTxWithNonceForCreator(creator []byte, generator api.TxIDGenerator) (string, []byte, error) {
+	nonce, err := crypto.RandomBytes(24)
+	if err != nil {
+		return ``, nil, errors.Wrap(err, `failed to get nonce`)
+	}
+
+	txId, err := generator.TxID(nonce, creator)
+	if err != nil {
+		return ``, nil, errors.Wrap(err, `failed to generate tx id`)
+	}
+
+	if decoded, err := hex.DecodeString(txId); err != nil || len(decoded) != sha256.Size {
+		return ``, nil, fmt.Errorf(`invalid tx id %q: must be hex encoded %d bytes`, txId, sha256.Size)
+	}
+
+	return txId, nonce, nil
+}
+
 // generateTxId returns SHA-256 hash of nonce and creator concatenation
 func generateTxId(nonce, creator []byte) string {
 	f := sha256.New()